@@ -0,0 +1,147 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var isWithAnniversaries bool
+var anniversaryYears []int
+
+// A contributor whose first contribution was a round number of years before the month
+type anniversary struct {
+	User       string
+	Years      int
+	FirstMonth string
+}
+
+// Adds the flags listing the anniversaries of the first contributions
+func addAnniversariesFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVarP(&isWithAnniversaries, "anniversaries", "", false, "Also lists the contributors whose first contribution was 1, 2 or 5 years before the month")
+	cmd.PersistentFlags().IntSliceVarP(&anniversaryYears, "anniversary-years", "", []int{1, 2, 5}, "Anniversaries (in years) listed with \"--anniversaries\"")
+}
+
+// Returns the name of the anniversaries file written next to the output file
+func generateAnniversariesFilename(outputFilename string) string {
+	return strings.TrimSuffix(outputFilename, filepath.Ext(outputFilename)) + "_anniversaries.csv"
+}
+
+// Returns the contributors whose first contribution was exactly one of the given numbers of
+// years before the month. The contributors active in the first month of the data are ignored,
+// as they may have contributed before.
+func findAnniversariesOfMonth(dataset *Dataset, month string, years []int) ([]anniversary, error) {
+	reference, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid month \"%s\"", month)
+	}
+	yearsOfMonth := make(map[string]int)
+	for _, year := range years {
+		yearsOfMonth[reference.AddDate(-year, 0, 0).Format("2006-01")] = year
+	}
+
+	var anniversaries []anniversary
+	for i, user := range dataset.Users {
+		if user == "deleted_user" {
+			continue
+		}
+		for column, count := range dataset.Counts[i] {
+			if count == 0 {
+				continue
+			}
+			if year, isAnniversary := yearsOfMonth[dataset.Months[column]]; isAnniversary && column > 0 {
+				anniversaries = append(anniversaries, anniversary{User: user, Years: year, FirstMonth: dataset.Months[column]})
+			}
+			break
+		}
+	}
+
+	// The longest commitments first
+	sort.SliceStable(anniversaries, func(i, j int) bool {
+		if anniversaries[i].Years != anniversaries[j].Years {
+			return anniversaries[i].Years > anniversaries[j].Years
+		}
+		return strings.ToLower(anniversaries[i].User) < strings.ToLower(anniversaries[j].User)
+	})
+	return anniversaries, nil
+}
+
+// Returns the anniversaries as a table (with a header line)
+func anniversariesAsTable(anniversaries []anniversary) [][]string {
+	table := [][]string{{"User", "Years", "First_Contribution"}}
+	for _, entry := range anniversaries {
+		table = append(table, []string{entry.User, strconv.Itoa(entry.Years), entry.FirstMonth})
+	}
+	return table
+}
+
+// Writes the anniversaries of the month of the extraction in a CSV file and, for a Markdown
+// output, in a recognition section at the end of the document
+func writeAnniversaries(ctx context.Context, anniversariesFilename string, inputFilename string, endMonth string, years []int, markdownFilename string) error {
+	dataset, err := loadDataset(ctx, inputFilename)
+	if err != nil {
+		return err
+	}
+	monthDataset, err := dataset.Period(endMonth, 1)
+	if err != nil {
+		return err
+	}
+	month := monthDataset.Months[0]
+	anniversaries, err := findAnniversariesOfMonth(dataset, month, years)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCSVtoFile(anniversariesFilename, anniversariesAsTable(anniversaries)); err != nil {
+		return err
+	}
+	logInfo("%d anniversaries of first contribution in %s written to \"%s\"\n", len(anniversaries), month, anniversariesFilename)
+	if markdownFilename == "" || len(anniversaries) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(markdownFilename, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	out := bufio.NewWriter(f)
+	fmt.Fprintf(out, "\n## Anniversaries\n\nAnniversaries of the first contribution in %s:\n\n", month)
+	for _, entry := range anniversaries {
+		yearText := "years"
+		if entry.Years == 1 {
+			yearText = "year"
+		}
+		fmt.Fprintf(out, "- %s: %d %s (first contribution in %s)\n", entry.User, entry.Years, yearText, entry.FirstMonth)
+	}
+	return out.Flush()
+}
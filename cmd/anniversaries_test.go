@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findAnniversariesOfMonth(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2018-04", "2018-05", "2021-04", "2022-04", "2023-03", "2023-04"},
+		{"early", "1", "0", "0", "0", "0", "1"},
+		{"five", "0", "4", "0", "0", "0", "0"},
+		{"two", "0", "0", "2", "1", "0", "0"},
+		{"one", "0", "0", "0", "3", "0", "0"},
+		{"alpha", "0", "0", "0", "1", "0", "0"},
+		{"newcomer", "0", "0", "0", "0", "0", "1"},
+		{"deleted_user", "0", "0", "1", "0", "0", "0"},
+	})
+	assert.NoError(t, err)
+
+	anniversaries, err := findAnniversariesOfMonth(dataset, "2023-04", []int{1, 2, 5})
+
+	assert.NoError(t, err)
+	// "early" may have contributed before the data, "five" started in May
+	assert.Equal(t, []anniversary{
+		{User: "two", Years: 2, FirstMonth: "2021-04"},
+		{User: "alpha", Years: 1, FirstMonth: "2022-04"},
+		{User: "one", Years: 1, FirstMonth: "2022-04"},
+	}, anniversaries)
+
+	anniversaries, err = findAnniversariesOfMonth(dataset, "2023-05", []int{5})
+	assert.NoError(t, err)
+	assert.Equal(t, []anniversary{{User: "five", Years: 5, FirstMonth: "2018-05"}}, anniversaries)
+}
+
+func Test_ExecuteExtractWithAnniversaries(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+	defer func() {
+		_ = extractCmd.PersistentFlags().Set("anniversaries", "false")
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "--type=submitters", "--topSize=5", "--anniversaries", "--out=" + outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(outputFile), "top_anniversaries.csv"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "User,Years,First_Contribution\n")
+	markdown, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "## Anniversaries\n\nAnniversaries of the first contribution in 2023-04:\n\n")
+}
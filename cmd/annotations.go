@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var annotationsFileName string
+
+// Header of the annotations file
+var annotationsFileHeader = []string{"user", "note"}
+
+// Notes attached to the users (the key is the lowercase username)
+type userAnnotations map[string][]string
+
+// Adds the flag to supply the annotations rendered as footnotes
+func addAnnotationsFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&annotationsFileName, "annotations", "", "", "File (\"user,note\" CSV) with notes rendered as footnotes of the Markdown output")
+}
+
+// Loads an annotations file ("user,note" CSV, "#" starts a comment). A user can have several notes.
+func loadAnnotations(fileName string) (userAnnotations, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = len(annotationsFileHeader)
+	r.TrimLeadingSpace = true
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid annotations file %s: %v", fileName, err)
+	}
+
+	annotations := make(userAnnotations)
+	for i, line := range lines {
+		if i == 0 && strings.EqualFold(line[0], annotationsFileHeader[0]) {
+			continue
+		}
+		if line[0] == "" || line[1] == "" {
+			return nil, fmt.Errorf("Invalid annotation \"%s\" in %s", strings.Join(line, ","), fileName)
+		}
+		user := strings.ToLower(line[0])
+		annotations[user] = append(annotations[user], line[1])
+	}
+	return annotations, nil
+}
+
+// Returns the notes of a user (case insensitive)
+func (annotations userAnnotations) notesOf(user string) []string {
+	return annotations[strings.ToLower(user)]
+}
+
+// Adds a footnote reference to the annotated users of a table (first column, header excluded)
+// and returns the Markdown footnotes to write after the table.
+func annotateMarkdownTable(data [][]string, annotations userAnnotations) ([][]string, string) {
+	annotated := make([][]string, 0, len(data))
+	var footnotes strings.Builder
+	footnoteNumber := 0
+	for i, line := range data {
+		// The name can already be enriched (ex: with a medal)
+		name, _, _ := strings.Cut(line[0], " ")
+		notes := annotations.notesOf(name)
+		if i == 0 || len(notes) == 0 {
+			annotated = append(annotated, line)
+			continue
+		}
+
+		footnoteNumber++
+		annotatedLine := append([]string{}, line...)
+		annotatedLine[0] = fmt.Sprintf("%s [^%d]", line[0], footnoteNumber)
+		annotated = append(annotated, annotatedLine)
+		fmt.Fprintf(&footnotes, "[^%d]: %s: %s\n", footnoteNumber, name, strings.Join(notes, "; "))
+	}
+	return annotated, footnotes.String()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadAnnotations(t *testing.T) {
+	annotations, err := loadAnnotations("../test_data/annotations.csv")
+
+	assert.NoError(t, err)
+	expected := userAnnotations{
+		"basil":      {"Release lead", "Company X sponsored"},
+		"markewaite": {"Jenkins board member, docs officer"},
+	}
+	assert.Equal(t, expected, annotations)
+	assert.Equal(t, []string{"Jenkins board member, docs officer"}, annotations.notesOf("MARKEWAITE"))
+	assert.Empty(t, annotations.notesOf("unknown"))
+
+	_, err = loadAnnotations("../test_data/overview.csv")
+	assert.Error(t, err)
+	_, err = loadAnnotations("../test_data/blaah.csv")
+	assert.Error(t, err)
+}
+
+func Test_annotateMarkdownTable(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"bravo", "7"},
+		{"charlie", "3"},
+	}
+	annotations := userAnnotations{"charlie": {"on sabbatical"}, "alpha": {"release lead", "sponsored"}}
+
+	annotated, footnotes := annotateMarkdownTable(data, annotations)
+
+	expected := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha [^1]", "12"},
+		{"bravo", "7"},
+		{"charlie [^2]", "3"},
+	}
+	assert.Equal(t, expected, annotated)
+	assert.Equal(t, "[^1]: alpha: release lead; sponsored\n[^2]: charlie: on sabbatical\n", footnotes)
+	// The original data is not modified
+	assert.Equal(t, "alpha", data[1][0])
+}
+
+func Test_ExecuteExtract_annotations(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+	defer func() { _ = extractCmd.PersistentFlags().Set("annotations", "") }()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "10", "--history=false", "-o", outputFile, "--annotations", "../test_data/annotations.csv"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "| basil [^1]")
+	assert.Contains(t, string(content), "|\n\n[^1]: basil: Release lead; Company X sponsored\n[^2]: MarkEWaite: Jenkins board member, docs officer\n")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var auditFileName string
+
+// Kinds of transformations recorded in the audit file
+const (
+	auditFilter        = "filter"        // months or users left out of a computation
+	auditExclusion     = "exclusion"     // users explicitly excluded
+	auditMerge         = "merge"         // lines merged into one
+	auditNormalization = "normalization" // usernames rewritten
+	auditDeduplication = "deduplication" // duplicated rows removed
+)
+
+// A transformation applied to the data
+type auditEntry struct {
+	Operation   string   `json:"operation"`
+	Description string   `json:"description"`
+	Users       []string `json:"users,omitempty"`
+}
+
+// The audit file: what was run and every transformation applied to the data, for reproducibility
+type auditLog struct {
+	Version         string       `json:"version"`
+	Command         string       `json:"command"`
+	GeneratedAt     string       `json:"generated_at"`
+	Transformations []auditEntry `json:"transformations"`
+}
+
+// The transformations recorded during the current run
+var auditEntries []auditEntry
+
+// Records a transformation applied to the data (only written if "--audit-file" is given)
+func recordTransformation(operation string, users []string, format string, a ...any) {
+	auditEntries = append(auditEntries, auditEntry{Operation: operation, Description: fmt.Sprintf(format, a...), Users: users})
+}
+
+// Starts a new audit log (called before each command)
+func resetAudit() {
+	auditEntries = []auditEntry{}
+}
+
+// Writes the audit file, if requested, with the transformations of the run
+func writeAuditFile(commandLine []string, now time.Time) error {
+	if auditFileName == "" {
+		return nil
+	}
+	log := auditLog{
+		Version:         version,
+		Command:         strings.Join(commandLine, " "),
+		GeneratedAt:     now.UTC().Format(time.RFC3339),
+		Transformations: auditEntries,
+	}
+	content, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := CheckDir(auditFileName); err != nil {
+		return err
+	}
+	if err := os.WriteFile(auditFileName, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("Unable to write the audit file %s: %v", auditFileName, err)
+	}
+	logVerbose("%d transformations written to the audit file \"%s\"\n", len(auditEntries), auditFileName)
+	return nil
+}
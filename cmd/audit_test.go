@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeAuditFile(t *testing.T) {
+	auditFile := filepath.Join(t.TempDir(), "audit.json")
+	auditFileName = auditFile
+	defer func() { auditFileName = "" }()
+
+	resetAudit()
+	records := [][]string{
+		{"", "2023-01", "2023-02"},
+		{"alpha", "1", "0"},
+		{"Bravo", "0", "2"},
+	}
+	excludeUsers(records, []string{"bravo"})
+
+	err := writeAuditFile([]string{"jenkins-contribution-aggregator", "report", "spec.yaml"}, time.Date(2023, 3, 4, 5, 6, 7, 0, time.UTC))
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(auditFile)
+	assert.NoError(t, err)
+	var log auditLog
+	assert.NoError(t, json.Unmarshal(content, &log))
+	assert.Equal(t, "jenkins-contribution-aggregator report spec.yaml", log.Command)
+	assert.Equal(t, "2023-03-04T05:06:07Z", log.GeneratedAt)
+	assert.Equal(t, []auditEntry{{Operation: auditExclusion, Description: "1 users excluded", Users: []string{"Bravo"}}}, log.Transformations)
+}
+
+func Test_writeAuditFile_notRequested(t *testing.T) {
+	resetAudit()
+	recordTransformation(auditFilter, nil, "ignored")
+
+	assert.NoError(t, writeAuditFile(nil, time.Now()))
+}
+
+func Test_ExecuteTrim_audit(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "trimmed.csv")
+	auditFile := filepath.Join(tempDir, "audit.json")
+	defer func() {
+		_ = trimCmd.Flags().Set("from", "")
+		_ = trimCmd.Flags().Set("drop-empty", "false")
+		_ = trimCmd.Flags().Set("out", "")
+		_ = rootCmd.PersistentFlags().Set("audit-file", "")
+	}()
+
+	rootCmd.SetArgs([]string{"trim", "../test_data/deleted_user_case.csv", "--from", "2022-01", "--drop-empty", "-o", outputFile, "--audit-file", auditFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(auditFile)
+	assert.NoError(t, err)
+	var log auditLog
+	assert.NoError(t, json.Unmarshal(content, &log))
+	assert.Len(t, log.Transformations, 2)
+	assert.Equal(t, auditFilter, log.Transformations[0].Operation)
+	assert.Contains(t, log.Transformations[1].Description, "users without activity in the period removed")
+	assert.NotContains(t, log.Transformations[1].Users, "ADITYADAS1999")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var compareBaseline string
+
+// Parses a month range ("2023-01..2023-06"). A single month is a range of one month.
+func parseMonthRange(monthRange string) (firstMonth string, lastMonth string, err error) {
+	firstMonth, lastMonth, isRange := strings.Cut(monthRange, "..")
+	if !isRange {
+		lastMonth = firstMonth
+	}
+	first, err1 := time.Parse("2006-01", firstMonth)
+	last, err2 := time.Parse("2006-01", lastMonth)
+	if err1 != nil || err2 != nil {
+		return "", "", fmt.Errorf("Invalid month range \"%s\" (expecting YYYY-MM..YYYY-MM)", monthRange)
+	}
+	if last.Before(first) {
+		return "", "", fmt.Errorf("Invalid month range \"%s\" (the last month is before the first one)", monthRange)
+	}
+	return firstMonth, lastMonth, nil
+}
+
+// Extracts the top users of the baseline: the ranking is based on the average monthly activity of
+// the baseline months, scaled to the number of months of the compared period (see comparedMonths)
+// so that it can be compared with a regular extraction.
+func extractBaselineData(inputFilename string, header []string, firstMonth string, lastMonth string, topSize int, nbrOfMonths int, inputType InputType) ([][]string, error) {
+	firstColumn := searchStringMonth(header, firstMonth)
+	lastColumn := searchStringMonth(header, lastMonth)
+	if firstColumn < 1 || lastColumn < 1 {
+		return nil, fmt.Errorf("The baseline months (%s to %s) are not all available in %s", firstMonth, lastMonth, inputFilename)
+	}
+
+	records, err := loadProjectedPivotTable(inputFilename, firstColumn, lastColumn)
+	if err != nil {
+		return nil, err
+	}
+	return rankTopUsers(averageOverPeriod(records, nbrOfMonths), topSize, inputType), nil
+}
+
+// Returns the number of months of the period ending with endMonth, as extracted (a period
+// of 0, or longer than the file, starts with the first month available)
+func comparedMonths(header []string, endMonth string, period int) int {
+	firstColumn, lastColumn, _, _ := getBoundaries([][]string{header}, endMonth, period, 0)
+	return lastColumn - firstColumn + 1
+}
+
+// Replaces the months of a projected pivot table with a single column: the average
+// monthly count multiplied by the number of months of the compared period (rounded).
+// The months without data (empty cells) are not part of the average.
+func averageOverPeriod(records [][]string, periodMonths int) [][]string {
+	averaged := [][]string{{"", "baseline"}}
+	for _, dataLine := range records[1:] {
+		total, nbrOfMonths := 0, 0
+		for _, column := range dataLine[1:] {
+			if column == "" {
+				continue
+			}
+			// We don't treat conversion errors as the file has already been checked
+			value, _ := strconv.Atoi(column)
+			total += value
+			nbrOfMonths++
+		}
+		scaled := 0.0
+		if nbrOfMonths > 0 {
+			scaled = math.Round(float64(total) * float64(periodMonths) / float64(nbrOfMonths))
+		}
+		averaged = append(averaged, []string{dataLine[0], strconv.Itoa(int(scaled))})
+	}
+	return averaged
+}
+
+// Returns the introduction of the Markdown output of a compare with a baseline
+func getBaselineIntroduction(inputType InputType, topSize int, period int, firstMonth string, lastMonth string, real_endDate string) string {
+	users := "submitters (non-bot PR creators)"
+	title := "# Top Submitters (Compare)\n"
+	if inputType == InputTypeCommenters {
+		users = "(non-bot) commenters"
+		title = "# Top Commenters (Compare)\n"
+	}
+	return title + fmt.Sprintf("\nExtraction of the %d top %s \nover the %d months before \"%s\".\n", topSize, users, period, real_endDate) +
+		fmt.Sprintf("Table shows new and \"churned\" users compared \nto the average activity between %s and %s.\n\n", firstMonth, lastMonth)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseMonthRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		monthRange string
+		wantFirst  string
+		wantLast   string
+		wantErr    bool
+	}{
+		{"range", "2023-01..2023-06", "2023-01", "2023-06", false},
+		{"single month", "2023-03", "2023-03", "2023-03", false},
+		{"reversed", "2023-06..2023-01", "", "", true},
+		{"invalid month", "2023-13..2023-14", "", "", true},
+		{"invalid separator", "2023-01-2023-06", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, err := parseMonthRange(tt.monthRange)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFirst, first)
+			assert.Equal(t, tt.wantLast, last)
+		})
+	}
+}
+
+func Test_averageOverPeriod(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "3", "0", "4"},
+		{"bravo", "1", "1", "0"},
+	}
+
+	// 7 PRs in 3 months is 28 PRs over 12 months, 2 PRs are 8
+	assert.Equal(t, [][]string{{"", "baseline"}, {"alpha", "28"}, {"bravo", "8"}}, averageOverPeriod(records, 12))
+
+	// The months without data are not part of the average
+	records[2][3] = ""
+	assert.Equal(t, [][]string{{"", "baseline"}, {"alpha", "28"}, {"bravo", "12"}}, averageOverPeriod(records, 12))
+}
+
+func Test_comparedMonths(t *testing.T) {
+	header := []string{"", "2023-01", "2023-02", "2023-03", "2023-04"}
+
+	assert.Equal(t, 2, comparedMonths(header, "2023-04", 2))
+	// A period of 0, or longer than the file, covers all the months available
+	assert.Equal(t, 4, comparedMonths(header, "2023-04", 0))
+	assert.Equal(t, 3, comparedMonths(header, "2023-03", 12))
+}
+
+func Test_extractBaselineData(t *testing.T) {
+	header, err := loadPivotTableHeader("../test_data/overview.csv")
+	assert.NoError(t, err)
+
+	data, err := extractBaselineData("../test_data/overview.csv", header, "2022-01", "2022-06", 3, 12, InputTypeSubmitters)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Submitter", "Total_PRs"}, data[0])
+	assert.Len(t, data, 4)
+	assert.Equal(t, "basil", data[1][0])
+
+	_, err = extractBaselineData("../test_data/overview.csv", header, "2019-01", "2019-06", 3, 12, InputTypeSubmitters)
+	assert.Error(t, err)
+}
+
+func Test_ExecuteCompare_baseline(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "compare.md")
+	defer func() { compareBaseline = "" }()
+
+	rootCmd.SetArgs([]string{"compare", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "5", "--history=false",
+		"-o", outputFile, "--baseline", "2022-01..2022-06"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "to the average activity between 2022-01 and 2022-06.")
+}
@@ -0,0 +1,391 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var browseExportFileName string
+
+// The columns the browser view can be sorted on
+const (
+	browseSortByName = iota
+	browseSortByMonth
+	browseSortByTotal
+	browseSortColumnCount
+)
+
+// A line of the browser view
+type browseRow struct {
+	User      string //Submitter name
+	Month     int    //Count for the selected month
+	Total     int    //Count for all the available months
+	IsMissing bool   //No data for the selected month (the count is 0)
+}
+
+// Result of an export of the current view, sent back to Update
+type browseExportMsg struct {
+	lines int
+	err   error
+}
+
+// State of the terminal UI
+type browseModel struct {
+	dataset        *Dataset // loaded pivot table
+	month          int      // index of the selected month in the dataset
+	sortColumn     int      // one of the browseSortByXXX values
+	sortDescending bool
+	filter         string // current search string
+	isSearching    bool   // are we typing a search string ?
+	searchInput    string
+	cursor         int // selected line in the (filtered) view
+	offset         int // first line displayed
+	height         int // number of data lines that can be displayed
+	exportFileName string
+	message        string // status message displayed at the bottom
+	rows           []browseRow
+}
+
+// browseCmd represents the browse command
+var browseCmd = &cobra.Command{
+	Use:   "browse [input file]",
+	Short: "Interactively browses the supplied pivot table",
+	Long: `The BROWSE command opens a terminal UI to explore the pivot table.
+
+Keys:
+  up/down, pgup/pgdown  scroll through the submitters
+  left/right            switch to the previous/next month
+  /                     search a submitter (enter to apply, esc to clear)
+  s                     change the sort column (name, month, total)
+  r                     reverse the sort order
+  e                     export the current view as a CSV file
+  q                     quit`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		model := newBrowseModel(dataset, browseExportFileName)
+		program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout()))
+		_, err = program.Run()
+		return err
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(browseCmd)
+
+	browseCmd.Flags().StringVarP(&browseExportFileName, "out", "o", "browse_export.csv", "File name used when exporting the current view")
+	browseCmd.ValidArgsFunction = completeInputFile
+}
+
+// Creates the browser state positioned on the most recent month, sorted by descending month count.
+func newBrowseModel(dataset *Dataset, exportFileName string) browseModel {
+	m := browseModel{
+		dataset:        dataset,
+		month:          len(dataset.Months) - 1,
+		sortColumn:     browseSortByMonth,
+		sortDescending: true,
+		height:         20,
+		exportFileName: exportFileName,
+	}
+	m.refreshRows()
+	return m
+}
+
+// Init is part of the bubbletea Model interface
+func (m browseModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is part of the bubbletea Model interface: it handles the keyboard and window events
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Keep room for the title, header, and status lines
+		m.height = msg.Height - 5
+		if m.height < 1 {
+			m.height = 1
+		}
+		m.scrollToCursor()
+
+	case tea.KeyMsg:
+		if m.isSearching {
+			return m.updateSearch(msg), nil
+		}
+
+		m.message = ""
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "pgup":
+			m.moveCursor(-m.height)
+		case "pgdown":
+			m.moveCursor(m.height)
+		case "left", "h":
+			if m.month > 0 {
+				m.month--
+				m.refreshRows()
+			}
+		case "right", "l":
+			if m.month < len(m.dataset.Months)-1 {
+				m.month++
+				m.refreshRows()
+			}
+		case "s":
+			m.sortColumn = (m.sortColumn + 1) % browseSortColumnCount
+			m.refreshRows()
+		case "r":
+			m.sortDescending = !m.sortDescending
+			m.refreshRows()
+		case "/":
+			m.isSearching = true
+			m.searchInput = m.filter
+		case "e":
+			// The file is written outside of the event loop, the result comes back as a message
+			return m, m.exportCmd()
+		}
+
+	case browseExportMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Export failed: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Exported %d lines to \"%s\"", msg.lines, m.exportFileName)
+		}
+	}
+	return m, nil
+}
+
+// Handles the keys while the search string is being typed
+func (m browseModel) updateSearch(msg tea.KeyMsg) browseModel {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.isSearching = false
+		m.filter = m.searchInput
+		m.refreshRows()
+	case tea.KeyEsc:
+		m.isSearching = false
+		m.searchInput = ""
+		m.filter = ""
+		m.refreshRows()
+	case tea.KeyBackspace:
+		if len(m.searchInput) > 0 {
+			m.searchInput = m.searchInput[:len(m.searchInput)-1]
+		}
+	case tea.KeyRunes:
+		m.searchInput = m.searchInput + string(msg.Runes)
+	}
+	return m
+}
+
+// View is part of the bubbletea Model interface: it renders the current state
+func (m browseModel) View() string {
+	var b strings.Builder
+
+	month := m.dataset.Months[m.month]
+	fmt.Fprintf(&b, "Month: %s (%d/%d)   Sort: %s   Submitters: %d", month, m.month+1, len(m.dataset.Months), m.sortDescription(), len(m.rows))
+	if m.filter != "" {
+		fmt.Fprintf(&b, "   Filter: \"%s\"", m.filter)
+	}
+	b.WriteString("\n\n")
+
+	nameWidth := len("Submitter")
+	for _, row := range m.rows {
+		if len(row.User) > nameWidth {
+			nameWidth = len(row.User)
+		}
+	}
+	fmt.Fprintf(&b, "  %-*s %8s %8s\n", nameWidth, "Submitter", month, "Total")
+
+	end := m.offset + m.height
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+	for i := m.offset; i < end; i++ {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		row := m.rows[i]
+		monthCount := strconv.Itoa(row.Month)
+		if row.IsMissing {
+			monthCount = "-"
+		}
+		fmt.Fprintf(&b, "%s%-*s %8s %8d\n", marker, nameWidth, row.User, monthCount, row.Total)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.isSearching:
+		fmt.Fprintf(&b, "Search: %s", m.searchInput)
+	case m.message != "":
+		b.WriteString(m.message)
+	default:
+		b.WriteString("←/→ month  ↑/↓ scroll  / search  s sort  r reverse  e export  q quit")
+	}
+	return b.String()
+}
+
+// Recomputes the displayed lines based on the selected month, filter and sort order
+func (m *browseModel) refreshRows() {
+	m.rows = computeBrowseRows(m.dataset, m.month, m.filter, m.sortColumn, m.sortDescending)
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+// Moves the selected line by the given delta, staying within the view
+func (m *browseModel) moveCursor(delta int) {
+	m.cursor = m.cursor + delta
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+// Makes sure the selected line is visible
+func (m *browseModel) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+// Human readable description of the sort settings
+func (m browseModel) sortDescription() string {
+	column := ""
+	switch m.sortColumn {
+	case browseSortByName:
+		column = "name"
+	case browseSortByMonth:
+		column = "month"
+	case browseSortByTotal:
+		column = "total"
+	}
+	if m.sortDescending {
+		return column + " (desc)"
+	}
+	return column + " (asc)"
+}
+
+// Returns the command exporting the current view, its result being a browseExportMsg
+func (m browseModel) exportCmd() tea.Cmd {
+	return func() tea.Msg {
+		return browseExportMsg{lines: len(m.rows), err: m.export()}
+	}
+}
+
+// Writes the current view as a CSV file
+func (m browseModel) export() error {
+	if err := CheckDir(m.exportFileName); err != nil {
+		return err
+	}
+
+	month := m.dataset.Months[m.month]
+	exportSlice := [][]string{{"Submitter", month, "Total"}}
+	for _, row := range m.rows {
+		// Like in the pivot table, a month without data is an empty cell
+		monthCount := strconv.Itoa(row.Month)
+		if row.IsMissing {
+			monthCount = ""
+		}
+		exportSlice = append(exportSlice, []string{row.User, monthCount, strconv.Itoa(row.Total)})
+	}
+	return writeCSVtoFile(m.exportFileName, exportSlice)
+}
+
+// Builds the lines to display: filters the submitters on the (case insensitive) search string
+// and sorts them on the requested column.
+func computeBrowseRows(dataset *Dataset, month int, filter string, sortColumn int, isDescending bool) []browseRow {
+	var rows []browseRow
+	lowerFilter := strings.ToLower(filter)
+
+	totals := dataset.UserTotals()
+	for i, user := range dataset.Users {
+		if lowerFilter != "" && !strings.Contains(strings.ToLower(user), lowerFilter) {
+			continue
+		}
+		rows = append(rows, browseRow{User: user, Month: dataset.Counts[i][month], Total: totals[i], IsMissing: dataset.isMissing(i, month)})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if isDescending {
+			a, b = b, a
+		}
+		switch sortColumn {
+		case browseSortByMonth:
+			if a.Month != b.Month {
+				return a.Month < b.Month
+			}
+		case browseSortByTotal:
+			if a.Total != b.Total {
+				return a.Total < b.Total
+			}
+		}
+		if sortColumn == browseSortByName {
+			return strings.ToLower(a.User) < strings.ToLower(b.User)
+		}
+		// Ties are always listed alphabetically
+		return strings.ToLower(rows[i].User) < strings.ToLower(rows[j].User)
+	})
+
+	return rows
+}
@@ -0,0 +1,148 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var browse_records = [][]string{
+	{"", "2023-01", "2023-02", "2023-03"},
+	{"alpha", "1", "5", "2"},
+	{"bravo", "4", "0", "2"},
+	{"Charly", "0", "1", "7"},
+	{"delta", "3", "3", "0"},
+}
+
+func Test_computeBrowseRows(t *testing.T) {
+	dataset, err := newDataset("test", browse_records)
+	assert.NoError(t, err)
+
+	type args struct {
+		month        int
+		filter       string
+		sortColumn   int
+		isDescending bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want []browseRow
+	}{
+		{
+			"latest month, descending",
+			args{month: 2, filter: "", sortColumn: browseSortByMonth, isDescending: true},
+			[]browseRow{{"Charly", 7, 8, false}, {"alpha", 2, 8, false}, {"bravo", 2, 6, false}, {"delta", 0, 6, false}},
+		},
+		{
+			"first month, ascending",
+			args{month: 0, filter: "", sortColumn: browseSortByMonth, isDescending: false},
+			[]browseRow{{"Charly", 0, 8, false}, {"alpha", 1, 8, false}, {"delta", 3, 6, false}, {"bravo", 4, 6, false}},
+		},
+		{
+			"by name",
+			args{month: 0, filter: "", sortColumn: browseSortByName, isDescending: false},
+			[]browseRow{{"alpha", 1, 8, false}, {"bravo", 4, 6, false}, {"Charly", 0, 8, false}, {"delta", 3, 6, false}},
+		},
+		{
+			"by total, descending",
+			args{month: 1, filter: "", sortColumn: browseSortByTotal, isDescending: true},
+			[]browseRow{{"alpha", 5, 8, false}, {"Charly", 1, 8, false}, {"bravo", 0, 6, false}, {"delta", 3, 6, false}},
+		},
+		{
+			"filtered (case insensitive)",
+			args{month: 1, filter: "CH", sortColumn: browseSortByMonth, isDescending: true},
+			[]browseRow{{"Charly", 1, 8, false}},
+		},
+		{
+			"no match",
+			args{month: 1, filter: "zulu", sortColumn: browseSortByMonth, isDescending: true},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeBrowseRows(dataset, tt.args.month, tt.args.filter, tt.args.sortColumn, tt.args.isDescending); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeBrowseRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A month without data is flagged, rather than shown as a count of 0
+	withMissing, err := newDataset("test", [][]string{{"", "2023-01", "2023-02"}, {"alpha", "1", ""}, {"bravo", "2", "3"}})
+	assert.NoError(t, err)
+	got := computeBrowseRows(withMissing, 1, "", browseSortByName, false)
+	assert.Equal(t, []browseRow{{"alpha", 0, 1, true}, {"bravo", 3, 5, false}}, got)
+}
+
+func Test_browseModel_Update(t *testing.T) {
+	tempDir := t.TempDir()
+	exportFileName := filepath.Join(tempDir, "export.csv")
+
+	dataset, err := newDataset("test", browse_records)
+	assert.NoError(t, err)
+
+	var model tea.Model = newBrowseModel(dataset, exportFileName)
+	m := model.(browseModel)
+	assert.Equal(t, 2, m.month, "Should start on the most recent month")
+	assert.Equal(t, "Charly", m.rows[m.cursor].User)
+
+	// switch month and move down
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = model.(browseModel)
+	assert.Equal(t, 1, m.month)
+	assert.Equal(t, "delta", m.rows[m.cursor].User)
+
+	// search
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("br")})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(browseModel)
+	assert.Equal(t, "br", m.filter)
+	assert.Len(t, m.rows, 1)
+	assert.Equal(t, "bravo", m.rows[m.cursor].User)
+	assert.Contains(t, m.View(), "Filter: \"br\"")
+
+	// export
+	model, exportCmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.NotNil(t, exportCmd, "Export command expected")
+	model, _ = model.Update(exportCmd())
+	m = model.(browseModel)
+	assert.FileExists(t, exportFileName)
+	assert.Equal(t, "Exported 1 lines to \""+exportFileName+"\"", m.message)
+
+	// a failed export is reported in the status line
+	failing := m
+	failing.exportFileName = filepath.Join(exportFileName, "export.csv")
+	model, _ = failing.Update(failing.exportCmd()())
+	assert.Contains(t, model.(browseModel).message, "Export failed: ")
+
+	// quit
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.NotNil(t, cmd, "Quit command expected")
+}
@@ -0,0 +1,285 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var githubCacheFileName string
+var githubCacheTTL time.Duration
+var isGitHubCacheDisabled bool
+var cachePurgeNamespace string
+var cachePurgeOlderThan time.Duration
+
+// Namespaces of the cache: the lookups of different kinds are kept (and purged) separately
+const (
+	cacheNamespaceUsers = "users" // GitHub user profiles (login, display name, location)
+)
+
+// Version of the cache file format
+const githubCacheVersion = 2
+
+// A GitHub lookup saved in the cache
+type cachedGitHubEntry struct {
+	Value     json.RawMessage `json:"value,omitempty"` // empty if the object doesn't exist
+	FetchedAt time.Time       `json:"fetched_at"`
+}
+
+// Content of the cache file
+type githubCacheFile struct {
+	Version    int                                     `json:"version"`
+	Namespaces map[string]map[string]cachedGitHubEntry `json:"namespaces"`
+}
+
+// A GitHub user lookup, as saved by the first versions of the cache (a single map of users)
+type cachedGitHubUser struct {
+	User      *githubUser `json:"user,omitempty"` // nil if the user doesn't exist
+	FetchedAt time.Time   `json:"fetched_at"`
+}
+
+// Local cache of the GitHub lookups, so that monthly runs stay within the API rate limits
+type githubCache struct {
+	fileName   string
+	ttl        time.Duration
+	namespaces map[string]map[string]cachedGitHubEntry
+	isModified bool
+}
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages the local cache of the GitHub lookups",
+	Long: `The CACHE command manages the local cache of the GitHub lookups (see "--cache-file"
+of the commands calling GitHub). The lookups are stored by namespace ("users" for the
+user profiles).`,
+}
+
+// cachePurgeCmd represents the cache purge command
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Removes entries from the GitHub lookups cache",
+	Long: `The PURGE command removes entries from the GitHub lookups cache: all of them by default,
+only the ones of a namespace with "--namespace", and only the ones fetched more than the
+given duration ago with "--older-than" (ex: "720h").`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := loadGitHubCache(githubCacheFileName, githubCacheTTL)
+		if err != nil {
+			return fmt.Errorf("Unable to load the GitHub cache %s: %v", githubCacheFileName, err)
+		}
+		removed := cache.purge(cachePurgeNamespace, cachePurgeOlderThan, time.Now())
+		if err := cache.save(); err != nil {
+			return err
+		}
+		logInfo("%d entries removed from \"%s\"\n", removed, githubCacheFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cachePurgeCmd)
+
+	cachePurgeCmd.Flags().StringVarP(&githubCacheFileName, "cache-file", "", defaultGitHubCacheFileName(), "File caching the GitHub lookups")
+	cachePurgeCmd.Flags().StringVarP(&cachePurgeNamespace, "namespace", "", "", "Only purges this namespace (ex: \"users\")")
+	cachePurgeCmd.Flags().DurationVarP(&cachePurgeOlderThan, "older-than", "", 0, "Only purges the entries fetched more than this duration ago")
+	_ = cachePurgeCmd.RegisterFlagCompletionFunc("namespace", cobra.FixedCompletions([]string{cacheNamespaceUsers}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Adds the cache flags to a command using the GitHub enrichment
+func addGitHubCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&githubCacheFileName, "cache-file", "", defaultGitHubCacheFileName(), "File caching the GitHub lookups")
+	cmd.Flags().DurationVarP(&githubCacheTTL, "cache-ttl", "", 30*24*time.Hour, "Time before a cached GitHub lookup is refreshed")
+	cmd.Flags().BoolVarP(&isGitHubCacheDisabled, "no-cache", "", false, "Always queries GitHub (the cache is neither read nor updated)")
+}
+
+// Returns the default location of the cache file (in the user's cache directory)
+func defaultGitHubCacheFileName() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "jenkins-contribution-aggregator", "github-users.json")
+}
+
+// Loads the cache requested with the cache flags
+func openGitHubCache() (*githubCache, error) {
+	cacheFileName := githubCacheFileName
+	if isGitHubCacheDisabled {
+		cacheFileName = ""
+	}
+	cache, err := loadGitHubCache(cacheFileName, githubCacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load the GitHub cache %s: %v", cacheFileName, err)
+	}
+	return cache, nil
+}
+
+// Loads the cache file. A missing file is an empty cache. An empty file name disables the cache.
+// The files of the first versions (a map of users) are migrated to the "users" namespace.
+func loadGitHubCache(fileName string, ttl time.Duration) (*githubCache, error) {
+	cache := &githubCache{fileName: fileName, ttl: ttl, namespaces: make(map[string]map[string]cachedGitHubEntry)}
+	if fileName == "" {
+		return cache, nil
+	}
+
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file githubCacheFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	if file.Version >= githubCacheVersion {
+		for namespace, entries := range file.Namespaces {
+			cache.namespaces[namespace] = entries
+		}
+		return cache, nil
+	}
+
+	var legacyUsers map[string]cachedGitHubUser
+	if err := json.Unmarshal(content, &legacyUsers); err != nil {
+		return nil, err
+	}
+	users := make(map[string]cachedGitHubEntry)
+	for login, legacy := range legacyUsers {
+		entry := cachedGitHubEntry{FetchedAt: legacy.FetchedAt}
+		if legacy.User != nil {
+			if entry.Value, err = json.Marshal(legacy.User); err != nil {
+				return nil, err
+			}
+		}
+		users[login] = entry
+	}
+	cache.namespaces[cacheNamespaceUsers] = users
+	cache.isModified = len(users) > 0
+	logVerbose("%d users of the cache \"%s\" migrated to the \"%s\" namespace\n", len(users), fileName, cacheNamespaceUsers)
+	return cache, nil
+}
+
+// Writes the cache file if it was modified
+func (c *githubCache) save() error {
+	if c.fileName == "" || !c.isModified {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.fileName), 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(githubCacheFile{Version: githubCacheVersion, Namespaces: c.namespaces}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.fileName, content, 0644); err != nil {
+		return err
+	}
+	c.isModified = false
+	return nil
+}
+
+// Saves the cache at the end of a command. Whatever happened, the successful lookups are kept.
+func (c *githubCache) close() {
+	if err := c.save(); err != nil {
+		warn("Unable to save the GitHub cache %s: %v", c.fileName, err)
+	}
+}
+
+// Returns the cached value of the key (decoded in result) if it is recent enough, or else fetches it.
+// errGitHubNotFound is returned (and cached) if the object doesn't exist.
+func (c *githubCache) lookup(namespace string, key string, now time.Time, result any, fetch func() (any, error)) error {
+	entries, isKnown := c.namespaces[namespace]
+	if !isKnown {
+		entries = make(map[string]cachedGitHubEntry)
+		c.namespaces[namespace] = entries
+	}
+	if entry, isCached := entries[key]; isCached && now.Sub(entry.FetchedAt) < c.ttl {
+		if len(entry.Value) == 0 {
+			return errGitHubNotFound
+		}
+		return json.Unmarshal(entry.Value, result)
+	}
+
+	value, err := fetch()
+	if err != nil && !errors.Is(err, errGitHubNotFound) {
+		return err
+	}
+	entry := cachedGitHubEntry{FetchedAt: now}
+	if err == nil {
+		if entry.Value, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	entries[key] = entry
+	c.isModified = true
+	if len(entry.Value) == 0 {
+		return errGitHubNotFound
+	}
+	return json.Unmarshal(entry.Value, result)
+}
+
+// Returns the GitHub user, from the cache if it is recent enough or else from GitHub
+func (c *githubCache) getUser(ctx context.Context, client *githubClient, login string, now time.Time) (*githubUser, error) {
+	var user githubUser
+	err := c.lookup(cacheNamespaceUsers, login, now, &user, func() (any, error) {
+		return client.getUser(ctx, login)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Removes the entries of the namespace (all the namespaces if empty) fetched more than
+// olderThan ago (all of them if 0). Returns the number of removed entries.
+func (c *githubCache) purge(namespace string, olderThan time.Duration, now time.Time) int {
+	removed := 0
+	for name, entries := range c.namespaces {
+		if namespace != "" && name != namespace {
+			continue
+		}
+		for key, entry := range entries {
+			if olderThan == 0 || now.Sub(entry.FetchedAt) > olderThan {
+				delete(entries, key)
+				removed++
+			}
+		}
+		logVerbose("  %s: %d entries left\n", name, len(entries))
+	}
+	if removed > 0 {
+		c.isModified = true
+	}
+	return removed
+}
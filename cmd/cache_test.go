@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadGitHubCache_legacy(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "github-users.json")
+	fetchedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	legacy := map[string]cachedGitHubUser{
+		"alpha": {User: &githubUser{Login: "alpha", Location: "Paris"}, FetchedAt: fetchedAt},
+		"ghost": {FetchedAt: fetchedAt},
+	}
+	content, err := json.Marshal(legacy)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(cacheFile, content, 0644))
+
+	cache, err := loadGitHubCache(cacheFile, 24*time.Hour)
+	assert.NoError(t, err)
+
+	// The migrated entries are used without calling GitHub
+	client := newGitHubClient("")
+	client.baseURL = "http://127.0.0.1:0"
+	user, err := cache.getUser(context.Background(), client, "alpha", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "Paris", user.Location)
+	_, err = cache.getUser(context.Background(), client, "ghost", time.Now())
+	assert.ErrorIs(t, err, errGitHubNotFound)
+
+	// The file is written in the new format
+	assert.NoError(t, cache.save())
+	content, err = os.ReadFile(cacheFile)
+	assert.NoError(t, err)
+	var file githubCacheFile
+	assert.NoError(t, json.Unmarshal(content, &file))
+	assert.Equal(t, githubCacheVersion, file.Version)
+	assert.Len(t, file.Namespaces[cacheNamespaceUsers], 2)
+}
+
+func Test_githubCache_purge(t *testing.T) {
+	now := time.Now()
+	newCache := func() *githubCache {
+		return &githubCache{namespaces: map[string]map[string]cachedGitHubEntry{
+			cacheNamespaceUsers: {
+				"recent": {FetchedAt: now.Add(-time.Hour)},
+				"old":    {FetchedAt: now.Add(-48 * time.Hour)},
+			},
+			"other": {
+				"old": {FetchedAt: now.Add(-48 * time.Hour)},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		olderThan time.Duration
+		removed   int
+	}{
+		{"everything", "", 0, 3},
+		{"a namespace", cacheNamespaceUsers, 0, 2},
+		{"old entries", "", 24 * time.Hour, 2},
+		{"old entries of a namespace", "other", 24 * time.Hour, 1},
+		{"unknown namespace", "orgs", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newCache()
+			assert.Equal(t, tt.removed, cache.purge(tt.namespace, tt.olderThan, now))
+			assert.Equal(t, tt.removed > 0, cache.isModified)
+		})
+	}
+}
+
+func Test_ExecuteCachePurge(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "github-users.json")
+	cache, err := loadGitHubCache(cacheFile, time.Hour)
+	assert.NoError(t, err)
+	cache.namespaces[cacheNamespaceUsers] = map[string]cachedGitHubEntry{
+		"recent": {FetchedAt: time.Now()},
+		"old":    {FetchedAt: time.Now().Add(-48 * time.Hour)},
+	}
+	cache.isModified = true
+	assert.NoError(t, cache.save())
+	defer func() {
+		_ = cachePurgeCmd.Flags().Set("cache-file", defaultGitHubCacheFileName())
+		_ = cachePurgeCmd.Flags().Set("older-than", "0")
+	}()
+
+	rootCmd.SetArgs([]string{"cache", "purge", "--cache-file", cacheFile, "--older-than", "24h"})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	cache, err = loadGitHubCache(cacheFile, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, cache.namespaces[cacheNamespaceUsers], 1)
+	assert.Contains(t, cache.namespaces[cacheNamespaceUsers], "recent")
+}
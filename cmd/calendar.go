@@ -0,0 +1,202 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var calendarOutputDir string
+var calendarTopSize int
+var calendarPeriod int
+var calendarEndMonth string
+
+// A day (the first of the month) of the contribution calendar
+type calendarDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+	Level int    `json:"level"` // 0 (no activity) to 4, as in the GitHub contribution graph
+}
+
+// The contribution calendar of a submitter
+type contributionCalendar struct {
+	User          string        `json:"user"`
+	From          string        `json:"from"`
+	To            string        `json:"to"`
+	Total         int           `json:"total"`
+	Max           int           `json:"max"`
+	Contributions []calendarDay `json:"contributions"`
+}
+
+// calendarCmd represents the calendar command
+var calendarCmd = &cobra.Command{
+	Use:   "calendar [input file] [username...]",
+	Short: "Exports the contribution calendar of submitters as JSON",
+	Long: `The CALENDAR command exports, for each given submitter, a "calendar" JSON file that
+can be used by heatmap widgets (like the GitHub contribution graph) on the contributor
+spotlight pages.
+
+As the data is monthly, there is one entry per month, dated on the first day of the month,
+with the count and an activity level (0 to 4, relative to the most active month of the
+submitter). The months without data have no entry.
+
+Instead of listing the usernames, "--top" exports the calendars of the top submitters of
+the period. The files are named after the submitter in the "--out-dir" directory.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if len(args) == 1 && calendarTopSize <= 0 {
+			return fmt.Errorf("Usernames or \"--top\" are required")
+		}
+		if !isValidMonth(calendarEndMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", calendarEndMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		period, err := dataset.Period(calendarEndMonth, calendarPeriod)
+		if err != nil {
+			return err
+		}
+
+		usernames := args[1:]
+		if len(usernames) == 0 {
+			for i, entry := range period.Leaderboard() {
+				if i >= calendarTopSize {
+					break
+				}
+				usernames = append(usernames, entry.User)
+			}
+		}
+
+		if err := os.MkdirAll(calendarOutputDir, 0755); err != nil {
+			return err
+		}
+		for _, username := range usernames {
+			calendar, err := buildContributionCalendar(period, username)
+			if err != nil {
+				return err
+			}
+			if err := writeCalendarFile(filepath.Join(calendarOutputDir, calendar.User+".json"), calendar); err != nil {
+				return err
+			}
+		}
+		logInfo("%d calendars written to \"%s\"\n", len(usernames), calendarOutputDir)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+
+	calendarCmd.Flags().StringVarP(&calendarOutputDir, "out-dir", "", "calendars", "Directory where the calendar files are written")
+	calendarCmd.Flags().IntVarP(&calendarTopSize, "top", "t", 0, "Exports the calendars of that number of top submitters")
+	calendarCmd.Flags().IntVarP(&calendarPeriod, "period", "p", 0, "Number of months of the calendar (0 for all)")
+	calendarCmd.Flags().StringVarP(&calendarEndMonth, "month", "m", "latest", "Last month of the calendar")
+
+	calendarCmd.ValidArgsFunction = completeInputFile
+	_ = calendarCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Builds the calendar of a submitter (case insensitive) over the months of the dataset.
+// The months without data have no entry.
+func buildContributionCalendar(dataset *Dataset, username string) (contributionCalendar, error) {
+	var calendar contributionCalendar
+
+	userIndex := dataset.findUser(username)
+	if userIndex == -1 {
+		return calendar, fmt.Errorf("Submitter \"%s\" not found (the \"find\" command can help)", username)
+	}
+	calendar.User = dataset.Users[userIndex]
+
+	var months []time.Time
+	for _, monthName := range dataset.Months {
+		month, err := time.Parse("2006-01", monthName)
+		if err != nil {
+			return calendar, fmt.Errorf("Invalid month \"%s\" in the header", monthName)
+		}
+		months = append(months, month)
+	}
+	calendar.From = months[0].Format("2006-01-02")
+	calendar.To = months[len(months)-1].AddDate(0, 1, -1).Format("2006-01-02")
+
+	calendar.Contributions = []calendarDay{}
+	for column, month := range months {
+		if dataset.isMissing(userIndex, column) {
+			continue
+		}
+		count := dataset.Counts[userIndex][column]
+		calendar.Contributions = append(calendar.Contributions, calendarDay{Date: month.Format("2006-01-02"), Count: count})
+		calendar.Total += count
+		if count > calendar.Max {
+			calendar.Max = count
+		}
+	}
+
+	for i, day := range calendar.Contributions {
+		calendar.Contributions[i].Level = activityLevel(day.Count, calendar.Max)
+	}
+	return calendar, nil
+}
+
+// Returns the activity level (0 to 4) of a count, relative to the maximum count
+func activityLevel(count int, max int) int {
+	if count <= 0 || max <= 0 {
+		return 0
+	}
+	level := (count*4 + max - 1) / max
+	if level > 4 {
+		return 4
+	}
+	return level
+}
+
+// Writes the calendar as a JSON file
+func writeCalendarFile(fileName string, calendar contributionCalendar) error {
+	content, err := json.MarshalIndent(calendar, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(content, '\n'), 0644)
+}
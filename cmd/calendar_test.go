@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildContributionCalendar(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2022-12", "2023-01", "2023-02"},
+		{"alpha", "1", "0", "8"},
+		{"bravo", "2", "", "2"},
+	})
+	assert.NoError(t, err)
+
+	calendar, err := buildContributionCalendar(dataset, "ALPHA")
+
+	assert.NoError(t, err)
+	expected := contributionCalendar{
+		User:  "alpha",
+		From:  "2022-12-01",
+		To:    "2023-02-28",
+		Total: 9,
+		Max:   8,
+		Contributions: []calendarDay{
+			{Date: "2022-12-01", Count: 1, Level: 1},
+			{Date: "2023-01-01", Count: 0, Level: 0},
+			{Date: "2023-02-01", Count: 8, Level: 4},
+		},
+	}
+	assert.Equal(t, expected, calendar)
+
+	// The months without data have no entry
+	calendar, err = buildContributionCalendar(dataset, "bravo")
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-02-28", calendar.To)
+	assert.Equal(t, []calendarDay{{Date: "2022-12-01", Count: 2, Level: 4}, {Date: "2023-02-01", Count: 2, Level: 4}}, calendar.Contributions)
+
+	_, err = buildContributionCalendar(dataset, "unknown")
+	assert.Error(t, err)
+}
+
+func Test_activityLevel(t *testing.T) {
+	assert.Equal(t, 0, activityLevel(0, 10))
+	assert.Equal(t, 1, activityLevel(1, 10))
+	assert.Equal(t, 2, activityLevel(5, 10))
+	assert.Equal(t, 3, activityLevel(7, 10))
+	assert.Equal(t, 4, activityLevel(10, 10))
+	assert.Equal(t, 0, activityLevel(3, 0))
+}
+
+func Test_ExecuteCalendar(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() { _ = calendarCmd.Flags().Set("top", "0") }()
+
+	rootCmd.SetArgs([]string{"calendar", "../test_data/deleted_user_case.csv", "--top", "2", "--out-dir", tempDir})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(tempDir, "ADI10HERO.json"))
+	assert.NoError(t, err)
+	var calendar contributionCalendar
+	assert.NoError(t, json.Unmarshal(content, &calendar))
+	assert.Equal(t, 24, calendar.Total)
+	assert.Len(t, calendar.Contributions, 40)
+	assert.FileExists(t, filepath.Join(tempDir, "95-jonpet.json"))
+}
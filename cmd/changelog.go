@@ -0,0 +1,229 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var changelogFileName string
+var changelogTopSize int
+
+// Heading of the section of a month
+var changelogSectionRegexp = regexp.MustCompile(`^## (20[0-9]{2}-[0-9]{2})\s*$`)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [input file]",
+	Short: "Prepends the month's top submitters to a CHANGELOG.md-style file",
+	Long: `The CHANGELOG command adds a section for the month to a cumulative Markdown file
+(like a CHANGELOG.md), with the key figures of the month and the top submitters of
+the period. The new section is inserted above the previous ones, which are kept
+untouched. Running it again for the same month replaces the section of that month.
+
+The file is created if it doesn't exist.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		var err error
+		inputType, err = selectInputType(argInputType, args[0])
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		month, section, err := buildChangelogSection(cmd.Context(), args[0], changelogTopSize, endMonth, period, inputType, time.Now())
+		if err != nil {
+			return err
+		}
+
+		previous, err := os.ReadFile(changelogFileName)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if dirErr := CheckDir(changelogFileName); dirErr != nil {
+			return dirErr
+		}
+		if err := os.WriteFile(changelogFileName, []byte(insertChangelogSection(string(previous), month, section, inputType)), 0644); err != nil {
+			return err
+		}
+		logInfo("Section \"%s\" added to \"%s\"\n", month, changelogFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	changelogCmd.Flags().StringVarP(&changelogFileName, "out", "o", "CHANGELOG.md", "Cumulative Markdown file the section is added to")
+	changelogCmd.Flags().IntVarP(&changelogTopSize, "topSize", "t", 10, "Number of top submitters listed.")
+	changelogCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate.")
+	changelogCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Month to extract top submitters.")
+	changelogCmd.Flags().StringVarP(&argInputType, "type", "", inputTypeAuto, "The type of data being analyzed: \"submitters\", \"commenters\" or \"auto\" (detected from the input file)")
+
+	changelogCmd.ValidArgsFunction = completeInputFile
+	_ = changelogCmd.RegisterFlagCompletionFunc("month", completeMonth)
+	_ = changelogCmd.RegisterFlagCompletionFunc("type", completeInputType)
+}
+
+// Builds the section of the month: key figures and top users of the period
+func buildChangelogSection(ctx context.Context, inputFilename string, topSize int, endMonth string, period int, inputType InputType, now time.Time) (month string, section string, err error) {
+	result, month, top := extractData(ctx, inputFilename, topSize, endMonth, period, 0, inputType)
+	if !result {
+		return "", "", extractionError(ctx, "Failed to extract data")
+	}
+
+	header, err := loadPivotTableHeader(inputFilename)
+	if err != nil {
+		return "", "", err
+	}
+	// The top of the previous month (none if the data starts with the period)
+	var previousTop [][]string
+	if previousFirst, previousLast, _, _ := getBoundaries([][]string{header}, month, period, 1); previousLast != 0 {
+		previousRecords, err := loadProjectedPivotTable(inputFilename, previousFirst, previousLast)
+		if err != nil {
+			return "", "", err
+		}
+		previousTop = rankTopUsers(previousRecords, topSize, inputType)
+	}
+	firstColumn, lastColumn, firstMonth, _ := getBoundaries([][]string{header}, month, period, 0)
+	records, err := loadProjectedPivotTable(inputFilename, firstColumn, lastColumn)
+	if err != nil {
+		return "", "", err
+	}
+
+	activeUsers, monthTotal, periodTotal := 0, 0, 0
+	lastColumnOfPeriod := lastColumn - firstColumn + 1
+	for _, dataLine := range records[1:] {
+		value, _ := strconv.Atoi(dataLine[lastColumnOfPeriod])
+		if value > 0 {
+			activeUsers++
+			monthTotal += value
+		}
+		periodTotal += sumColumns(dataLine, 1, lastColumnOfPeriod)
+	}
+
+	users, contributions := "submitters", "PRs"
+	if inputType == InputTypeCommenters {
+		users, contributions = "commenters", "comments"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n", month)
+	fmt.Fprintf(&sb, "_Generated on %s_\n\n", now.Format("2006-01-02"))
+	fmt.Fprintf(&sb, "- %d %s in %s: %d active %s\n", monthTotal, contributions, month, activeUsers, users)
+	fmt.Fprintf(&sb, "- %d %s between %s and %s\n", periodTotal, contributions, firstMonth, month)
+	if newcomers := newInTop(top, previousTop); len(newcomers) > 0 {
+		fmt.Fprintf(&sb, "- New in the top %d: %s\n", topSize, strings.Join(newcomers, ", "))
+	}
+	fmt.Fprintf(&sb, "\nTop %d %s between %s and %s:\n\n", topSize, users, firstMonth, month)
+	if err := writeMarkdownTable(&sb, top, nil, inputType); err != nil {
+		return "", "", err
+	}
+	return month, sb.String(), nil
+}
+
+// Returns the users of the top that weren't in the previous one (the first line is the header)
+func newInTop(top [][]string, previousTop [][]string) []string {
+	if len(previousTop) == 0 {
+		return nil
+	}
+	var newcomers []string
+	for _, dataLine := range top[1:] {
+		if getIndexInPivotTable(previousTop, dataLine[0]) < 1 {
+			newcomers = append(newcomers, dataLine[0])
+		}
+	}
+	return newcomers
+}
+
+// Inserts the section of the month above the sections of the previous months (replacing the
+// section of the same month if any). The rest of the file is kept as is.
+func insertChangelogSection(content string, month string, section string, inputType InputType) string {
+	if strings.TrimSpace(content) == "" {
+		title := "Jenkins top submitters"
+		if inputType == InputTypeCommenters {
+			title = "Jenkins top commenters"
+		}
+		return fmt.Sprintf("# %s\n\n%s", title, section)
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	insertAt, removeFrom, removeTo := -1, -1, len(lines)
+	for i, line := range lines {
+		matches := changelogSectionRegexp.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if matches == nil {
+			continue
+		}
+		if removeFrom >= 0 && removeTo == len(lines) {
+			removeTo = i
+		}
+		if insertAt < 0 && matches[1] <= month {
+			insertAt = i
+			if matches[1] == month {
+				removeFrom = i
+			}
+		}
+	}
+
+	if insertAt < 0 {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + "\n" + section
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(lines[:insertAt], ""))
+	sb.WriteString(section)
+	if removeFrom >= 0 {
+		lines = lines[removeTo:]
+	} else {
+		lines = lines[insertAt:]
+	}
+	if rest := strings.Join(lines, ""); rest != "" {
+		sb.WriteString("\n" + rest)
+	}
+	return sb.String()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_insertChangelogSection(t *testing.T) {
+	existing := "# Jenkins top submitters\n\nSome words.\n\n## 2023-02\n\nFebruary\n\n## 2023-01\n\nJanuary\n"
+
+	tests := []struct {
+		name    string
+		content string
+		month   string
+		want    string
+	}{
+		{"new file", "", "2023-03", "# Jenkins top submitters\n\n## 2023-03\n\nMarch\n"},
+		{"prepended", existing, "2023-03", "# Jenkins top submitters\n\nSome words.\n\n## 2023-03\n\nMarch\n\n## 2023-02\n\nFebruary\n\n## 2023-01\n\nJanuary\n"},
+		{"same month replaced", existing, "2023-02", "# Jenkins top submitters\n\nSome words.\n\n## 2023-02\n\nMarch\n\n## 2023-01\n\nJanuary\n"},
+		{"last month replaced", existing, "2023-01", "# Jenkins top submitters\n\nSome words.\n\n## 2023-02\n\nFebruary\n\n## 2023-01\n\nMarch\n"},
+		{"older month appended", existing, "2022-12", existing + "\n## 2022-12\n\nMarch\n"},
+		{"no section yet", "# My changelog", "2023-03", "# My changelog\n\n## 2023-03\n\nMarch\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := "## " + tt.month + "\n\nMarch\n"
+			assert.Equal(t, tt.want, insertChangelogSection(tt.content, tt.month, section, InputTypeSubmitters))
+		})
+	}
+}
+
+func Test_newInTop(t *testing.T) {
+	top := [][]string{{"Submitter", "Total_PRs"}, {"alpha", "5"}, {"bravo", "3"}, {"charlie", "1"}}
+	previousTop := [][]string{{"Submitter", "Total_PRs"}, {"bravo", "4"}, {"delta", "2"}}
+
+	assert.Equal(t, []string{"alpha", "charlie"}, newInTop(top, previousTop))
+	assert.Nil(t, newInTop(top, nil))
+}
+
+func Test_buildChangelogSection(t *testing.T) {
+	month, section, err := buildChangelogSection(context.Background(), "../test_data/overview.csv", 3, "2023-03", 3, InputTypeSubmitters, time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-03", month)
+	assert.True(t, strings.HasPrefix(section, "## 2023-03\n\n_Generated on 2023-04-02_\n\n- "))
+	assert.Contains(t, section, "\nTop 3 submitters between 2023-01 and 2023-03:\n\n| Submitter   | Total_PRs |\n")
+	assert.Contains(t, section, "| lemeurherve |       301 |\n")
+}
+
+func Test_ExecuteChangelog(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	for _, month := range []string{"2023-02", "2023-03", "2023-03"} {
+		rootCmd.SetArgs([]string{"changelog", "../test_data/overview.csv", "-m", month, "-p", "3", "-o", outputFile})
+		assert.NoError(t, rootCmd.Execute())
+	}
+
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# Jenkins top submitters\n\n## 2023-03\n"))
+	assert.Equal(t, 1, strings.Count(string(content), "## 2023-03\n"))
+	assert.Equal(t, 1, strings.Count(string(content), "## 2023-02\n"))
+}
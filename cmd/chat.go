@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var notifyDiscordURL string
+var notifyMatrixRoom string
+var matrixHomeserver string
+
+// Returns the text of the chat message announcing a generation (Markdown or HTML)
+func formatChatSummary(summary generationSummary, isHTML bool) string {
+	var sb strings.Builder
+	intro := fmt.Sprintf("Jenkins contributions from %s to %s (%d months): %d users, %d in total.",
+		summary.Period.From, summary.Period.To, summary.Period.Months, summary.Users, summary.Total)
+	if isHTML {
+		sb.WriteString("<p>" + html.EscapeString(intro) + "</p>")
+		if len(summary.Top) > 0 {
+			sb.WriteString("<ol>")
+			for _, user := range summary.Top {
+				sb.WriteString(fmt.Sprintf("<li>%s (%d)</li>", html.EscapeString(user.User), user.Total))
+			}
+			sb.WriteString("</ol>")
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(intro + "\n")
+	for i, user := range summary.Top {
+		sb.WriteString(fmt.Sprintf("%d. **%s** (%d)\n", i+1, user.User, user.Total))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Posts the summary to a Discord webhook
+func notifyDiscord(ctx context.Context, webhookURL string, summary generationSummary) error {
+	payload, err := json.Marshal(map[string]string{"content": formatChatSummary(summary, false)})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if err := sendChatMessage(request, "Discord"); err != nil {
+		return err
+	}
+	logVerbose("Summary posted to Discord\n")
+	return nil
+}
+
+// Posts the summary to a Matrix room (the access token is read from MATRIX_ACCESS_TOKEN)
+func notifyMatrix(ctx context.Context, homeserver string, room string, summary generationSummary, now time.Time) error {
+	token := os.Getenv("MATRIX_ACCESS_TOKEN")
+	if token == "" {
+		return fmt.Errorf("A Matrix access token is required (MATRIX_ACCESS_TOKEN environment variable)")
+	}
+	payload, err := json.Marshal(map[string]string{
+		"msgtype":        "m.notice",
+		"body":           formatChatSummary(summary, false),
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formatChatSummary(summary, true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The transaction id makes the retries of the same message idempotent
+	transactionID := fmt.Sprintf("aggregator-%d", now.UnixNano())
+	endpoint := strings.TrimSuffix(homeserver, "/") + "/_matrix/client/v3/rooms/" + url.PathEscape(room) + "/send/m.room.message/" + transactionID
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+	if err := sendChatMessage(request, "Matrix"); err != nil {
+		return err
+	}
+	logVerbose("Summary posted to the Matrix room \"%s\"\n", room)
+	return nil
+}
+
+// Sends the request of a chat notification. Any non 2xx answer is an error.
+func sendChatMessage(request *http.Request, platform string) error {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to notify %s: %v", platform, err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%s answered with status %s", platform, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var chatTestSummary = generationSummary{
+	Command: "extract",
+	Period:  generationPeriod{From: "2022-04", To: "2023-03", Months: 12},
+	Users:   35,
+	Total:   4521,
+	Top:     []generationTopUser{{User: "alpha", Total: 512}, {User: "b<r>avo", Total: 20}},
+}
+
+func Test_formatChatSummary(t *testing.T) {
+	assert.Equal(t, "Jenkins contributions from 2022-04 to 2023-03 (12 months): 35 users, 4521 in total.\n"+
+		"1. **alpha** (512)\n"+
+		"2. **b<r>avo** (20)", formatChatSummary(chatTestSummary, false))
+	assert.Equal(t, "<p>Jenkins contributions from 2022-04 to 2023-03 (12 months): 35 users, 4521 in total.</p>"+
+		"<ol><li>alpha (512)</li><li>b&lt;r&gt;avo (20)</li></ol>", formatChatSummary(chatTestSummary, true))
+}
+
+func Test_notifyDiscord(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, notifyDiscord(context.Background(), server.URL+"/api/webhooks/1/abc", chatTestSummary))
+	assert.Equal(t, formatChatSummary(chatTestSummary, false), received["content"])
+
+	assert.Error(t, notifyDiscord(context.Background(), server.URL+"/failing", chatTestSummary))
+}
+
+func Test_notifyMatrix(t *testing.T) {
+	var path, authorization string
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		path = r.URL.EscapedPath()
+		authorization = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("MATRIX_ACCESS_TOKEN", "")
+	assert.ErrorContains(t, notifyMatrix(context.Background(), server.URL, "!room:matrix.org", chatTestSummary, time.Unix(0, 42)), "MATRIX_ACCESS_TOKEN")
+
+	t.Setenv("MATRIX_ACCESS_TOKEN", "secret")
+	err := notifyMatrix(context.Background(), server.URL+"/", "!room:matrix.org", chatTestSummary, time.Unix(0, 42))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/_matrix/client/v3/rooms/%21room:matrix.org/send/m.room.message/aggregator-42", path)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, "m.notice", received["msgtype"])
+	assert.Equal(t, "org.matrix.custom.html", received["format"])
+	assert.Equal(t, formatChatSummary(chatTestSummary, true), received["formatted_body"])
+}
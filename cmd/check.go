@@ -23,16 +23,23 @@ package cmd
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
+	"github.com/jenkins-infra/jenkins-contribution-aggregator/pivottable"
 	"github.com/spf13/cobra"
 )
 
-var isVerboseCheck bool
+// Verbosity level needed to display the details of the checks
+var checkDetailLevel = levelVerbose
+var maxReportedProblems int
+var errorFormat string
 
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
@@ -40,7 +47,31 @@ var checkCmd = &cobra.Command{
 	Short: "Validates if input file has the correct format",
 	Long: `The CHECK command validates whether the input file is processable.
 It must absolutely be generated by the GNU "datamash" pivot function in
-order to be successfully processed.`,
+order to be successfully processed.
+The first column of the header is either empty or named "GroupBy(...)"
+(as generated by the newer datamash versions).
+
+All the problems found in the data lines are reported together (up to the
+number given with "--max-errors").
+
+A data quality score is then printed: the percentage of clean rows (without
+problem, with a valid GitHub username and some activity), followed by the
+counts of suspicious usernames, users without activity and months missing or
+without activity. With "--quality-json", the breakdown is also written as JSON
+("-" for the standard output) to track the quality of the input over time.
+
+With "--spike-factor", the counts exceeding that factor times the rolling
+average of the user (over "--spike-window" months) are reported as warnings,
+as they are likely caused by duplicated imports. "--spikes-file" also writes
+them in a CSV file.
+
+With "--against", the input is also checked against another pivot table (ex:
+the commenters of the same export) before a combined report is generated: both
+must cover the same months and share a plausible part of their active users
+(see "--min-overlap").
+
+With "--error-format sarif", the problems are output as a SARIF 2.1.0 log
+that can be uploaded to GitHub code scanning.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
 			return err
@@ -48,101 +79,257 @@ order to be successfully processed.`,
 		if !isFileValid(args[0]) {
 			return fmt.Errorf("Invalid file")
 		}
+		if errorFormat != "text" && errorFormat != "sarif" {
+			return fmt.Errorf("Invalid error format \"%s\" (should be \"text\" or \"sarif\")", errorFormat)
+		}
+		if againstFileName != "" && !isFileValid(againstFileName) {
+			return fmt.Errorf("Invalid file to check against (\"%s\")", againstFileName)
+		}
+		if minUserOverlap < 0 || minUserOverlap > 100 {
+			return fmt.Errorf("The minimum overlap must be a percentage (between 0 and 100)")
+		}
 		return nil
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		if errorFormat == "sarif" {
+			// Only the SARIF log goes to the standard output
+			logOutput = os.Stderr
+			defer func() { logOutput = os.Stdout }()
+			problems := validatePivotTable(args[0])
+			if len(problems) == 0 && againstFileName != "" {
+				problems = checkAgainst(cmd.Context(), args[0], againstFileName)
+			}
+			if err := writeProblemsAsSarif(os.Stdout, args[0], problems); err != nil {
+				return err
+			}
+			if len(problems) > 0 {
+				return checkFailed(cmd)
+			}
+			return nil
+		}
 
 		// When called standalone, we want to give at least some information
 		isSilent := false
-		if !checkFile(args[0], isSilent) {
+		problems := validateFileProblems(args[0], isSilent)
+		// The consistency with the other table is checked first, so that the file is only
+		// reported as valid when it is consistent
+		var againstProblems []dataProblem
+		if len(problems) == 0 && againstFileName != "" {
+			againstProblems = checkAgainst(cmd.Context(), args[0], againstFileName)
+			printProblems(os.Stdout, againstProblems, maxReportedProblems)
+		}
+		if len(problems) == 0 && len(againstProblems) == 0 {
+			printCheckSuccess(args[0])
+			if err := reportSpikes(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+		}
+
+		quality, err := computeDataQuality(args[0], problems)
+		if err == nil {
+			printDataQuality(os.Stdout, quality)
+			if qualityFileName != "" {
+				if err := writeDataQuality(qualityFileName, quality, os.Stdout); err != nil {
+					return err
+				}
+			}
+		} else if qualityFileName != "" {
+			log.Printf("%v\n", err)
+		}
+
+		if len(problems) > 0 || len(againstProblems) > 0 {
 			fmt.Print("Check failed.")
-			os.Exit(1)
+			return checkFailed(cmd)
 		}
+		return nil
 	},
 }
 
+// Returned when the checked file has problems (already reported): the command exits with 1,
+// once the lock is released and the statistics emitted, without printing the usage.
+var errCheckFailed = errors.New("Check failed")
+
+func checkFailed(cmd *cobra.Command) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return errCheckFailed
+}
+
 // initialize the Cobra processor and flags
 func init() {
-	checkCmd.PersistentFlags().BoolVarP(&isVerboseCheck, "verbose", "v", false, "Displays useful info during the validation")
+	checkCmd.PersistentFlags().IntVarP(&maxReportedProblems, "max-errors", "", 20, "Maximum number of problems reported (0 for all)")
+	checkCmd.PersistentFlags().StringVarP(&errorFormat, "error-format", "", "text", "Format of the reported problems (text or sarif)")
+	addSpikeFlags(checkCmd)
+	checkCmd.PersistentFlags().StringVarP(&againstFileName, "against", "", "", "Also checks the consistency with this other pivot table (same months, overlapping users)")
+	checkCmd.PersistentFlags().Float64VarP(&minUserOverlap, "min-overlap", "", 10, "Minimum percentage of the active users of the smaller dataset also active in the other one (with \"--against\")")
+	checkCmd.PersistentFlags().StringVarP(&qualityFileName, "quality-json", "", "", "Writes the data quality breakdown as JSON in that file (\"-\" for the standard output)")
 
 	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.ValidArgsFunction = completeInputFile
+	_ = checkCmd.RegisterFlagCompletionFunc("error-format", cobra.FixedCompletions([]string{"text", "sarif"}, cobra.ShellCompDirectiveNoFileComp))
 }
 
+// A problem found in the input file
+type dataProblem struct {
+	Line    int // line in the file (starting at 1), 0 if not related to a line
+	Column  int // column in the line (starting at 1), 0 if not related to a column
+	Rule    string
+	Message string
+}
+
+// Identifiers of the kind of problems (used as SARIF rule ids)
+const (
+	ruleReadError       = "read-error"
+	ruleHeaderFormat    = "header-format"
+	ruleMissingData     = "missing-data"
+	ruleColumnCount     = "column-count"
+	ruleInvalidUser     = "invalid-username"
+	ruleInvalidValue    = "invalid-value"
+	ruleNegativeValue   = "negative-value"
+	ruleDatasetMismatch = "dataset-mismatch"
+)
+
 // Loads the data from a file and try to parse it as a CSV
 func checkFile(fileName string, isSilent bool) bool {
+	return len(checkFileProblems(fileName, isSilent)) == 0
+}
+
+// Checks the file and returns the problems found (after printing them)
+func checkFileProblems(fileName string, isSilent bool) []dataProblem {
+	problems := validateFileProblems(fileName, isSilent)
+	if len(problems) == 0 && !isSilent {
+		printCheckSuccess(fileName)
+	}
+	return problems
+}
 
-	var isValidTable = true
+// Validates the file and returns the problems found (after printing them)
+func validateFileProblems(fileName string, isSilent bool) []dataProblem {
+	// When embedded in another command, the details are only displayed with "-vv"
+	checkDetailLevel = levelVerbose
 	if isSilent {
-		isVerboseCheck = false
+		checkDetailLevel = levelDebug
+	}
+
+	span := startSpan("validate")
+	span.setAttribute("file", fileName)
+	problems := validatePivotTable(fileName)
+	span.setAttribute("problems", len(problems))
+	if len(problems) > 0 {
+		span.finish(fmt.Errorf("%d problems found", len(problems)))
+	} else {
+		span.finish(nil)
+	}
+	if len(problems) > 0 {
+		printProblems(os.Stdout, problems, maxReportedProblems)
+		return problems
+	}
+
+	logAt(checkDetailLevel, "  - Number of data columns match header columns.\n")
+	logAt(checkDetailLevel, "  - Records have a valid GitHub username and number of submitted PRs.\n")
+	return nil
+}
+
+// Tells that the file can be processed (with the type of data detected)
+func printCheckSuccess(fileName string) {
+	inputType, _ := detectInputType(fileName)
+	logInfo("\nSuccessfully checked \"%s\"\n   It is a valid Jenkins pivot table of %s and can be processed\n\n", fileName, inputTypeName(inputType))
+}
+
+// Validates the format of the pivot table.
+// Problems with the header stop the validation. The problems in the data lines are all
+// collected so that they can be fixed in one go.
+func validatePivotTable(fileName string) (problems []dataProblem) {
+	firstLine, records, problems := readPivotTableForValidation(fileName)
+	if len(problems) > 0 {
+		return problems
+	}
+	return validatePivotRecords(firstLine, records)
+}
+
+// Reads the header and the data lines to validate. The number of fields isn't checked
+// (it is reported as a problem). The formats other than the datamash pivot table are
+// converted by their parser.
+func readPivotTableForValidation(fileName string) (firstLine []string, records [][]string, problems []dataProblem) {
+	if format := parsedInputFormat(fileName); format != "" {
+		pivot, err := readPivot(fileName, format)
+		if err != nil {
+			log.Printf("Unexpected error loading %s: %v\n", fileName, err)
+			return nil, nil, []dataProblem{{Rule: ruleReadError, Message: fmt.Sprintf("Unexpected error loading %s: %v", fileName, err)}}
+		}
+		countProcessedRows(len(pivot))
+		return pivot[0], pivot[1:], nil
 	}
 
 	f, err := os.Open(fileName)
 	if err != nil {
-		log.Printf("Unable to read input file "+fileName+"\n", err)
-		return false
+		log.Printf("Unable to read input file %s: %v\n", fileName, err)
+		return nil, nil, []dataProblem{{Rule: ruleReadError, Message: fmt.Sprintf("Unable to read input file %s", fileName)}}
 	}
 	defer f.Close()
 
 	r := csv.NewReader(f)
+	// The number of fields is checked for each line (and reported as a problem)
+	r.FieldsPerRecord = -1
 
 	//The first record is not properly formatted, we skip it
 	firstLine, err1 := r.Read()
 	if err1 != nil {
-		log.Printf("Unexpected error loading"+fileName+"\n", err)
-		return false
+		log.Printf("Unexpected error loading %s: %v\n", fileName, err1)
+		return nil, nil, []dataProblem{{Line: 1, Rule: ruleReadError, Message: fmt.Sprintf("Unexpected error loading %s: %v", fileName, err1)}}
 	}
 
-	if isVerboseCheck {
-		fmt.Println("Checking file format")
-		fmt.Printf("  - Number of columns defined in header: %d\n", len(firstLine))
+	records, err = r.ReadAll()
+	if err != nil {
+		log.Printf("Unexpected error loading %s: %v\n", fileName, err)
+		return nil, nil, []dataProblem{{Rule: ruleReadError, Message: fmt.Sprintf("Unexpected error loading %s: %v", fileName, err)}}
+	}
+	countProcessedRows(len(records) + 1)
+	return firstLine, records, nil
+}
+
+// Validates the header and the data lines of a pivot table
+func validatePivotRecords(firstLine []string, records [][]string) (problems []dataProblem) {
+	logAt(checkDetailLevel, "Checking file format\n")
+	logAt(checkDetailLevel, "  - Number of columns defined in header: %d\n", len(firstLine))
+
+	// The header of the newer datamash versions has a named first column
+	if pivottable.IsGroupByColumn(firstLine[0]) {
+		logAt(checkDetailLevel, "  - Header generated by a newer datamash version (first column \"%s\").\n", firstLine[0])
 	}
+	normalizePivotTableHeader(firstLine)
 
 	// first column should be empty
 	if firstLine[0] != "" {
-		fmt.Println("Not the expected first column name (should be empty)")
-		return false
-	}
-	if isVerboseCheck {
-		fmt.Println("  - File's header start with empty column name.")
+		return []dataProblem{{Line: 1, Column: 1, Rule: ruleHeaderFormat, Message: "Not the expected first column name (should be empty)"}}
 	}
+	logAt(checkDetailLevel, "  - File's header start with empty column name.\n")
 
 	//loop through columns to check headings
 	month_regexp, _ := regexp.Compile("20[0-9]{2}-[0-9]{2}")
 	for i, s := range firstLine {
 		if i != 0 {
 			if !month_regexp.MatchString(s) {
-				fmt.Printf("Column header %s is not of the expected format (YYYY-MM)\n", s)
-				return false
+				return []dataProblem{{Line: 1, Column: i + 1, Rule: ruleHeaderFormat, Message: fmt.Sprintf("Column header %s is not of the expected format (YYYY-MM)", s)}}
 			}
 		}
 	}
-	if isVerboseCheck {
-		endMonth := firstLine[len(firstLine)-1]
-		fmt.Printf("  - File's header data column format (\"20YY-MM\"). Most recent data is \"%s\"\n", endMonth)
-	}
+	endMonth := firstLine[len(firstLine)-1]
+	warnAboutPartialMonth(endMonth, time.Now())
+	logAt(checkDetailLevel, "  - File's header data column format (\"20YY-MM\"). Most recent data is \"%s\"\n", endMonth)
 
 	nbrOfColumns := len(firstLine)
 	if nbrOfColumns < 3 {
-		fmt.Printf("Not enough monthly data available\n")
-		return false
-	}
-	if isVerboseCheck {
-		fmt.Printf("  - More than one month data available\n")
-	}
-
-	records, err := r.ReadAll()
-	if err != nil {
-		log.Printf("Unexpected error loading"+fileName+"\n", err)
-		return false
+		return []dataProblem{{Line: 1, Rule: ruleMissingData, Message: "Not enough monthly data available"}}
 	}
+	logAt(checkDetailLevel, "  - More than one month data available\n")
 
 	if len(records) < 2 {
-		fmt.Printf("No data available after the header\n")
-		return false
-	}
-	if isVerboseCheck {
-		fmt.Println("  - At least one submitter's data available")
+		return []dataProblem{{Rule: ruleMissingData, Message: "No data available after the header"}}
 	}
+	logAt(checkDetailLevel, "  - At least one submitter's data available (%d data records)\n", len(records))
 
 	//The GitHub user validation regexp (see https://stackoverflow.com/questions/58726546/github-username-convention-using-regex)
 	// should be regexp.Compile(`^[a-zA-Z0-9]+(?:-[a-zA-Z0-9]+)*$`). But the dataset contains "invalid" data: username ending with a "-" or
@@ -151,42 +338,54 @@ func checkFile(fileName string, isSilent bool) bool {
 
 	//Check the loaded data
 	for i, dataLine := range records {
-		//Skip header line as it has already been checked
-		if i == 0 {
+		// The header is line 1 of the file
+		lineNumber := i + 2
+		nbrOfProblems := len(problems)
+
+		if len(dataLine) != nbrOfColumns {
+			problems = append(problems, dataProblem{Line: lineNumber, Rule: ruleColumnCount, Message: fmt.Sprintf("Line %d has %d columns while the header has %d", lineNumber, len(dataLine), nbrOfColumns)})
 			continue
 		}
+
 		for ii, column := range dataLine {
 			//check the GitHub user (first columns)
 			if ii == 0 {
 				if !(len(column) < 40 && len(column) > 0 && name_exp.MatchString(column)) {
 					if column != "deleted_user" {
-						fmt.Printf("User \"%s\" at line %d does not follow GitHub rules\n", column, i)
-						return false
+						problems = append(problems, dataProblem{Line: lineNumber, Column: ii + 1, Rule: ruleInvalidUser, Message: fmt.Sprintf("User \"%s\" at line %d does not follow GitHub rules", column, lineNumber)})
 					}
 				}
-			} else {
-				// check the other columns is an integer (we don't check the sign)
+			} else if column != "" {
+				// check the other columns is an integer (we don't check the sign), an empty
+				// cell meaning that there is no data for the month
 				if data_value, err := strconv.Atoi(column); err != nil {
-					fmt.Printf("Value \"%s\" at line %d (column %d) isn't an integer\n", column, i, ii)
-					return false
+					problems = append(problems, dataProblem{Line: lineNumber, Column: ii + 1, Rule: ruleInvalidValue, Message: fmt.Sprintf("Value \"%s\" at line %d (column %d) isn't an integer", column, lineNumber, ii+1)})
 				} else {
 					if data_value < 0 {
-						fmt.Printf("Value \"%s\" at line %d (column %d) is negative\n", column, i, ii)
-						return false
+						problems = append(problems, dataProblem{Line: lineNumber, Column: ii + 1, Rule: ruleNegativeValue, Message: fmt.Sprintf("Value \"%s\" at line %d (column %d) is negative", column, lineNumber, ii+1)})
 					}
 				}
 			}
 		}
-	}
 
-	if isVerboseCheck {
-		fmt.Println("  - Number of data columns match header columns.")
-		fmt.Printf("  - Records have a valid GitHub username and number of submitted PRs. (%d data records)\n", len(records)-1)
+		if len(problems) == nbrOfProblems {
+			warnAboutDataLine(dataLine, lineNumber)
+		}
 	}
 
-	if !isSilent {
-		fmt.Printf("\nSuccessfully checked \"%s\"\n   It is a valid Jenkins Submitter Pivot Table and can be processes\n\n", fileName)
-	}
+	return problems
+}
 
-	return isValidTable
+// Prints the problems found, limited to maxProblems (0 means no limit)
+func printProblems(out io.Writer, problems []dataProblem, maxProblems int) {
+	for i, problem := range problems {
+		if maxProblems > 0 && i >= maxProblems {
+			fmt.Fprintf(out, "... and %d more problem(s) (see \"--max-errors\")\n", len(problems)-maxProblems)
+			break
+		}
+		fmt.Fprintln(out, problem.Message)
+	}
+	if len(problems) > 1 {
+		fmt.Fprintf(out, "%d problems found\n", len(problems))
+	}
 }
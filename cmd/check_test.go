@@ -21,7 +21,15 @@ THE SOFTWARE.
 */
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func Test_checkFile(t *testing.T) {
 	type args struct {
@@ -89,6 +97,22 @@ func Test_checkFile(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"several problems",
+			args{
+				fileName: "../test_data/multiple_errors.csv",
+				isSilent: false,
+			},
+			false,
+		},
+		{
+			"newer datamash header (GroupBy)",
+			args{
+				fileName: "../test_data/datamash_groupby_header.csv",
+				isSilent: false,
+			},
+			true,
+		},
 		{
 			"Happy case",
 			args{
@@ -106,3 +130,105 @@ func Test_checkFile(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkFile_inputType(t *testing.T) {
+	out := new(bytes.Buffer)
+	logOutput = out
+	defer func() { logOutput = os.Stdout }()
+	commentersFile := filepath.Join(t.TempDir(), "jenkins-commenters.csv")
+	content, err := os.ReadFile("../test_data/deleted_user_case.csv")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(commentersFile, content, 0644))
+
+	assert.True(t, checkFile("../test_data/deleted_user_case.csv", false))
+	assert.Contains(t, out.String(), "It is a valid Jenkins pivot table of submitters")
+
+	assert.True(t, checkFile(commentersFile, false))
+	assert.Contains(t, out.String(), "It is a valid Jenkins pivot table of commenters")
+}
+
+func Test_validatePivotTable(t *testing.T) {
+	problems := validatePivotTable("../test_data/multiple_errors.csv")
+
+	expected := []dataProblem{
+		{Line: 2, Column: 1, Rule: ruleInvalidUser, Message: "User \"a b\" at line 2 does not follow GitHub rules"},
+		{Line: 3, Column: 2, Rule: ruleInvalidValue, Message: "Value \"x\" at line 3 (column 2) isn't an integer"},
+		{Line: 3, Column: 3, Rule: ruleNegativeValue, Message: "Value \"-1\" at line 3 (column 3) is negative"},
+		{Line: 4, Column: 0, Rule: ruleColumnCount, Message: "Line 4 has 2 columns while the header has 3"},
+	}
+	assert.Equal(t, expected, problems)
+
+	// Header problems stop the validation
+	problems = validatePivotTable("../test_data/bad_first_column.csv")
+	assert.Equal(t, []dataProblem{{Line: 1, Column: 1, Rule: ruleHeaderFormat, Message: "Not the expected first column name (should be empty)"}}, problems)
+
+	assert.Empty(t, validatePivotTable("../test_data/overview.csv"))
+	// The empty cells are the months without data
+	assert.Empty(t, validatePivotTable("../test_data/no_data_months.csv"))
+}
+
+func Test_printProblems(t *testing.T) {
+	problems := []dataProblem{
+		{Line: 2, Column: 1, Rule: ruleInvalidUser, Message: "first"},
+		{Line: 3, Column: 2, Message: "second"},
+		{Line: 3, Column: 3, Message: "third"},
+	}
+
+	out := new(bytes.Buffer)
+	printProblems(out, problems, 2)
+	assert.Equal(t, "first\nsecond\n... and 1 more problem(s) (see \"--max-errors\")\n3 problems found\n", out.String())
+
+	out.Reset()
+	printProblems(out, problems, 0)
+	assert.Equal(t, "first\nsecond\nthird\n3 problems found\n", out.String())
+}
+
+func Test_validatePivotTable_warnings(t *testing.T) {
+	out := new(bytes.Buffer)
+	warningsOutput = out
+	defer func() { warningsOutput = os.Stderr }()
+
+	problems := validatePivotTable("../test_data/suspicious_data.csv")
+
+	assert.Empty(t, problems)
+	expected := "Warning: User \"trailing-\" at line 3 is not a valid GitHub username\n" +
+		"Warning: User \"double--dash\" at line 4 is not a valid GitHub username\n" +
+		"Warning: User \"sleeper\" at line 5 has no activity\n" +
+		"Warning: User \"deleted_user\" at line 6 has no activity\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_normalizePivotTableHeader(t *testing.T) {
+	tests := []struct {
+		header []string
+		want   []string
+	}{
+		{[]string{"", "2023-01", "2023-02"}, []string{"", "2023-01", "2023-02"}},
+		{[]string{"GroupBy(user.login)", "2023-01", " 2023-02 "}, []string{"", "2023-01", "2023-02"}},
+		{[]string{"groupby(1)", "2023-01"}, []string{"", "2023-01"}},
+		{[]string{"submitter", "2023-01"}, []string{"submitter", "2023-01"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.header, ","), func(t *testing.T) {
+			normalizePivotTableHeader(tt.header)
+			assert.Equal(t, tt.want, tt.header)
+		})
+	}
+}
+
+func Test_loadPivotTable_groupByHeader(t *testing.T) {
+	expected, err := loadInputPivotTable("../test_data/deleted_user_case.csv")
+	assert.NoError(t, err)
+
+	records, err := loadInputPivotTable("../test_data/datamash_groupby_header.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, records)
+
+	header, err := loadPivotTableHeader("../test_data/datamash_groupby_header.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, expected[0], header)
+
+	projected, err := loadProjectedPivotTable("../test_data/datamash_groupby_header.csv", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "2020-01", "2020-02"}, projected[0])
+}
@@ -23,20 +23,33 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var compareWith int
+var compareUsers []string
 
 // compareCmd represents the compare command
 var compareCmd = &cobra.Command{
 	Use:   "compare",
 	Short: "Compares two top Submitters extractions to show \"churned\" or \"new\" submitters.",
 	Long: `The COMPARE command will will extract a the Top Submitters as with the EXTRACT command and than
-compare it with an extraction with the same settings but with an X amount of months before`,
+compare it with an extraction with the same settings but with an X amount of months before.
+
+The "--user" flag restricts the output to the given submitters (comma separated list). The table
+then shows how their activity evolved between the two periods, even if they are not top submitters.
+
+With "--baseline 2023-01..2023-06", the top list is compared with the ranking of the average monthly
+activity of these months (scaled to the period) instead of the extraction "--compare" months before.
+
+With "--diff-file", the changes between the two top lists (added, removed and changed users, with
+their old and new totals and ranks) are also written as JSON, for the programmatic consumers.
+
+With "--exit-code", the command exits with 1 if the two top lists differ, 0 if they are
+identical and 2 if it failed (like "diff"), to check in automation whether anything changed.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
 			return err
@@ -44,22 +57,25 @@ compare it with an extraction with the same settings but with an X amount of mon
 		if !isFileValid(args[0]) {
 			return fmt.Errorf("Invalid input file\n")
 		}
-		if !isValidMonth(endMonth, isVerboseExtract) {
+		if !isValidMonth(endMonth, isVerbose()) {
 			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
 		}
 
-		// check the input type
-		switch strings.ToLower(argInputType) {
-		case "submitters":
-			inputType = InputTypeSubmitters
-		case "commenters":
-			inputType = InputTypeCommenters
-		default:
-			inputType = InputTypeUnknown
+		// check the input type (or detect it)
+		var err error
+		if inputType, err = selectInputType(argInputType, args[0]); err != nil {
+			return err
+		}
+
+		if isWithZScore && !isOutputHistory {
+			return fmt.Errorf("\"--zscore\" requires \"--history\"\n")
+		}
+		if err := checkHistoryFlags(); err != nil {
+			return err
 		}
 
-		if inputType == InputTypeUnknown {
-			return fmt.Errorf("%s is an invalid input type\n", argInputType)
+		if compareBaseline != "" && len(compareUsers) > 0 {
+			return fmt.Errorf("\"--baseline\" can't be combined with \"--user\"\n")
 		}
 
 		return nil
@@ -71,8 +87,7 @@ compare it with an extraction with the same settings but with an X amount of mon
 		inputPivotTableName := args[0]
 
 		if !checkFile(inputPivotTableName, isSilent) {
-			fmt.Print("Invalid input file.")
-			os.Exit(1)
+			return fmt.Errorf("Invalid input file")
 		}
 
 		if outputFileName == "top-submitters_YYYY-MM.csv" {
@@ -80,19 +95,74 @@ compare it with an extraction with the same settings but with an X amount of mon
 		}
 
 		// Extract the data (with no offset)
-		result, real_endDate, csv_output_slice := extractData(args[0], topSize, endMonth, period, 0, inputType, isVerboseExtract)
+		result, real_endDate, csv_output_slice := extractData(cmd.Context(), args[0], topSize, endMonth, period, 0, inputType)
 		if !result {
-			return fmt.Errorf("Failed to extract data")
+			return extractionError(cmd.Context(), "Failed to extract data")
 		}
 
-		// Extract the data (with offset this time)
-		result, _, csv_offset_output_slice := extractData(args[0], topSize, endMonth, period, compareWith, inputType, isVerboseExtract)
-		if !result {
-			return fmt.Errorf("Failed to extract offset-ted data")
+		// Extract the data (with offset this time), or the baseline if requested
+		var csv_offset_output_slice [][]string
+		var baselineFrom, baselineTo string
+		if compareBaseline != "" {
+			var err error
+			if baselineFrom, baselineTo, err = parseMonthRange(compareBaseline); err != nil {
+				return err
+			}
+			header, err := loadPivotTableHeader(inputPivotTableName)
+			if err != nil {
+				return err
+			}
+			nbrOfMonths := comparedMonths(header, real_endDate, period)
+			if csv_offset_output_slice, err = extractBaselineData(inputPivotTableName, header, baselineFrom, baselineTo, topSize, nbrOfMonths, inputType); err != nil {
+				return err
+			}
+		} else {
+			result, _, csv_offset_output_slice = extractData(cmd.Context(), args[0], topSize, endMonth, period, compareWith, inputType)
+			if !result {
+				return extractionError(cmd.Context(), "Failed to extract offset-ted data")
+			}
 		}
 
 		enrichedExtractedData := compareExtractedData(csv_output_slice, csv_offset_output_slice, inputType)
 
+		// If requested, compute the machine readable diff of the two top lists
+		var artifacts []string
+		var diff compareDiff
+		if compareDiffFileName != "" || isCompareExitCode {
+			header, err := loadPivotTableHeader(inputPivotTableName)
+			if err != nil {
+				return err
+			}
+			diff, err = buildCompareDiff(header, csv_output_slice, csv_offset_output_slice, endMonth, period, compareWith, inputType)
+			if err != nil {
+				return err
+			}
+			if compareBaseline != "" {
+				diff.Previous = diffPeriod{From: baselineFrom, To: baselineTo}
+			}
+		}
+		if compareDiffFileName != "" {
+			if err := CheckDir(compareDiffFileName); err != nil {
+				return err
+			}
+			if err := writeCompareDiff(compareDiffFileName, diff); err != nil {
+				return err
+			}
+			artifacts = append(artifacts, compareDiffFileName)
+		}
+
+		// If requested, focus on the evolution of the given users
+		if len(compareUsers) > 0 {
+			records, err := loadInputPivotTable(inputPivotTableName)
+			if err != nil {
+				return err
+			}
+			enrichedExtractedData, err = computeUserEvolution(records, compareUsers, endMonth, period, compareWith, enrichedExtractedData, inputType)
+			if err != nil {
+				return err
+			}
+		}
+
 		//FIXME: this seems duplicate with line 76
 
 		//FIXME: change default filename when specifying another type of input
@@ -100,15 +170,11 @@ compare it with an extraction with the same settings but with an X amount of mon
 		if outputFileName == "top-submitters_YYYY-MM.csv" {
 			outputFileName = "top-submitters_" + strings.ToUpper(endMonth) + ".csv"
 		}
-		isMDoutput := isWithMDfileExtension(outputFileName)
-
-		if isVerboseExtract {
-			fileTypeText := "(CSV format)"
-			if isMDoutput {
-				fileTypeText = "(Markdown format)"
-			}
-			fmt.Printf("Writing compare results to \"%s\" %s\n\n", outputFileName, fileTypeText)
+		outputFormat, err := selectRenderFormat(outputFileName, resultFormat)
+		if err != nil {
+			return err
 		}
+		isMDoutput := outputFormat == renderMarkdown
 
 		// Check that the output directory exists
 		dirErr := CheckDir(outputFileName)
@@ -116,35 +182,112 @@ compare it with an extraction with the same settings but with an X amount of mon
 			return dirErr
 		}
 
+		previous, err := keepPreviousOutput(outputFileName)
+		if err != nil {
+			return err
+		}
+
+		// If requested, the files are generated in a staging directory
+		targetFileName := outputFileName
+		var transaction *outputTransaction
+		if isAtomicOutput {
+			if transaction, err = beginOutputTransaction(outputFileName); err != nil {
+				return err
+			}
+			defer transaction.rollback()
+			targetFileName = transaction.stagedName(outputFileName)
+		}
+
+		options := renderOptions{Title: getTopTitle(inputType), IsHistory: isOutputHistory, InputType: inputType}
 		if isMDoutput {
-			introduction := ""
-			if inputType == InputTypeSubmitters {
-				introduction = "# Top Submitters (Compare)\n"
-				buffer := fmt.Sprintf("\nExtraction of the %d top submitters (non-bot PR creators) \nover the %d months before \"%s\".\n", topSize, period, real_endDate)
-				buffer = buffer + fmt.Sprintf("Table shows new and \"churned\" submitters compared \nto the situation %d months before.\n\n", compareWith)
-				introduction = introduction + buffer
-			}
-			if inputType == InputTypeCommenters {
-				introduction = "# Top Commenters (Compare)\n"
-				buffer := fmt.Sprintf("\nExtraction of the %d top (non-bot) commenters \nover the %d months before \"%s\".\n", topSize, period, real_endDate)
-				buffer = buffer + fmt.Sprintf("Table shows new and \"churned\" commenters compared \nto the situation %d months before.\n\n", compareWith)
-				introduction = introduction + buffer
-			}
-			writeDataAsMarkdown(outputFileName, enrichedExtractedData, introduction, isOutputHistory, inputType)
-		} else {
-			writeCSVtoFile(outputFileName, enrichedExtractedData)
+			options.Introduction = getCompareIntroduction(inputType, topSize, period, compareWith, real_endDate, compareUsers)
+			if compareBaseline != "" {
+				options.Introduction = getBaselineIntroduction(inputType, topSize, period, baselineFrom, baselineTo, real_endDate)
+			}
+			if introFileName != "" {
+				header, err := loadPivotTableHeader(inputPivotTableName)
+				if err != nil {
+					return err
+				}
+				options.Introduction, err = renderIntroductionFile(introFileName, newIntroductionData(header, endMonth, period, topSize, compareWith, inputType, enrichedExtractedData))
+				if err != nil {
+					return err
+				}
+			}
+			decorations := getMarkdownDecorations()
+			options.Decorations = &decorations
+		}
+		// The history (and the plots) is written first, for the Markdown to only link to the generated plots
+		historyOutputFilename := generateHistoryFilename(targetFileName, inputType, true)
+		if isOutputHistory {
+			if err := writeHistoryOutput(historyOutputFilename, inputPivotTableName, inputType, enrichedExtractedData); err != nil {
+				return err
+			}
+		}
+		if err := renderTable(targetFileName, outputFormat, enrichedExtractedData, options); err != nil {
+			return err
 		}
 
+		artifacts = append([]string{targetFileName}, artifacts...)
+
 		//if requested, write the history based the supplied top user slice
 		if isOutputHistory {
-			isCompare := true
-			historyOutputFilename := generateHistoryFilename(outputFileName, inputType, isCompare)
+			artifacts = append(artifacts, historyOutputFilename)
+			if isMDoutput {
+				if err := appendHistoryToMarkdown(targetFileName, historyOutputFilename, inputType); err != nil {
+					return err
+				}
+			}
+			if isWithZScore {
+				zscoreOutputFilename := generateZScoreFilename(historyOutputFilename)
+				artifacts = append(artifacts, zscoreOutputFilename)
+				if err := writeZScoreHistory(historyOutputFilename, zscoreOutputFilename); err != nil {
+					return err
+				}
+			}
+			if isWithLongHistory {
+				longHistoryFilename := generateLongHistoryFilename(historyOutputFilename)
+				artifacts = append(artifacts, longHistoryFilename)
+				if err := writeLongHistory(historyOutputFilename, longHistoryFilename, isLongHistorySparse); err != nil {
+					return err
+				}
+			}
+			if isWithLeaderboardHistory {
+				leaderboardFilename := generateLeaderboardHistoryFilename(historyOutputFilename)
+				artifacts = append(artifacts, leaderboardFilename)
+				if err := writeLeaderboardHistory(cmd.Context(), leaderboardFilename, inputPivotTableName, topSize); err != nil {
+					return err
+				}
+			}
+			if isWithDashboardData {
+				dashboardFilename := generateDashboardFilename(historyOutputFilename)
+				artifacts = append(artifacts, dashboardFilename)
+				if err := writeDashboardData(cmd.Context(), dashboardFilename, historyOutputFilename, inputPivotTableName, inputType); err != nil {
+					return err
+				}
+			}
+		}
 
-			if err := writeHistoryOutput(historyOutputFilename, inputPivotTableName, inputType, enrichedExtractedData); err != nil {
+		if transaction != nil {
+			if err := transaction.commit(); err != nil {
 				return err
 			}
+			artifacts = transaction.finalNames(artifacts)
+		}
+		if err := previous.showDiff(outputFileName); err != nil {
+			return err
+		}
+
+		if err := notifyGeneration(cmd.Context(), "compare", inputPivotTableName, enrichedExtractedData, artifacts); err != nil {
+			return err
 		}
 
+		// As "diff", the exit code tells whether the top lists differ (set by Execute, once the run is finished)
+		if isCompareExitCode && !diff.isEmpty() {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return errLeaderboardsDiffer
+		}
 		return nil
 	},
 }
@@ -154,14 +297,32 @@ func init() {
 
 	// Here you will define your flags and configuration settings.
 	compareCmd.PersistentFlags().StringVarP(&outputFileName, "out", "o", "top-submitters_YYYY-MM.csv", "Output file name.")
-	compareCmd.PersistentFlags().StringVarP(&argInputType, "type", "", "submitters", "The type of data being analyzed. Can be either \"submitters\" or \"commenters\"")
+	compareCmd.PersistentFlags().StringVarP(&argInputType, "type", "", inputTypeAuto, "The type of data being analyzed: \"submitters\", \"commenters\" or \"auto\" (detected from the input file)")
 	compareCmd.PersistentFlags().IntVarP(&topSize, "topSize", "t", 35, "Number of top submitters to extract.")
 	compareCmd.PersistentFlags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate.")
 	compareCmd.PersistentFlags().IntVarP(&compareWith, "compare", "c", 3, "Number of months back to compare with.")
 	compareCmd.PersistentFlags().StringVarP(&endMonth, "month", "m", "latest", "Month to extract top submitters.")
 	compareCmd.PersistentFlags().BoolVarP(&isOutputHistory, "history", "", false, "Outputs the available activity history for the top submitters")
-
-	compareCmd.PersistentFlags().BoolVarP(&isVerboseExtract, "verbose", "v", false, "Displays useful info during the extraction")
+	compareCmd.PersistentFlags().IntVarP(&maxReportedProblems, "max-errors", "", 20, "Maximum number of input problems reported (0 for all)")
+	compareCmd.PersistentFlags().StringVarP(&compareBaseline, "baseline", "", "", "Compares with the average activity of these months (YYYY-MM..YYYY-MM) instead of \"--compare\"")
+	compareCmd.PersistentFlags().StringVarP(&compareDiffFileName, "diff-file", "", "", "Also writes the changes between the two top lists as JSON in that file")
+	compareCmd.PersistentFlags().BoolVarP(&isCompareExitCode, "exit-code", "", false, "Exits with 1 if the two top lists differ (0 if they are identical, 2 on errors)")
+	compareCmd.PersistentFlags().StringSliceVarP(&compareUsers, "user", "u", []string{}, "Restricts the output to the evolution of the given submitters (comma separated)")
+
+	addIntroFileFlag(compareCmd)
+	addAnnotationsFlag(compareCmd)
+	addDecorationFlags(compareCmd)
+	addNotifyFlags(compareCmd)
+	addZScoreFlag(compareCmd)
+	addHistoryFlags(compareCmd)
+	addFormatFlag(compareCmd)
+	addAtomicOutputFlag(compareCmd)
+	addShowDiffFlag(compareCmd)
+
+	// dynamic completion of the arguments and flags
+	compareCmd.ValidArgsFunction = completeInputFile
+	_ = compareCmd.RegisterFlagCompletionFunc("type", completeInputType)
+	_ = compareCmd.RegisterFlagCompletionFunc("month", completeMonth)
 }
 
 func compareExtractedData(recentData [][]string, oldData [][]string, inputType InputType) (enrichedExtractedData [][]string) {
@@ -218,3 +379,93 @@ func isSubmitterFound(dataset [][]string, submitter string) (found bool) {
 	}
 	return false
 }
+
+// Builds a table showing, for the requested users, the totals of the current and
+// previous (offset-ted) periods, the evolution and their status in the top lists.
+func computeUserEvolution(records [][]string, users []string, endMonth string, period int, offset int, enrichedExtractedData [][]string, inputType InputType) ([][]string, error) {
+	var output_slice [][]string
+
+	if inputType == InputTypeCommenters {
+		output_slice = append(output_slice, []string{"Commenter", "Comments", "Previous_Comments", "Evolution", "Status"})
+	} else {
+		output_slice = append(output_slice, []string{"Submitter", "Total_PRs", "Previous_PRs", "Evolution", "Status"})
+	}
+
+	recentFirst, recentLast, _, _ := getBoundaries(records, endMonth, period, 0)
+	oldFirst, oldLast, _, _ := getBoundaries(records, endMonth, period, offset)
+	if oldLast == 0 {
+		return nil, fmt.Errorf("Failed to compute the previous period")
+	}
+
+	for _, requestedUser := range users {
+		// As with "show", the usernames are case insensitive (GitHub logins are)
+		index := -1
+		for i, dataLine := range records {
+			if i != 0 && strings.EqualFold(dataLine[0], requestedUser) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("Supplied name (%s) was not found in input pivot table file", requestedUser)
+		}
+		user := records[index][0]
+
+		recentTotal := sumColumns(records[index], recentFirst, recentLast)
+		oldTotal := sumColumns(records[index], oldFirst, oldLast)
+
+		evolution := strconv.Itoa(recentTotal - oldTotal)
+		if recentTotal > oldTotal {
+			evolution = "+" + evolution
+		}
+
+		status := "outside top"
+		for i, line := range enrichedExtractedData {
+			if i != 0 && line[0] == user {
+				status = line[2]
+				break
+			}
+		}
+
+		output_slice = append(output_slice, []string{user, strconv.Itoa(recentTotal), strconv.Itoa(oldTotal), evolution, status})
+	}
+
+	return output_slice, nil
+}
+
+// Sums the values of a pivot table line between the given columns (included)
+func sumColumns(dataLine []string, firstColumn int, lastColumn int) int {
+	total := 0
+	for column := firstColumn; column <= lastColumn; column++ {
+		// We don't treat conversion errors as the file has already been checked
+		value, _ := strconv.Atoi(dataLine[column])
+		total = total + value
+	}
+	return total
+}
+
+// Returns the introduction of the Markdown output of a compare
+func getCompareIntroduction(inputType InputType, topSize int, period int, compareWith int, real_endDate string, users []string) string {
+	introduction := ""
+	if inputType == InputTypeSubmitters {
+		introduction = "# Top Submitters (Compare)\n"
+		buffer := fmt.Sprintf("\nExtraction of the %d top submitters (non-bot PR creators) \nover the %d months before \"%s\".\n", topSize, period, real_endDate)
+		buffer = buffer + fmt.Sprintf("Table shows new and \"churned\" submitters compared \nto the situation %d months before.\n\n", compareWith)
+		introduction = introduction + buffer + getFocusedUsersText(users)
+	}
+	if inputType == InputTypeCommenters {
+		introduction = "# Top Commenters (Compare)\n"
+		buffer := fmt.Sprintf("\nExtraction of the %d top (non-bot) commenters \nover the %d months before \"%s\".\n", topSize, period, real_endDate)
+		buffer = buffer + fmt.Sprintf("Table shows new and \"churned\" commenters compared \nto the situation %d months before.\n\n", compareWith)
+		introduction = introduction + buffer + getFocusedUsersText(users)
+	}
+	return introduction
+}
+
+// Returns the sentence listing the users the compare is focused on (if any)
+func getFocusedUsersText(users []string) string {
+	if len(users) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Focused on: %s.\n\n", strings.Join(users, ", "))
+}
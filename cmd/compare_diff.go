@@ -0,0 +1,169 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+var compareDiffFileName string
+var isCompareExitCode bool
+
+// Returned by compare with "--exit-code" when the two top lists differ (exits with 1, as "diff")
+var errLeaderboardsDiffer = errors.New("The two top lists differ")
+
+// Machine readable result of a compare: the changes between the previous and the current top lists
+type compareDiff struct {
+	Type     string          `json:"type"` // "submitters" or "commenters"
+	Current  diffPeriod      `json:"current"`
+	Previous diffPeriod      `json:"previous"`
+	Added    []diffEntry     `json:"added"`   // in the current top list only
+	Removed  []diffEntry     `json:"removed"` // in the previous top list only ("churned")
+	Changed  []diffEntry     `json:"changed"` // in both, with a different total or rank
+	Summary  diffSummaryInfo `json:"summary"`
+}
+
+type diffPeriod struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// A user of the diff. The values of the list the user is not part of are null.
+type diffEntry struct {
+	User     string `json:"user"`
+	OldTotal *int   `json:"old_total"`
+	NewTotal *int   `json:"new_total"`
+	OldRank  *int   `json:"old_rank"`
+	NewRank  *int   `json:"new_rank"`
+	RankMove int    `json:"rank_move"` // positive when moving up, 0 if added or removed
+}
+
+type diffSummaryInfo struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Position of a user in an extraction
+type rankedTotal struct {
+	Total int
+	Rank  int
+}
+
+// Returns the position of the users of an extraction (sorted on the totals). The ex-aequo share the same rank.
+func rankExtraction(data [][]string) map[string]rankedTotal {
+	positions := make(map[string]rankedTotal)
+	rank, previousTotal := 0, -1
+	for i, line := range data {
+		if i == 0 {
+			continue
+		}
+		total, _ := strconv.Atoi(line[1])
+		if total != previousTotal {
+			rank = i
+			previousTotal = total
+		}
+		positions[line[0]] = rankedTotal{Total: total, Rank: rank}
+	}
+	return positions
+}
+
+// Computes the diff between the current and previous extractions. The periods are computed
+// from the header of the pivot table.
+func buildCompareDiff(header []string, recentData [][]string, oldData [][]string, endMonth string, period int, offset int, inputType InputType) (compareDiff, error) {
+	_, recentLast, recentFrom, recentTo := getBoundaries([][]string{header}, endMonth, period, 0)
+	_, oldLast, oldFrom, oldTo := getBoundaries([][]string{header}, endMonth, period, offset)
+	if recentLast == 0 || oldLast == 0 {
+		return compareDiff{}, fmt.Errorf("Failed to compute the periods of the diff")
+	}
+
+	diff := compareDiff{
+		Type:     "submitters",
+		Current:  diffPeriod{From: recentFrom, To: recentTo},
+		Previous: diffPeriod{From: oldFrom, To: oldTo},
+		Added:    []diffEntry{},
+		Removed:  []diffEntry{},
+		Changed:  []diffEntry{},
+	}
+	if inputType == InputTypeCommenters {
+		diff.Type = "commenters"
+	}
+
+	recent := rankExtraction(recentData)
+	old := rankExtraction(oldData)
+	for user, newPosition := range recent {
+		newPosition := newPosition
+		oldPosition, isFound := old[user]
+		if !isFound {
+			diff.Added = append(diff.Added, diffEntry{User: user, NewTotal: &newPosition.Total, NewRank: &newPosition.Rank})
+			continue
+		}
+		if oldPosition == newPosition {
+			diff.Summary.Unchanged++
+			continue
+		}
+		diff.Changed = append(diff.Changed, diffEntry{User: user, OldTotal: &oldPosition.Total, NewTotal: &newPosition.Total,
+			OldRank: &oldPosition.Rank, NewRank: &newPosition.Rank, RankMove: oldPosition.Rank - newPosition.Rank})
+	}
+	for user, oldPosition := range old {
+		oldPosition := oldPosition
+		if _, isFound := recent[user]; !isFound {
+			diff.Removed = append(diff.Removed, diffEntry{User: user, OldTotal: &oldPosition.Total, OldRank: &oldPosition.Rank})
+		}
+	}
+
+	sortDiffEntries(diff.Added, func(e diffEntry) int { return *e.NewRank })
+	sortDiffEntries(diff.Changed, func(e diffEntry) int { return *e.NewRank })
+	sortDiffEntries(diff.Removed, func(e diffEntry) int { return *e.OldRank })
+	diff.Summary.Added, diff.Summary.Removed, diff.Summary.Changed = len(diff.Added), len(diff.Removed), len(diff.Changed)
+	return diff, nil
+}
+
+// Returns true if the two top lists are identical (same users, totals and ranks)
+func (diff compareDiff) isEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// Sorts the entries on the given rank (and the user names for the ex-aequo), so that the output is stable
+func sortDiffEntries(entries []diffEntry, rankOf func(diffEntry) int) {
+	sort.Slice(entries, func(i, j int) bool {
+		if rankOf(entries[i]) != rankOf(entries[j]) {
+			return rankOf(entries[i]) < rankOf(entries[j])
+		}
+		return entries[i].User < entries[j].User
+	})
+}
+
+// Writes the diff as indented JSON
+func writeCompareDiff(fileName string, diff compareDiff) error {
+	content, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(content, '\n'), 0644)
+}
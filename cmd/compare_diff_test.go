@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intRef(value int) *int {
+	return &value
+}
+
+func Test_rankExtraction(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"bravo", "7"},
+		{"charlie", "7"},
+		{"delta", "3"},
+	}
+
+	assert.Equal(t, map[string]rankedTotal{
+		"alpha":   {Total: 12, Rank: 1},
+		"bravo":   {Total: 7, Rank: 2},
+		"charlie": {Total: 7, Rank: 2},
+		"delta":   {Total: 3, Rank: 4},
+	}, rankExtraction(data))
+}
+
+func Test_buildCompareDiff(t *testing.T) {
+	header := []string{"", "2023-01", "2023-02", "2023-03", "2023-04"}
+	recent := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"charlie", "9"},
+		{"bravo", "7"},
+		{"echo", "2"},
+	}
+	old := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"bravo", "10"},
+		{"delta", "5"},
+		{"charlie", "3"},
+	}
+
+	diff, err := buildCompareDiff(header, recent, old, "latest", 2, 1, InputTypeSubmitters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, diffPeriod{From: "2023-03", To: "2023-04"}, diff.Current)
+	assert.Equal(t, diffPeriod{From: "2023-02", To: "2023-03"}, diff.Previous)
+	assert.Equal(t, []diffEntry{{User: "echo", NewTotal: intRef(2), NewRank: intRef(4)}}, diff.Added)
+	assert.Equal(t, []diffEntry{{User: "delta", OldTotal: intRef(5), OldRank: intRef(3)}}, diff.Removed)
+	assert.Equal(t, []diffEntry{
+		{User: "charlie", OldTotal: intRef(3), NewTotal: intRef(9), OldRank: intRef(4), NewRank: intRef(2), RankMove: 2},
+		{User: "bravo", OldTotal: intRef(10), NewTotal: intRef(7), OldRank: intRef(2), NewRank: intRef(3), RankMove: -1},
+	}, diff.Changed)
+	assert.Equal(t, diffSummaryInfo{Added: 1, Removed: 1, Changed: 2, Unchanged: 1}, diff.Summary)
+}
+
+func Test_compareDiff_isEmpty(t *testing.T) {
+	header := []string{"", "2023-01", "2023-02", "2023-03"}
+	data := [][]string{{"Submitter", "Total_PRs"}, {"alpha", "12"}, {"bravo", "7"}}
+
+	diff, err := buildCompareDiff(header, data, data, "latest", 1, 1, InputTypeSubmitters)
+	assert.NoError(t, err)
+	assert.True(t, diff.isEmpty())
+	assert.Equal(t, 2, diff.Summary.Unchanged)
+
+	// A different total is a change, even with the same rank
+	diff, err = buildCompareDiff(header, data, [][]string{{"Submitter", "Total_PRs"}, {"alpha", "12"}, {"bravo", "6"}}, "latest", 1, 1, InputTypeSubmitters)
+	assert.NoError(t, err)
+	assert.False(t, diff.isEmpty())
+}
+
+func Test_ExecuteCompare_diffFile(t *testing.T) {
+	tempDir := t.TempDir()
+	diffFile := filepath.Join(tempDir, "diff.json")
+	defer func() { compareDiffFileName = "" }()
+
+	rootCmd.SetArgs([]string{"compare", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "5", "-c", "3", "--history=false",
+		"-o", filepath.Join(tempDir, "compare.csv"), "--diff-file", diffFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(diffFile)
+	assert.NoError(t, err)
+	var diff compareDiff
+	assert.NoError(t, json.Unmarshal(content, &diff))
+	assert.Equal(t, "submitters", diff.Type)
+	assert.Equal(t, 5, diff.Summary.Changed)
+	assert.Equal(t, diffEntry{User: "lemeurherve", OldTotal: intRef(692), NewTotal: intRef(870), OldRank: intRef(4), NewRank: intRef(2), RankMove: 2}, diff.Changed[1])
+}
+
+func Test_ExecuteCompare_exitCode(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isCompareExitCode = false
+		compareCmd.SilenceErrors = false
+		compareCmd.SilenceUsage = false
+	}()
+
+	rootCmd.SetArgs([]string{"compare", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "5", "-c", "3", "--history=false",
+		"-o", filepath.Join(tempDir, "compare.csv"), "--exit-code"})
+	err := rootCmd.Execute()
+
+	// The differing top lists are reported to Execute, which exits with 1 once the run is finished
+	assert.ErrorIs(t, err, errLeaderboardsDiffer)
+	assert.FileExists(t, filepath.Join(tempDir, "compare.csv"))
+
+	rootCmd.SetArgs([]string{"compare", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "5", "-c", "3", "--history=false",
+		"-o", filepath.Join(tempDir, "compare.csv"), "--exit-code"})
+	assert.Equal(t, 1, executeRoot(context.Background()))
+	rootCmd.SetArgs([]string{"compare", "missing.csv", "-m", "latest", "--exit-code"})
+	assert.Equal(t, 2, executeRoot(context.Background()))
+}
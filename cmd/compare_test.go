@@ -23,6 +23,8 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -285,3 +287,59 @@ func Test_ExecuteSubmitterCompareWithHistory_integrationTest(t *testing.T) {
 }
 
 //TODO: validate CSV output
+
+func Test_computeUserEvolution(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "1", "5", "2", "0"},
+		{"bravo", "4", "0", "2", "9"},
+		{"charly", "0", "1", "7", "0"},
+	}
+	enriched := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"bravo", "11", "new"},
+		{"charly", "8", ""},
+		{"alpha", "", "churned"},
+	}
+
+	// The usernames are case insensitive, the output uses the ones of the pivot table
+	got, err := computeUserEvolution(records, []string{"Bravo", "alpha"}, "latest", 2, 1, enriched, InputTypeSubmitters)
+
+	assert.NoError(t, err)
+	want := [][]string{
+		{"Submitter", "Total_PRs", "Previous_PRs", "Evolution", "Status"},
+		{"bravo", "11", "2", "+9", "new"},
+		{"alpha", "2", "7", "-5", "churned"},
+	}
+	assert.Equal(t, want, got)
+
+	_, err = computeUserEvolution(records, []string{"zulu"}, "latest", 2, 1, enriched, InputTypeSubmitters)
+	assert.EqualError(t, err, "Supplied name (zulu) was not found in input pivot table file")
+}
+
+func Test_ExecuteCompare_singleUserHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "compare.md")
+	defer func() {
+		compareUsers = []string{}
+		isOutputHistory = false
+	}()
+
+	rootCmd.SetArgs([]string{"compare", "../test_data/overview.csv", "-m", "latest", "-c", "3", "-o", outputFile,
+		"--user", "AAYUSHsaini101", "--history"})
+	assert.NoError(t, rootCmd.Execute())
+
+	// The history of the single user keeps the status, and the Markdown links to the generated plot
+	history, err := os.ReadFile(filepath.Join(tempDir, "top_submitters_evolution_fullHistory.csv"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(history), "\nAayushSaini101 (outside top),")
+	assert.FileExists(t, filepath.Join(tempDir, "plot", "AayushSaini101.png"))
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "](plot/AayushSaini101.png)")
+}
+
+func Test_getFocusedUsersText(t *testing.T) {
+	assert.Equal(t, "", getFocusedUsersText([]string{}))
+	assert.Equal(t, "Focused on: alpha, bravo.\n\n", getFocusedUsersText([]string{"alpha", "bravo"}))
+}
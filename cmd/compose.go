@@ -0,0 +1,279 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// Endpoint of the X API to create a post (changed by the tests)
+var xPostURL = "https://api.twitter.com/2/tweets"
+
+var composeTopSize int
+var composePlatform string
+var composeTemplateFileName string
+var composeOutputFileName string
+var mastodonInstance string
+var isComposePost bool
+
+// Maximum length of a post on each platform
+var composeLengthLimits = map[string]int{
+	"mastodon": 500,
+	"x":        280,
+}
+
+const defaultComposeTemplate = `Congratulations to the top Jenkins {{.Type}} of {{.Month}}!
+{{range .Users}}{{.Rank}}. {{.User}} ({{.Total}})
+{{end}}Thank you all for the {{.Total}} contributions of the last {{.Period}} months! #Jenkins #OpenSource`
+
+// Variables available in the post templates
+type composeData struct {
+	Month      string        // last month of the period
+	FirstMonth string        // first month of the period
+	Period     int           // number of months of the period
+	Type       string        // "submitters" or "commenters"
+	Total      int           // total of all the users of the period
+	Users      []composeUser // the top users (fewer than requested if the post would be too long)
+}
+
+type composeUser struct {
+	Rank  int
+	User  string
+	Total int
+}
+
+// composeCmd represents the compose command
+var composeCmd = &cobra.Command{
+	Use:   "compose [input file]",
+	Short: "Writes a social media post congratulating the top submitters",
+	Long: `The COMPOSE command writes a ready-to-post text congratulating the top submitters
+(or commenters) of the month, for Mastodon (500 characters) or X (280 characters).
+
+The text is rendered from a Go template ("--template"). It can use {{.Month}}, {{.FirstMonth}},
+{{.Period}}, {{.Type}}, {{.Total}} and {{.Users}} (with {{.Rank}}, {{.User}} and {{.Total}}).
+When the post is too long for the platform, the last users are dropped until it fits.
+
+The text is written to the standard output (or the "--out" file). With "--post", it is
+also posted: on Mastodon with the MASTODON_ACCESS_TOKEN of an account of "--mastodon-instance",
+on X with the X_ACCESS_TOKEN (OAuth 2.0 user token with the "tweet.write" scope).`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		if _, ok := composeLengthLimits[composePlatform]; !ok {
+			return fmt.Errorf("Invalid platform \"%s\" (should be \"mastodon\" or \"x\")\n", composePlatform)
+		}
+		var err error
+		inputType, err = selectInputType(argInputType, args[0])
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		data, err := getComposeData(cmd.Context(), args[0], composeTopSize, endMonth, period, inputType)
+		if err != nil {
+			return err
+		}
+
+		postTemplate := defaultComposeTemplate
+		if composeTemplateFileName != "" {
+			content, err := readTextFile(composeTemplateFileName)
+			if err != nil {
+				return fmt.Errorf("Unable to read the template: %v", err)
+			}
+			postTemplate = content
+		}
+		post, err := composePost(postTemplate, data, composeLengthLimits[composePlatform])
+		if err != nil {
+			return err
+		}
+
+		if composeOutputFileName == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), post)
+		} else {
+			if err := CheckDir(composeOutputFileName); err != nil {
+				return err
+			}
+			if err := os.WriteFile(composeOutputFileName, []byte(post+"\n"), 0644); err != nil {
+				return err
+			}
+			logInfo("Post written to \"%s\"\n", composeOutputFileName)
+		}
+
+		if isComposePost {
+			return publishPost(composePlatform, post)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(composeCmd)
+
+	composeCmd.Flags().IntVarP(&composeTopSize, "topSize", "t", 5, "Number of top submitters to congratulate.")
+	composeCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate.")
+	composeCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Month to extract top submitters.")
+	composeCmd.Flags().StringVarP(&argInputType, "type", "", inputTypeAuto, "The type of data being analyzed: \"submitters\", \"commenters\" or \"auto\" (detected from the input file)")
+	composeCmd.Flags().StringVarP(&composePlatform, "platform", "", "mastodon", "Platform the post is written for (\"mastodon\" or \"x\")")
+	composeCmd.Flags().StringVarP(&composeTemplateFileName, "template", "", "", "Go template of the post (a default text is used otherwise)")
+	composeCmd.Flags().StringVarP(&composeOutputFileName, "out", "o", "", "Output file name (default is the standard output)")
+	composeCmd.Flags().BoolVarP(&isComposePost, "post", "", false, "Posts the text on the platform (with the MASTODON_ACCESS_TOKEN or X_ACCESS_TOKEN)")
+	composeCmd.Flags().StringVarP(&mastodonInstance, "mastodon-instance", "", "https://fosstodon.org", "URL of the Mastodon instance of the posting account")
+
+	composeCmd.ValidArgsFunction = completeInputFile
+	_ = composeCmd.RegisterFlagCompletionFunc("month", completeMonth)
+	_ = composeCmd.RegisterFlagCompletionFunc("type", completeInputType)
+	_ = composeCmd.RegisterFlagCompletionFunc("platform", cobra.FixedCompletions([]string{"mastodon", "x"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Computes the template variables from the pivot table
+func getComposeData(ctx context.Context, inputFilename string, topSize int, endMonth string, period int, inputType InputType) (composeData, error) {
+	result, realEndMonth, extraction := extractData(ctx, inputFilename, topSize, endMonth, period, 0, inputType)
+	if !result {
+		return composeData{}, extractionError(ctx, "Failed to extract data")
+	}
+	header, err := loadPivotTableHeader(inputFilename)
+	if err != nil {
+		return composeData{}, err
+	}
+	firstColumn, lastColumn, firstMonth, _ := getBoundaries([][]string{header}, realEndMonth, period, 0)
+	records, err := loadProjectedPivotTable(inputFilename, firstColumn, lastColumn)
+	if err != nil {
+		return composeData{}, err
+	}
+
+	data := composeData{
+		Month:      realEndMonth,
+		FirstMonth: firstMonth,
+		Period:     lastColumn - firstColumn + 1,
+		Type:       "submitters",
+	}
+	if inputType == InputTypeCommenters {
+		data.Type = "commenters"
+	}
+	for _, entry := range computeLeaderboard(records, 1, len(records[0])-1) {
+		data.Total += entry.Total
+	}
+	// The extraction is sorted: the ex-aequo share the rank of the first of them
+	for i, line := range extraction[1:] {
+		total, _ := strconv.Atoi(line[1])
+		user := composeUser{Rank: i + 1, User: line[0], Total: total}
+		if i > 0 && data.Users[i-1].Total == total {
+			user.Rank = data.Users[i-1].Rank
+		}
+		data.Users = append(data.Users, user)
+	}
+	return data, nil
+}
+
+// Renders the post, dropping the last users until it fits in maxLength characters
+func composePost(postTemplate string, data composeData, maxLength int) (string, error) {
+	parsedTemplate, err := template.New("post").Parse(postTemplate)
+	if err != nil {
+		return "", fmt.Errorf("Invalid post template: %v", err)
+	}
+
+	allUsers := data.Users
+	for count := len(allUsers); count >= 0; count-- {
+		data.Users = allUsers[:count]
+		var b strings.Builder
+		if err := parsedTemplate.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("Failed to render the post: %v", err)
+		}
+		post := strings.TrimSpace(b.String())
+		if utf8.RuneCountInString(post) <= maxLength {
+			if count < len(allUsers) {
+				logVerbose("Only %d of the %d users fit in the post\n", count, len(allUsers))
+			}
+			return post, nil
+		}
+	}
+	return "", fmt.Errorf("The post is longer than %d characters, even without users", maxLength)
+}
+
+// Posts the text on Mastodon or X
+func publishPost(platform string, post string) error {
+	var endpoint, token string
+	var payload map[string]string
+	switch platform {
+	case "mastodon":
+		endpoint = strings.TrimSuffix(mastodonInstance, "/") + "/api/v1/statuses"
+		token = os.Getenv("MASTODON_ACCESS_TOKEN")
+		payload = map[string]string{"status": post}
+	case "x":
+		endpoint = xPostURL
+		token = os.Getenv("X_ACCESS_TOKEN")
+		payload = map[string]string{"text": post}
+	}
+	if token == "" {
+		return fmt.Errorf("An access token is required to post on %s (%s_ACCESS_TOKEN environment variable)", platform, strings.ToUpper(platform))
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to post on %s: %v", platform, err)
+	}
+	defer response.Body.Close()
+	answer, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%s answered with status %s: %s", platform, response.Status, strings.TrimSpace(string(answer)))
+	}
+	logInfo("Posted on %s\n", platform)
+	return nil
+}
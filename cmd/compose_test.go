@@ -0,0 +1,131 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getComposeData(t *testing.T) {
+	data, err := getComposeData(context.Background(), "../test_data/overview.csv", 3, "2023-03", 3, InputTypeSubmitters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-03", data.Month)
+	assert.Equal(t, "2023-01", data.FirstMonth)
+	assert.Equal(t, 3, data.Period)
+	assert.Equal(t, "submitters", data.Type)
+	expected := []composeUser{{1, "lemeurherve", 301}, {2, "basil", 294}, {3, "MarkEWaite", 227}}
+	assert.Equal(t, expected, data.Users)
+	assert.Greater(t, data.Total, 301+294+227)
+}
+
+func Test_composePost(t *testing.T) {
+	data := composeData{
+		Month: "2023-03",
+		Type:  "submitters",
+		Users: []composeUser{{1, "alpha", 12}, {1, "bravo", 12}, {3, "charlie", 5}},
+	}
+	postTemplate := "Top of {{.Month}}:{{range .Users}} {{.Rank}}.{{.User}}{{end}}"
+
+	tests := []struct {
+		name      string
+		maxLength int
+		want      string
+		wantErr   bool
+	}{
+		{"everybody fits", 100, "Top of 2023-03: 1.alpha 1.bravo 3.charlie", false},
+		{"last user dropped", 40, "Top of 2023-03: 1.alpha 1.bravo", false},
+		{"only one user", 25, "Top of 2023-03: 1.alpha", false},
+		{"nothing fits", 10, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := composePost(postTemplate, data, tt.maxLength)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := composePost("{{.Unknown", data, 100)
+	assert.Error(t, err)
+}
+
+func Test_composePost_defaultTemplate(t *testing.T) {
+	data := composeData{Month: "2023-03", Period: 12, Type: "submitters", Total: 4521, Users: []composeUser{{1, "alpha", 12}}}
+
+	post, err := composePost(defaultComposeTemplate, data, composeLengthLimits["x"])
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Congratulations to the top Jenkins submitters of 2023-03!\n1. alpha (12)\n"+
+		"Thank you all for the 4521 contributions of the last 12 months! #Jenkins #OpenSource", post)
+}
+
+func Test_publishPost(t *testing.T) {
+	received := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		payload := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received[r.URL.Path] = payload
+	}))
+	defer server.Close()
+	defer func(instance string, url string) { mastodonInstance, xPostURL = instance, url }(mastodonInstance, xPostURL)
+	mastodonInstance = server.URL
+	xPostURL = server.URL + "/2/tweets"
+
+	t.Setenv("MASTODON_ACCESS_TOKEN", "")
+	assert.ErrorContains(t, publishPost("mastodon", "Hello"), "MASTODON_ACCESS_TOKEN")
+
+	t.Setenv("MASTODON_ACCESS_TOKEN", "secret")
+	t.Setenv("X_ACCESS_TOKEN", "secret")
+	assert.NoError(t, publishPost("mastodon", "Hello"))
+	assert.NoError(t, publishPost("x", "Hi"))
+	assert.Equal(t, map[string]map[string]string{
+		"/api/v1/statuses": {"status": "Hello"},
+		"/2/tweets":        {"text": "Hi"},
+	}, received)
+}
+
+func Test_ExecuteCompose(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "post.txt")
+	defer func() {
+		_ = composeCmd.Flags().Set("out", "")
+		_ = composeCmd.Flags().Set("platform", "mastodon")
+	}()
+
+	rootCmd.SetArgs([]string{"compose", "../test_data/overview.csv", "-m", "2023-03", "-p", "3", "-t", "3", "--platform", "x", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "Congratulations to the top Jenkins submitters of 2023-03!\n1. lemeurherve (301)\n"))
+}
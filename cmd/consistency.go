@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+var againstFileName string
+var minUserOverlap float64
+
+// Overlap of the active users of two datasets
+type datasetConsistency struct {
+	ActiveA int     // active users of the first dataset
+	ActiveB int     // active users of the second dataset
+	Common  int     // users active in both (the case being ignored)
+	Overlap float64 // percentage of the active users of the smaller dataset also active in the other one
+}
+
+// Checks that two datasets (ex: the submitters and the commenters of the same export) can be combined:
+// they must have the same months and a plausible share of common active users (at least minOverlap percent).
+func checkConsistency(datasetA *Dataset, datasetB *Dataset, minOverlap float64) (datasetConsistency, []dataProblem) {
+	var problems []dataProblem
+	if message := compareMonths(datasetA, datasetB); message != "" {
+		problems = append(problems, dataProblem{Rule: ruleDatasetMismatch, Message: message})
+	}
+
+	activeA, activeB := activeUsers(datasetA), activeUsers(datasetB)
+	consistency := datasetConsistency{ActiveA: len(activeA), ActiveB: len(activeB)}
+	for user := range activeA {
+		if activeB[user] {
+			consistency.Common++
+		}
+	}
+	smaller, smallerName, otherName := len(activeA), datasetA.Name, datasetB.Name
+	if len(activeB) < smaller {
+		smaller, smallerName, otherName = len(activeB), datasetB.Name, datasetA.Name
+	}
+	if smaller > 0 {
+		consistency.Overlap = float64(consistency.Common) * 100 / float64(smaller)
+	}
+	if consistency.Overlap < minOverlap {
+		problems = append(problems, dataProblem{Rule: ruleDatasetMismatch, Message: fmt.Sprintf(
+			"Only %.1f%% of the active users of \"%s\" are active in \"%s\" (%d out of %d, at least %g%% expected)",
+			consistency.Overlap, smallerName, otherName, consistency.Common, smaller, minOverlap)})
+	}
+	return consistency, problems
+}
+
+// Returns why the months of the datasets are not the same (empty if they are)
+func compareMonths(datasetA *Dataset, datasetB *Dataset) string {
+	if len(datasetA.Months) == 0 || len(datasetB.Months) == 0 {
+		return fmt.Sprintf("\"%s\" and \"%s\" must both have months", datasetA.Name, datasetB.Name)
+	}
+	firstA, lastA := datasetA.Months[0], datasetA.Months[len(datasetA.Months)-1]
+	firstB, lastB := datasetB.Months[0], datasetB.Months[len(datasetB.Months)-1]
+	if firstA != firstB || lastA != lastB {
+		return fmt.Sprintf("The months of \"%s\" (%s to %s) and of \"%s\" (%s to %s) are not the same",
+			datasetA.Name, firstA, lastA, datasetB.Name, firstB, lastB)
+	}
+
+	var onlyA, onlyB []string
+	for _, month := range datasetA.Months {
+		if datasetB.monthIndex(month) == -1 {
+			onlyA = append(onlyA, month)
+		}
+	}
+	for _, month := range datasetB.Months {
+		if datasetA.monthIndex(month) == -1 {
+			onlyB = append(onlyB, month)
+		}
+	}
+	switch {
+	case len(onlyA) > 0:
+		return fmt.Sprintf("\"%s\" has months absent from \"%s\": %s", datasetA.Name, datasetB.Name, strings.Join(onlyA, ", "))
+	case len(onlyB) > 0:
+		return fmt.Sprintf("\"%s\" has months absent from \"%s\": %s", datasetB.Name, datasetA.Name, strings.Join(onlyB, ", "))
+	}
+	return ""
+}
+
+// Returns the users (lower cased) with some activity in the dataset
+func activeUsers(dataset *Dataset) map[string]bool {
+	active := make(map[string]bool)
+	for i, total := range dataset.UserTotals() {
+		if total > 0 && dataset.Users[i] != "deleted_user" {
+			active[strings.ToLower(dataset.Users[i])] = true
+		}
+	}
+	return active
+}
+
+// Checks the consistency of a (valid) pivot table with the one given with "--against"
+func checkAgainst(ctx context.Context, fileName string, otherFileName string) []dataProblem {
+	if otherProblems := validatePivotTable(otherFileName); len(otherProblems) > 0 {
+		return []dataProblem{{Rule: ruleDatasetMismatch, Message: fmt.Sprintf(
+			"\"%s\" is not a valid pivot table (%d problem(s), the first one being: %s)", otherFileName, len(otherProblems), otherProblems[0].Message)}}
+	}
+	dataset, err := loadDataset(ctx, fileName)
+	if err != nil {
+		return []dataProblem{{Rule: ruleReadError, Message: err.Error()}}
+	}
+	other, err := loadDataset(ctx, otherFileName)
+	if err != nil {
+		return []dataProblem{{Rule: ruleReadError, Message: err.Error()}}
+	}
+
+	consistency, problems := checkConsistency(dataset, other, minUserOverlap)
+	if len(problems) == 0 {
+		logInfo("Consistent with \"%s\": same months (%s to %s), %.1f%% of the active users in common\n",
+			otherFileName, dataset.Months[0], dataset.Months[len(dataset.Months)-1], consistency.Overlap)
+	}
+	return problems
+}
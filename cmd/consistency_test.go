@@ -0,0 +1,119 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkConsistency(t *testing.T) {
+	submitters, _ := newDataset("submitters", [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "1", "0", "2"},
+		{"bravo", "0", "3", "0"},
+		{"charlie", "0", "0", "0"},
+		{"delta", "4", "0", "0"},
+	})
+	commenters, _ := newDataset("commenters", [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"Alpha", "5", "0", "0"},
+		{"charlie", "0", "1", "0"},
+	})
+
+	consistency, problems := checkConsistency(submitters, commenters, 10)
+
+	assert.Empty(t, problems)
+	// "charlie" is not active in the submitters, the case of "alpha" is ignored
+	assert.Equal(t, datasetConsistency{ActiveA: 3, ActiveB: 2, Common: 1, Overlap: 50}, consistency)
+
+	_, problems = checkConsistency(submitters, commenters, 75)
+	assert.Equal(t, []dataProblem{{Rule: ruleDatasetMismatch,
+		Message: "Only 50.0% of the active users of \"commenters\" are active in \"submitters\" (1 out of 2, at least 75% expected)"}}, problems)
+
+	shorter, _ := submitters.Slice("2023-01", "2023-02")
+	_, problems = checkConsistency(shorter, commenters, 10)
+	assert.Equal(t, []dataProblem{{Rule: ruleDatasetMismatch,
+		Message: "The months of \"submitters\" (2023-01 to 2023-02) and of \"commenters\" (2023-01 to 2023-03) are not the same"}}, problems)
+
+	withGap, _ := newDataset("gap", [][]string{{"", "2023-01", "2023-03"}, {"alpha", "1", "1"}})
+	_, problems = checkConsistency(withGap, commenters, 10)
+	assert.Equal(t, []dataProblem{{Rule: ruleDatasetMismatch,
+		Message: "\"commenters\" has months absent from \"gap\": 2023-02"}}, problems)
+}
+
+func Test_ExecuteCheck_against(t *testing.T) {
+	otherFile := filepath.Join(t.TempDir(), "commenters.csv")
+	content, err := os.ReadFile("../test_data/overview.csv")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(otherFile, content, 0644))
+	defer func() { againstFileName = "" }()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/overview.csv", "--against", otherFile})
+	assert.NoError(t, rootCmd.Execute())
+
+	rootCmd.SetArgs([]string{"check", "../test_data/overview.csv", "--against", "missing.csv"})
+	assert.Error(t, rootCmd.Execute())
+
+	rootCmd.SetArgs([]string{"check", "../test_data/overview.csv", "--against", otherFile, "--min-overlap", "120"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "The minimum overlap must be a percentage")
+	_ = checkCmd.PersistentFlags().Set("min-overlap", "10")
+}
+
+func Test_ExecuteCheck_againstFailure(t *testing.T) {
+	out := new(bytes.Buffer)
+	logOutput = out
+	defer func() {
+		logOutput = os.Stdout
+		againstFileName = ""
+		checkCmd.SilenceErrors = false
+		checkCmd.SilenceUsage = false
+	}()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/overview.csv", "--against", "../test_data/suspicious_data.csv"})
+	assert.ErrorIs(t, rootCmd.Execute(), errCheckFailed)
+
+	// An inconsistent file is not reported as valid
+	assert.NotContains(t, out.String(), "Successfully checked")
+}
+
+func Test_checkAgainst(t *testing.T) {
+	defer func() { minUserOverlap = 10 }()
+	minUserOverlap = 10
+
+	assert.Empty(t, checkAgainst(context.Background(), "../test_data/overview.csv", "../test_data/overview.csv"))
+
+	problems := checkAgainst(context.Background(), "../test_data/overview.csv", "../test_data/multiple_errors.csv")
+	assert.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Message, "\"../test_data/multiple_errors.csv\" is not a valid pivot table (4 problem(s)")
+
+	problems = checkAgainst(context.Background(), "../test_data/overview.csv", "../test_data/suspicious_data.csv")
+	assert.Len(t, problems, 2)
+	assert.Contains(t, problems[0].Message, "are not the same")
+	assert.Contains(t, problems[1].Message, "Only 0.0% of the active users of \"suspicious_data\"")
+}
@@ -0,0 +1,431 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xuri/excelize/v2"
+)
+
+// Supported data layouts
+const (
+	formatWide  = "wide"  // the datamash pivot table (one line per submitter, one column per month)
+	formatLong  = "long"  // CSV with one "user,month,count" line per value
+	formatJSONL = "jsonl" // one {"user","month","count"} JSON object per line
+	formatXLSX  = "xlsx"  // Excel workbook with the wide layout
+)
+
+// Formats that can be written (the readable ones are the registered parsers)
+var supportedFormats = []string{formatWide, formatLong, formatJSONL, formatXLSX}
+
+// Header of the long CSV format
+var longFormatHeader = []string{"user", "month", "count"}
+
+var convertFromFormat string
+var convertToFormat string
+
+// A line of the long (and JSONL) format
+type longRecord struct {
+	User  string `json:"user"`
+	Month string `json:"month"`
+	Count int    `json:"count"`
+}
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert [input file] [output file]",
+	Short: "Converts a pivot table between the wide, long, JSONL and XLSX formats",
+	Long: `The CONVERT command translates the data between the following formats (in both directions):
+  - "wide": the pivot table generated by datamash (one line per submitter, one column per month)
+  - "long": a CSV file with a "user,month,count" line for each value
+  - "jsonl": one {"user":..., "month":..., "count":...} JSON object per line
+  - "xlsx": an Excel workbook with the wide layout
+
+By default, the formats are deduced from the file extensions (".jsonl", ".xlsx" or ".csv").
+The CSV layout (wide or long) of the input file is detected from its header. The output CSV
+files are wide unless "--to long" is specified.
+
+The other commands (ex: CHECK or EXTRACT) also read the input files in any of these formats,
+detected in the same way.
+
+When converting to the wide format, the users are kept in the order of their first
+appearance and the missing months are added (with empty cells, as no data is known
+for them) so that the months are contiguous.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if _, err := getParser(convertFromFormat); convertFromFormat != "auto" && err != nil {
+			return fmt.Errorf("\"%s\" is an invalid format (should be one of %s)", convertFromFormat, strings.Join(parserFormats, ", "))
+		}
+		if convertToFormat != "auto" && !isSupportedFormat(convertToFormat) {
+			return fmt.Errorf("\"%s\" is an invalid format (should be one of %s)", convertToFormat, strings.Join(supportedFormats, ", "))
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputFormat := convertFromFormat
+		if inputFormat == "auto" {
+			detected, err := detectInputFormat(args[0])
+			if err != nil {
+				return err
+			}
+			inputFormat = detected
+		}
+		outputFormat := convertToFormat
+		if outputFormat == "auto" {
+			outputFormat = formatFromExtension(args[1])
+		}
+
+		pivot, err := readPivot(args[0], inputFormat)
+		if err != nil {
+			return err
+		}
+
+		if dirErr := CheckDir(args[1]); dirErr != nil {
+			return dirErr
+		}
+		if err := writePivot(args[1], outputFormat, pivot); err != nil {
+			return err
+		}
+		logInfo("Converted \"%s\" (%s) to \"%s\" (%s)\n", args[0], inputFormat, args[1], outputFormat)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringVarP(&convertFromFormat, "from", "", "auto", "Format of the input file (wide, long, jsonl, xlsx or auto)")
+	convertCmd.Flags().StringVarP(&convertToFormat, "to", "", "auto", "Format of the output file (wide, long, jsonl, xlsx or auto)")
+
+	convertCmd.ValidArgsFunction = completeInputFile
+	formats := append([]string{"auto"}, supportedFormats...)
+	_ = convertCmd.RegisterFlagCompletionFunc("from", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+	_ = convertCmd.RegisterFlagCompletionFunc("to", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Is the format one of the supported ones ?
+func isSupportedFormat(format string) bool {
+	for _, supported := range supportedFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the format corresponding to the file extension (CSV files are assumed wide)
+func formatFromExtension(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".jsonl", ".ndjson":
+		return formatJSONL
+	case ".xlsx":
+		return formatXLSX
+	default:
+		return formatWide
+	}
+}
+
+// Writes a pivot table to a file of the given format
+func writePivot(fileName string, format string, pivot [][]string) error {
+	switch format {
+	case formatWide:
+		return writeCSVtoFile(fileName, pivot)
+	case formatLong:
+		records, err := wideToLong(pivot)
+		if err != nil {
+			return err
+		}
+		data := [][]string{longFormatHeader}
+		for _, record := range records {
+			data = append(data, []string{record.User, record.Month, strconv.Itoa(record.Count)})
+		}
+		return writeCSVtoFile(fileName, data)
+	case formatJSONL:
+		records, err := wideToLong(pivot)
+		if err != nil {
+			return err
+		}
+		return writeJSONL(fileName, records)
+	case formatXLSX:
+		return writeXLSX(fileName, pivot)
+	default:
+		return fmt.Errorf("Unsupported output format \"%s\"", format)
+	}
+}
+
+// Converts the pivot table to a list of values (the months without data, empty in the
+// pivot table, are not listed)
+func wideToLong(pivot [][]string) ([]longRecord, error) {
+	if len(pivot) == 0 {
+		return nil, fmt.Errorf("No data to convert")
+	}
+	header := pivot[0]
+	var records []longRecord
+	for i, dataLine := range pivot[1:] {
+		if len(dataLine) != len(header) {
+			return nil, fmt.Errorf("Line %d has %d columns while the header has %d", i+2, len(dataLine), len(header))
+		}
+		for column := 1; column < len(dataLine); column++ {
+			// No data for the month
+			if dataLine[column] == "" {
+				continue
+			}
+			count, err := strconv.Atoi(dataLine[column])
+			if err != nil {
+				return nil, fmt.Errorf("Value \"%s\" at line %d (column %d) isn't an integer", dataLine[column], i+2, column+1)
+			}
+			records = append(records, longRecord{User: dataLine[0], Month: header[column], Count: count})
+		}
+	}
+	return records, nil
+}
+
+// Builds a pivot table from a list of values. The users are in the order of their first
+// appearance and all the months between the oldest and the most recent one are present.
+// Values given several times for the same user and month are added.
+func longToWide(records []longRecord) ([][]string, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("No data to convert")
+	}
+
+	counts := make(map[string]map[string]int)
+	var users []string
+	oldest, mostRecent := records[0].Month, records[0].Month
+	for _, record := range records {
+		if !isValidMonth(record.Month, false) || strings.EqualFold(record.Month, "latest") {
+			return nil, fmt.Errorf("\"%s\" is an invalid month (user %s)", record.Month, record.User)
+		}
+		if counts[record.User] == nil {
+			counts[record.User] = make(map[string]int)
+			users = append(users, record.User)
+		}
+		counts[record.User][record.Month] += record.Count
+		if record.Month < oldest {
+			oldest = record.Month
+		}
+		if record.Month > mostRecent {
+			mostRecent = record.Month
+		}
+	}
+
+	months, err := monthRange(oldest, mostRecent)
+	if err != nil {
+		return nil, err
+	}
+
+	pivot := [][]string{append([]string{""}, months...)}
+	for _, user := range users {
+		dataLine := make([]string, len(months)+1)
+		dataLine[0] = user
+		for i, month := range months {
+			// The months without a record have no data (an empty cell), which is not a zero
+			if count, ok := counts[user][month]; ok {
+				dataLine[i+1] = strconv.Itoa(count)
+			}
+		}
+		pivot = append(pivot, dataLine)
+	}
+	return pivot, nil
+}
+
+// Returns all the months ("YYYY-MM") between the two given months (included)
+func monthRange(first string, last string) ([]string, error) {
+	start, err := time.Parse("2006-01", first)
+	if err != nil {
+		return nil, fmt.Errorf("\"%s\" is an invalid month", first)
+	}
+	end, err := time.Parse("2006-01", last)
+	if err != nil {
+		return nil, fmt.Errorf("\"%s\" is an invalid month", last)
+	}
+	var months []string
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format("2006-01"))
+	}
+	return months, nil
+}
+
+// Reads a long CSV file ("user,month,count")
+func readLongCSV(fileName string) ([]longRecord, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(longFormatHeader)
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read %s: %v", fileName, err)
+	}
+
+	var records []longRecord
+	for i, line := range lines {
+		// skip the header
+		if i == 0 {
+			continue
+		}
+		count, err := strconv.Atoi(line[2])
+		if err != nil {
+			return nil, fmt.Errorf("Value \"%s\" at line %d isn't an integer", line[2], i+1)
+		}
+		records = append(records, longRecord{User: line[0], Month: line[1], Count: count})
+	}
+	return records, nil
+}
+
+// Reads a JSONL file (one JSON object per line)
+func readJSONL(fileName string) ([]longRecord, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []longRecord
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record longRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, fmt.Errorf("Invalid JSON at line %d of %s: %v", lineNumber, fileName, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Writes the values as a JSONL file
+func writeJSONL(fileName string, records []longRecord) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buffer := bufio.NewWriter(f)
+	encoder := json.NewEncoder(buffer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return buffer.Flush()
+}
+
+// Reads the first sheet of an Excel workbook as a pivot table
+func readXLSX(fileName string) ([][]string, error) {
+	f, err := excelize.OpenFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(f.GetSheetName(0))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("No data in %s", fileName)
+	}
+
+	// Trailing empty cells are not returned by excelize
+	width := len(rows[0])
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// Writes the pivot table in an Excel workbook (the counts are stored as numbers)
+func writeXLSX(fileName string, pivot [][]string) error {
+	return writeXLSXSheet(fileName, "pivot", pivot)
+}
+
+// Writes a table in the named sheet of an Excel workbook. The numbers, except in the header
+// and the first column, are stored as numbers.
+func writeXLSXSheet(fileName string, sheetName string, pivot [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), sheetName); err != nil {
+		return err
+	}
+	stream, err := f.NewStreamWriter(sheetName)
+	if err != nil {
+		return err
+	}
+
+	for i, dataLine := range pivot {
+		values := make([]interface{}, len(dataLine))
+		for column, value := range dataLine {
+			values[column] = value
+			if i > 0 && column > 0 {
+				number, isNumeric := numericText(value)
+				if count, err := strconv.Atoi(number); err == nil && isNumeric {
+					values[column] = count
+				} else if decimal, err := strconv.ParseFloat(number, 64); err == nil && isNumeric {
+					values[column] = decimal
+				}
+			}
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := stream.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	if err := stream.Flush(); err != nil {
+		return err
+	}
+	return f.SaveAs(fileName)
+}
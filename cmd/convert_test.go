@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_convertRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	original, err := loadInputPivotTable("../test_data/deleted_user_case.csv")
+	assert.NoError(t, err)
+
+	for _, format := range []string{formatWide, formatLong, formatJSONL, formatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			fileName := filepath.Join(tempDir, "converted."+format)
+
+			err := writePivot(fileName, format, original)
+			assert.NoError(t, err)
+
+			converted, err := readPivot(fileName, format)
+			assert.NoError(t, err)
+			assert.Equal(t, original, converted)
+		})
+	}
+}
+
+func Test_wideToLong(t *testing.T) {
+	records, err := wideToLong([][]string{{"", "2023-01", "2023-02"}, {"alpha", "", "0"}})
+
+	assert.NoError(t, err)
+	// The months without data are not listed, the months without activity are
+	assert.Equal(t, []longRecord{{User: "alpha", Month: "2023-02", Count: 0}}, records)
+
+	// Back to the wide format, the months without data are empty again
+	records, err = wideToLong([][]string{{"", "2023-01", "2023-02", "2023-03"}, {"alice", "1", "", "3"}})
+	assert.NoError(t, err)
+	pivot, err := longToWide(records)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "2023-01", "2023-02", "2023-03"}, {"alice", "1", "", "3"}}, pivot)
+}
+
+func Test_longToWide(t *testing.T) {
+	records := []longRecord{
+		{User: "bravo", Month: "2023-03", Count: 2},
+		{User: "alpha", Month: "2022-12", Count: 1},
+		{User: "bravo", Month: "2023-03", Count: 3},
+	}
+
+	pivot, err := longToWide(records)
+
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"", "2022-12", "2023-01", "2023-02", "2023-03"},
+		{"bravo", "", "", "", "5"},
+		{"alpha", "1", "", "", ""},
+	}
+	assert.Equal(t, expected, pivot)
+
+	_, err = longToWide([]longRecord{{User: "alpha", Month: "junk", Count: 1}})
+	assert.Error(t, err)
+	_, err = longToWide(nil)
+	assert.Error(t, err)
+}
+
+func Test_detectInputFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	longFile := filepath.Join(tempDir, "long.csv")
+	writeCSVtoFile(longFile, [][]string{longFormatHeader, {"alpha", "2023-01", "1"}})
+
+	tests := []struct {
+		fileName string
+		want     string
+	}{
+		{"../test_data/overview.csv", formatWide},
+		{longFile, formatLong},
+		{"data.jsonl", formatJSONL},
+		{"data.XLSX", formatXLSX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			got, err := detectInputFormat(tt.fileName)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ExecuteConvert(t *testing.T) {
+	tempDir := t.TempDir()
+	longFile := filepath.Join(tempDir, "long.csv")
+	wideFile := filepath.Join(tempDir, "wide.csv")
+	defer func() { _ = convertCmd.Flags().Set("to", "auto") }()
+
+	rootCmd.SetArgs([]string{"convert", "../test_data/deleted_user_case.csv", longFile, "--to", "long"})
+	assert.NoError(t, rootCmd.Execute())
+	_ = convertCmd.Flags().Set("to", "auto")
+
+	rootCmd.SetArgs([]string{"convert", longFile, wideFile})
+	assert.NoError(t, rootCmd.Execute())
+
+	original, _ := loadInputPivotTable("../test_data/deleted_user_case.csv")
+	converted, err := loadInputPivotTable(wideFile)
+	assert.NoError(t, err)
+	assert.Equal(t, original, converted)
+}
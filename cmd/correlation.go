@@ -0,0 +1,223 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var correlationOutputFileName string
+var correlationHeatmapFileName string
+var correlationTopSize int
+
+// Pairwise correlations of the monthly activity of the users (NaN when it can't be computed)
+type correlationMatrix struct {
+	Users  []string
+	Values [][]float64
+}
+
+// correlationCmd represents the correlation command
+var correlationCmd = &cobra.Command{
+	Use:   "correlation [input file]",
+	Short: "Computes the correlation of the monthly activity of the top contributors",
+	Long: `The CORRELATION command computes the pairwise correlation (Pearson) of the monthly
+activity of the top contributors of the period (see "--top", "--month" and "--period").
+A value close to 1 means that the two contributors are active during the same months,
+close to -1 that one is active when the other is not.
+
+The matrix is written as CSV (or in the format selected by the extension of the output
+file or with "--format"). The correlation of a contributor without any variation
+during the period can't be computed: the cell is left empty.
+
+With "--heatmap", the matrix is also rendered as an SVG heatmap (red for the positive
+correlations, blue for the negative ones).`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		matrix, err := computeCorrelationMatrix(dataset, correlationTopSize, endMonth, period)
+		if err != nil {
+			return err
+		}
+
+		if dirErr := CheckDir(correlationOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		options := renderOptions{Title: "Correlation matrix", Introduction: "# Correlation matrix\n\n", InputType: InputTypeSubmitters}
+		if err := writeResultTable(correlationOutputFileName, matrix.asTable(), options); err != nil {
+			return err
+		}
+		logInfo("Correlation matrix of %d contributors written to \"%s\"\n", len(matrix.Users), correlationOutputFileName)
+
+		if correlationHeatmapFileName != "" {
+			if dirErr := CheckDir(correlationHeatmapFileName); dirErr != nil {
+				return dirErr
+			}
+			if err := os.WriteFile(correlationHeatmapFileName, []byte(matrix.renderHeatmapSVG()), 0644); err != nil {
+				return err
+			}
+			logInfo("Heatmap written to \"%s\"\n", correlationHeatmapFileName)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(correlationCmd)
+
+	correlationCmd.Flags().StringVarP(&correlationOutputFileName, "out", "o", "correlation.csv", "Output file name of the matrix. The extension selects the format (see \"--format\")")
+	correlationCmd.Flags().StringVarP(&correlationHeatmapFileName, "heatmap", "", "", "Also renders the matrix as an SVG heatmap in this file")
+	correlationCmd.Flags().IntVarP(&correlationTopSize, "top", "t", 20, "Number of top contributors of the period to correlate")
+	correlationCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months of the period (0 for all).")
+	correlationCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Last month of the period.")
+	addFormatFlag(correlationCmd)
+
+	correlationCmd.ValidArgsFunction = completeInputFile
+	_ = correlationCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Computes the correlation matrix of the top users of the period. The ex-aequo of
+// the last rank are not included, so that the matrix has the requested size.
+func computeCorrelationMatrix(dataset *Dataset, topSize int, endMonth string, period int) (correlationMatrix, error) {
+	periodDataset, err := dataset.Period(endMonth, period)
+	if err != nil {
+		return correlationMatrix{}, err
+	}
+
+	var series [][]float64
+	var matrix correlationMatrix
+	for _, entry := range periodDataset.Leaderboard() {
+		if entry.Total == 0 || len(matrix.Users) >= topSize {
+			break
+		}
+		var values []float64
+		for _, count := range periodDataset.Counts[periodDataset.userIndex(entry.User)] {
+			values = append(values, float64(count))
+		}
+		matrix.Users = append(matrix.Users, entry.User)
+		series = append(series, values)
+	}
+
+	for i := range series {
+		row := make([]float64, len(series))
+		for j := range series {
+			correlation, isCorrelated := pearsonCorrelation(series[i], series[j])
+			switch {
+			case !isCorrelated:
+				correlation = math.NaN()
+			case i == j:
+				// Exactly 1, without the rounding errors of the computation (ex: 0.9999999999999999)
+				correlation = 1
+			}
+			row[j] = correlation
+		}
+		matrix.Values = append(matrix.Values, row)
+	}
+	return matrix, nil
+}
+
+// Returns the matrix as a table, the users being the header line and the first column
+func (matrix correlationMatrix) asTable() [][]string {
+	table := [][]string{append([]string{""}, matrix.Users...)}
+	for i, user := range matrix.Users {
+		line := []string{user}
+		for _, value := range matrix.Values[i] {
+			if math.IsNaN(value) {
+				line = append(line, "")
+			} else {
+				line = append(line, formatMetric(value))
+			}
+		}
+		table = append(table, line)
+	}
+	return table
+}
+
+// Formats a correlation with two decimals (empty if it can't be computed)
+func formatCorrelation(value float64) string {
+	if math.IsNaN(value) {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+// Renders the matrix as an SVG heatmap
+func (matrix correlationMatrix) renderHeatmapSVG() string {
+	const cellSize, labelWidth = 24, 140
+	size := labelWidth + cellSize*len(matrix.Users)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", size, size)
+	for i, user := range matrix.Users {
+		position := labelWidth + i*cellSize
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", labelWidth-4, position+cellSize*2/3, html.EscapeString(user))
+		fmt.Fprintf(&b, `<text transform="translate(%d,%d) rotate(-90)">%s</text>`+"\n", position+cellSize*2/3, labelWidth-4, html.EscapeString(user))
+	}
+	for i, row := range matrix.Values {
+		for j, value := range row {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s / %s: %s</title></rect>`+"\n",
+				labelWidth+j*cellSize, labelWidth+i*cellSize, cellSize, cellSize, correlationColor(value),
+				html.EscapeString(matrix.Users[i]), html.EscapeString(matrix.Users[j]), formatCorrelation(value))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// Returns the color of a correlation: from blue (-1) to white (0) and red (1), gray if not computed
+func correlationColor(value float64) string {
+	if math.IsNaN(value) {
+		return "#eeeeee"
+	}
+	fade := int(math.Round(255 * (1 - math.Abs(value))))
+	if value >= 0 {
+		return fmt.Sprintf("#ff%02x%02x", fade, fade)
+	}
+	return fmt.Sprintf("#%02x%02xff", fade, fade)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeCorrelationMatrix(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "9", "1", "2", "3"},
+		{"bravo", "2", "4", "6", "8"},
+		{"charlie", "4", "3", "2", "1"},
+		{"delta", "1", "1", "1", "1"},
+		{"echo", "0", "0", "0", "1"},
+	}
+
+	dataset, err := newDataset("test", records)
+	assert.NoError(t, err)
+
+	matrix, err := computeCorrelationMatrix(dataset, 4, "latest", 3)
+
+	assert.NoError(t, err)
+	// Over the 3 last months, "alpha" and "bravo" grow together, "charlie" decreases and "delta" is constant
+	assert.Equal(t, []string{"bravo", "alpha", "charlie", "delta"}, matrix.Users)
+	assert.InDelta(t, 1, matrix.Values[0][1], 1e-9)
+	assert.InDelta(t, -1, matrix.Values[0][2], 1e-9)
+	assert.True(t, math.IsNaN(matrix.Values[3][0]))
+	// A contributor whose activity varies is exactly correlated with itself
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1.0, matrix.Values[i][i])
+	}
+	assert.True(t, math.IsNaN(matrix.Values[3][3]))
+
+	assert.Equal(t, []string{"", "bravo", "alpha", "charlie", "delta"}, matrix.asTable()[0])
+	assert.Equal(t, []string{"delta", "", "", "", ""}, matrix.asTable()[4])
+
+	rounded, _ := newDataset("rounded", [][]string{{"", "2023-01", "2023-02", "2023-03"}, {"alpha", "0", "0", "3"}})
+	roundedMatrix, err := computeCorrelationMatrix(rounded, 1, "latest", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, roundedMatrix.Values[0][0], "computed as 0.9999999999999998")
+	assert.Equal(t, []string{"alpha", "1"}, roundedMatrix.asTable()[1])
+}
+
+func Test_correlationColor(t *testing.T) {
+	assert.Equal(t, "#ff0000", correlationColor(1))
+	assert.Equal(t, "#ffffff", correlationColor(0))
+	assert.Equal(t, "#8080ff", correlationColor(-0.5))
+	assert.Equal(t, "#eeeeee", correlationColor(math.NaN()))
+}
+
+func Test_ExecuteCorrelation(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "correlation.csv")
+	heatmapFile := filepath.Join(tempDir, "correlation.svg")
+	defer func() { metricPrecision = -1 }()
+
+	rootCmd.SetArgs([]string{"correlation", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "5", "-o", outputFile, "--heatmap", heatmapFile, "--precision", "2"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, ",basil,lemeurherve,NotMyFault,MarkEWaite,dduportal", lines[0])
+	assert.Equal(t, "basil,1.00,-0.14,0.66,0.51,0.59", lines[1])
+	heatmap, err := os.ReadFile(heatmapFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 25, strings.Count(string(heatmap), "<rect "))
+}
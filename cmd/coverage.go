@@ -0,0 +1,213 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var coverageOutputFileName string
+var coverageShare float64
+var coverageMaxBusFactor int
+var coverageMinPRs int
+
+// Extracts the repository ("org/repo") of a pull request URL
+var pullRequestURLRegexp = regexp.MustCompile(`github\.com/([^/]+/[^/]+)/pull/`)
+
+// The submitters of a repository
+type repositoryCoverage struct {
+	Repository    string
+	PRs           int
+	Submitters    int
+	BusFactor     int // number of submitters needed to exceed the share of the PRs
+	MainSubmitter string
+	MainShare     float64 // in percent
+}
+
+// coverageCmd represents the coverage command
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [export files...]",
+	Short: "Reports the repositories depending on a single submitter",
+	Long: `The COVERAGE command reports, from the PR export files (before they are pivoted),
+the repositories whose PR volume depends on very few submitters, to identify the
+at-risk plugins.
+
+The repository is read from the "repository" (or "repo") column of the files or else
+deduced from the PR "url" column. The submitter is read from the "user" column.
+
+The bus factor of a repository is the smallest number of submitters authoring more than
+half of its PRs (see "--share"). The repositories with a bus factor of 1 (see
+"--max-bus-factor") and at least 5 PRs (see "--min-prs") are listed, the largest
+volume first.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		for _, fileName := range args {
+			if !isFileValid(fileName) {
+				return fmt.Errorf("Invalid input file \"%s\"\n", fileName)
+			}
+		}
+		if coverageShare <= 0 || coverageShare >= 100 {
+			return fmt.Errorf("\"--share\" must be between 0 and 100 (excluded)\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		header, rows, err := loadExportFiles(args)
+		if err != nil {
+			return err
+		}
+		prsPerRepository, err := countRepositoryPRs(header, rows)
+		if err != nil {
+			return err
+		}
+		atRisk := computeRepositoryCoverage(prsPerRepository, coverageShare, coverageMaxBusFactor, coverageMinPRs)
+
+		if dirErr := CheckDir(coverageOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		introduction := fmt.Sprintf("# Repository coverage\n\nRepositories with a bus factor of %d or less (at least %d PRs).\n\n", coverageMaxBusFactor, coverageMinPRs)
+		options := renderOptions{Title: "Repository coverage", Introduction: introduction, InputType: InputTypeSubmitters}
+		if err := writeResultTable(coverageOutputFileName, coverageAsTable(atRisk), options); err != nil {
+			return err
+		}
+		logInfo("%d repositories out of %d at risk written to \"%s\"\n", len(atRisk), len(prsPerRepository), coverageOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+
+	coverageCmd.Flags().StringVarP(&coverageOutputFileName, "out", "o", "repository-coverage.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	coverageCmd.Flags().Float64VarP(&coverageShare, "share", "", 50, "Share of the PRs (in percent) the bus factor is computed on")
+	coverageCmd.Flags().IntVarP(&coverageMaxBusFactor, "max-bus-factor", "", 1, "Highest bus factor of the reported repositories")
+	coverageCmd.Flags().IntVarP(&coverageMinPRs, "min-prs", "", 5, "Minimum number of PRs of the reported repositories")
+	addFormatFlag(coverageCmd)
+
+	coverageCmd.ValidArgsFunction = completeInputFile
+}
+
+// Counts the PRs of each submitter, per repository
+func countRepositoryPRs(header []string, rows []exportRow) (map[string]map[string]int, error) {
+	userColumns, err := getKeyColumns(header, []string{"user"})
+	if err != nil {
+		return nil, fmt.Errorf("The export files have no \"user\" column")
+	}
+	repositoryColumn, isFromURL := -1, false
+	for _, name := range []string{"repository", "repo", "url"} {
+		if columns, err := getKeyColumns(header, []string{name}); err == nil {
+			repositoryColumn, isFromURL = columns[0], name == "url"
+			break
+		}
+	}
+	if repositoryColumn < 0 {
+		return nil, fmt.Errorf("The export files have no \"repository\", \"repo\" or \"url\" column")
+	}
+
+	prsPerRepository := make(map[string]map[string]int)
+	for _, row := range rows {
+		if repositoryColumn >= len(row.Values) || userColumns[0] >= len(row.Values) {
+			warn("%s:%d is incomplete and was ignored", row.File, row.Line)
+			continue
+		}
+		repository := strings.TrimSpace(row.Values[repositoryColumn])
+		if isFromURL {
+			match := pullRequestURLRegexp.FindStringSubmatch(repository)
+			if match == nil {
+				warn("No repository found in the URL \"%s\" (%s:%d)", repository, row.File, row.Line)
+				continue
+			}
+			repository = match[1]
+		}
+		repository = strings.ToLower(repository)
+		if prsPerRepository[repository] == nil {
+			prsPerRepository[repository] = make(map[string]int)
+		}
+		prsPerRepository[repository][strings.TrimSpace(row.Values[userColumns[0]])]++
+	}
+	return prsPerRepository, nil
+}
+
+// Returns the repositories with at most maxBusFactor submitters authoring more than share
+// percent of their PRs, the largest volume first
+func computeRepositoryCoverage(prsPerRepository map[string]map[string]int, share float64, maxBusFactor int, minPRs int) []repositoryCoverage {
+	var atRisk []repositoryCoverage
+	for repository, prsPerUser := range prsPerRepository {
+		var leaderboard []leaderboardEntry
+		total := 0
+		for user, count := range prsPerUser {
+			leaderboard = append(leaderboard, leaderboardEntry{User: user, Total: count})
+			total += count
+		}
+		if total < minPRs {
+			continue
+		}
+		leaderboard = rankLeaderboard(leaderboard)
+
+		busFactor, covered := 0, 0
+		for _, entry := range leaderboard {
+			busFactor++
+			covered += entry.Total
+			if float64(covered)*100 > share*float64(total) {
+				break
+			}
+		}
+		if busFactor > maxBusFactor {
+			continue
+		}
+		atRisk = append(atRisk, repositoryCoverage{
+			Repository:    repository,
+			PRs:           total,
+			Submitters:    len(leaderboard),
+			BusFactor:     busFactor,
+			MainSubmitter: leaderboard[0].User,
+			MainShare:     100 * float64(leaderboard[0].Total) / float64(total),
+		})
+	}
+
+	sort.Slice(atRisk, func(i, j int) bool {
+		if atRisk[i].PRs != atRisk[j].PRs {
+			return atRisk[i].PRs > atRisk[j].PRs
+		}
+		return atRisk[i].Repository < atRisk[j].Repository
+	})
+	return atRisk
+}
+
+// Returns the repositories as a table (with a header line)
+func coverageAsTable(repositories []repositoryCoverage) [][]string {
+	table := [][]string{{"Repository", "PRs", "Submitters", "Bus_Factor", "Main_Submitter", "Main_Share"}}
+	for _, repository := range repositories {
+		table = append(table, []string{repository.Repository, strconv.Itoa(repository.PRs), strconv.Itoa(repository.Submitters),
+			strconv.Itoa(repository.BusFactor), repository.MainSubmitter, formatMetric(repository.MainShare)})
+	}
+	return table
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_countRepositoryPRs(t *testing.T) {
+	header, rows, err := loadExportFiles([]string{"../test_data/export_repositories.csv"})
+	assert.NoError(t, err)
+
+	prsPerRepository, err := countRepositoryPRs(header, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"alpha": 4, "bravo": 1}, prsPerRepository["jenkinsci/git-plugin"])
+	assert.Len(t, prsPerRepository, 3)
+
+	// An explicit repository column is preferred to the URL
+	prsPerRepository, err = countRepositoryPRs([]string{"User", "Repository"}, []exportRow{{Values: []string{"alpha", "jenkinsci/Foo"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"alpha": 1}, prsPerRepository["jenkinsci/foo"])
+
+	_, err = countRepositoryPRs([]string{"user", "month"}, nil)
+	assert.ErrorContains(t, err, "no \"repository\", \"repo\" or \"url\" column")
+}
+
+func Test_computeRepositoryCoverage(t *testing.T) {
+	prsPerRepository := map[string]map[string]int{
+		"jenkinsci/git-plugin":   {"alpha": 4, "bravo": 1},
+		"jenkins-infra/helpdesk": {"bravo": 2, "charlie": 1, "delta": 1, "echo": 1},
+		"jenkinsci/half-plugin":  {"alpha": 3, "bravo": 3},
+		"jenkinsci/tiny-plugin":  {"charlie": 2},
+	}
+
+	atRisk := computeRepositoryCoverage(prsPerRepository, 50, 1, 5)
+
+	// "helpdesk" needs 2 submitters for more than half of its PRs, "half-plugin" too (exactly half is not more)
+	assert.Equal(t, []repositoryCoverage{
+		{Repository: "jenkinsci/git-plugin", PRs: 5, Submitters: 2, BusFactor: 1, MainSubmitter: "alpha", MainShare: 80},
+	}, atRisk)
+
+	atRisk = computeRepositoryCoverage(prsPerRepository, 50, 2, 1)
+	assert.Len(t, atRisk, 4)
+	assert.Equal(t, "jenkinsci/half-plugin", atRisk[0].Repository)
+	assert.Equal(t, "jenkinsci/tiny-plugin", atRisk[3].Repository)
+}
+
+func Test_ExecuteCoverage(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "coverage.csv")
+
+	rootCmd.SetArgs([]string{"coverage", "../test_data/export_repositories.csv", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "Repository,PRs,Submitters,Bus_Factor,Main_Submitter,Main_Share\njenkinsci/git-plugin,5,2,1,alpha,80\n", string(content))
+}
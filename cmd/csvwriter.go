@@ -0,0 +1,190 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var isCanonicalCSV bool
+var isCRLFOutput bool
+var outputSchema string
+
+// Versions of the header of the CSV result tables
+const (
+	outputSchemaV1 = "v1" // the historical headers (ex: "Submitter,Total_PRs")
+	outputSchemaV2 = "v2" // a "# schema: v2" line, then stable lower case headers (ex: "user,total")
+)
+
+// The v2 names of the columns whose name depends on the type of data. The other ones are lower cased.
+var outputSchemaV2Columns = map[string]string{
+	"submitter":      "user",
+	"commenter":      "user",
+	"total_prs":      "total",
+	"total_comments": "total",
+	"comments":       "total",
+}
+
+var nonIdentifierRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+var pivotMonthRegexp = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}$`)
+
+// Writes the data as CSV (in the canonical form if "--canonical-csv" is set)
+func writeCSV(out io.Writer, data [][]string) error {
+	if isCanonicalCSV {
+		return writeCanonicalCSV(out, data)
+	}
+	csvOut := csv.NewWriter(out)
+	csvOut.UseCRLF = isCRLFOutput
+	if err := csvOut.WriteAll(data); err != nil {
+		return err
+	}
+	csvOut.Flush()
+	return csvOut.Error()
+}
+
+// Checks the "--output-schema" flag
+func checkOutputSchema() error {
+	if outputSchema != outputSchemaV1 && outputSchema != outputSchemaV2 {
+		return fmt.Errorf("Unsupported output schema \"%s\" (should be %s or %s)", outputSchema, outputSchemaV1, outputSchemaV2)
+	}
+	return nil
+}
+
+// Writes a result table as CSV, with the header of the requested output schema. In v2, the first line
+// declares the version so that the downstream parsers can detect a change instead of silently breaking.
+func writeResultCSV(fileName string, table [][]string) error {
+	out, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if outputSchema == outputSchemaV2 && len(table) > 0 {
+		lineEnding := "\n"
+		if isCRLFOutput {
+			lineEnding = "\r\n"
+		}
+		if _, err := io.WriteString(out, "# schema: "+outputSchemaV2+lineEnding); err != nil {
+			return err
+		}
+		table = append([][]string{versionedHeader(table[0])}, table[1:]...)
+	}
+	if err := writeCSV(out, table); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// Returns the v2 header: lower case names, with "_" between the words, identical for the submitters and commenters
+func versionedHeader(header []string) []string {
+	result := make([]string, len(header))
+	for i, name := range header {
+		name = strings.Trim(nonIdentifierRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_")
+		if v2Name, isRenamed := outputSchemaV2Columns[name]; isRenamed {
+			name = v2Name
+		}
+		result[i] = name
+	}
+	return result
+}
+
+// Writes the data as CSV in a stable form, so that the files committed month after month
+// have minimal diffs: the lines of the pivot tables are sorted by username, the text values
+// are always quoted and the numbers never, the lines end with LF (CRLF with "--crlf"), including the last one.
+func writeCanonicalCSV(out io.Writer, data [][]string) error {
+	writer := bufio.NewWriter(out)
+	lineEnding := "\n"
+	if isCRLFOutput {
+		lineEnding = "\r\n"
+	}
+	for _, line := range canonicalLines(data) {
+		for i, value := range line {
+			if i > 0 {
+				writer.WriteString(",")
+			}
+			writer.WriteString(canonicalValue(value))
+		}
+		writer.WriteString(lineEnding)
+	}
+	return writer.Flush()
+}
+
+// Returns the lines in their canonical order, without the trailing empty lines.
+// Only the lines of a pivot table are sorted: the order of the other tables (ex: a ranking) is meaningful.
+func canonicalLines(data [][]string) [][]string {
+	end := len(data)
+	for end > 0 && isEmptyLine(data[end-1]) {
+		end--
+	}
+	lines := append([][]string{}, data[:end]...)
+	if len(lines) < 2 || !isPivotHeader(lines[0]) {
+		return lines
+	}
+	body := lines[1:]
+	sort.SliceStable(body, func(i, j int) bool {
+		a, b := strings.ToLower(body[i][0]), strings.ToLower(body[j][0])
+		if a != b {
+			return a < b
+		}
+		return body[i][0] < body[j][0]
+	})
+	return lines
+}
+
+// Returns true if the header is the one of a pivot table (the first column is the user, the others are months)
+func isPivotHeader(header []string) bool {
+	if len(header) < 2 {
+		return false
+	}
+	for _, month := range header[1:] {
+		if !pivotMonthRegexp.MatchString(month) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the line has no value
+func isEmptyLine(line []string) bool {
+	for _, value := range line {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Quotes the text values (as in the pivot tables generated by the Jenkins Submitters Stats scripts)
+func canonicalValue(value string) string {
+	if value == "" || isNumber(value) {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeCanonicalCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     [][]string
+		expected string
+	}{
+		{
+			name: "pivot table sorted by username",
+			data: [][]string{
+				{"", "2023-01", "2023-02"},
+				{"charlie", "1", "0"},
+				{"Bravo", "0", "2"},
+				{"alpha", "3", "1"},
+				{"", "", ""},
+			},
+			expected: ",\"2023-01\",\"2023-02\"\n\"alpha\",3,1\n\"Bravo\",0,2\n\"charlie\",1,0\n",
+		},
+		{
+			name: "ranking kept in order",
+			data: [][]string{
+				{"Submitter", "Total_PRs"},
+				{"zulu", "12"},
+				{"alpha", "3"},
+			},
+			expected: "\"Submitter\",\"Total_PRs\"\n\"zulu\",12\n\"alpha\",3\n",
+		},
+		{
+			name:     "quotes escaped",
+			data:     [][]string{{`say "hi"`, "1.5"}},
+			expected: "\"say \"\"hi\"\"\",1.5\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			assert.NoError(t, writeCanonicalCSV(out, tt.data))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func Test_writeCSV_crlf(t *testing.T) {
+	defer func() {
+		isCRLFOutput = false
+		isCanonicalCSV = false
+	}()
+	data := [][]string{{"", "2023-01"}, {"alpha", "1"}}
+
+	isCRLFOutput = true
+	out := new(bytes.Buffer)
+	assert.NoError(t, writeCSV(out, data))
+	assert.Equal(t, ",2023-01\r\nalpha,1\r\n", out.String())
+
+	isCanonicalCSV = true
+	out = new(bytes.Buffer)
+	assert.NoError(t, writeCSV(out, data))
+	assert.Equal(t, ",\"2023-01\"\r\n\"alpha\",1\r\n", out.String())
+}
+
+func Test_ExecuteExtract_crlfInput(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "windows.csv")
+	outputFile := filepath.Join(tempDir, "top.csv")
+	content, err := os.ReadFile("../test_data/deleted_user_case.csv")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(inputFile, bytes.ReplaceAll(content, []byte("\n"), []byte("\r\n")), 0644))
+
+	rootCmd.SetArgs([]string{"extract", inputFile, "-m", "latest", "-o", outputFile})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	records, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "ADITYADAS1999", records[1][0])
+}
+
+func Test_ExecuteTrim_canonical(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "trimmed.csv")
+	defer func() {
+		_ = trimCmd.Flags().Set("from", "")
+		_ = trimCmd.Flags().Set("out", "")
+		_ = rootCmd.PersistentFlags().Set("canonical-csv", "false")
+	}()
+
+	rootCmd.SetArgs([]string{"trim", "../test_data/deleted_user_case.csv", "--from", "2023-01", "-o", outputFile, "--canonical-csv"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\n\"0x41head\",")
+	assert.NotContains(t, string(content), "\r\n")
+
+	// The canonical file is still a valid pivot table
+	records, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-01", records[0][1])
+}
+
+func Test_versionedHeader(t *testing.T) {
+	assert.Equal(t, []string{"user", "total", "status", "trend"}, versionedHeader([]string{"Submitter", "Total_PRs", "Status", "Trend"}))
+	assert.Equal(t, []string{"user", "total", "status"}, versionedHeader([]string{"Commenter", "Comments", "status"}))
+	assert.Equal(t, []string{"", "first_month"}, versionedHeader([]string{"", "First month"}))
+}
+
+func Test_ExecuteExtract_outputSchema(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+	defer func() { _ = rootCmd.PersistentFlags().Set("output-schema", "v1") }()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "2", "-o", outputFile, "--output-schema", "v2"})
+	assert.NoError(t, rootCmd.Execute())
+
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^# schema: v2\nuser,total\n[^\n]+\n[^\n]+\n$`, string(content))
+	records, err := readCSVFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user", "total"}, records[0])
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile, "--output-schema", "v3"})
+	assert.Error(t, rootCmd.Execute())
+}
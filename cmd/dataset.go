@@ -0,0 +1,358 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A pivot table loaded in memory: the monthly counts of each user. The commands
+// should use it rather than the raw records, which need to be converted and
+// bounds checked at each access.
+// Only CHECK (which validates the raw cells), PERF (which measures the loaders) and the
+// EXTRACT, COMPARE and REPORT pipeline (which streams the needed columns of very large
+// tables, see loadProjectedPivotTable, and writes the history files) keep working on the
+// records.
+// An empty cell means that there is no data for the month (ex: not collected yet), which
+// is not the same as no activity: its count is 0, but it is flagged as missing.
+type Dataset struct {
+	Name    string   // name of the source (ex: the base name of the file)
+	Months  []string // "YYYY-MM", in the order of the source
+	Users   []string // in the order of the source
+	Counts  [][]int  // Counts[user][month]
+	Missing [][]bool // Missing[user][month], nil if there is data for all the months
+}
+
+// Creates a dataset from pivot table records (header line first)
+func newDataset(name string, records [][]string) (*Dataset, error) {
+	if len(records) == 0 || len(records[0]) < 2 {
+		return nil, fmt.Errorf("No month in the pivot table of \"%s\"", name)
+	}
+	dataset := &Dataset{Name: name, Months: append([]string(nil), records[0][1:]...)}
+	for i, dataLine := range records[1:] {
+		if len(dataLine) != len(records[0]) {
+			return nil, fmt.Errorf("Line %d of \"%s\" has %d columns instead of %d", i+2, name, len(dataLine), len(records[0]))
+		}
+		counts := make([]int, len(dataset.Months))
+		for month, value := range dataLine[1:] {
+			if strings.TrimSpace(value) == "" {
+				dataset.setMissing(len(dataset.Users), month)
+				continue
+			}
+			count, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid count \"%s\" for %s in %s (\"%s\")", value, dataLine[0], dataset.Months[month], name)
+			}
+			counts[month] = count
+		}
+		dataset.Users = append(dataset.Users, dataLine[0])
+		dataset.Counts = append(dataset.Counts, counts)
+	}
+	if dataset.Missing != nil {
+		dataset.setMissing(len(dataset.Users)-1, -1)
+	}
+	return dataset, nil
+}
+
+// Tells whether there is no data for the user in the month (as opposed to a count of 0)
+func (d *Dataset) isMissing(user int, month int) bool {
+	return d.Missing != nil && user < len(d.Missing) && d.Missing[user][month]
+}
+
+// Tells whether at least one user has data for the month
+func (d *Dataset) hasMonthData(month int) bool {
+	for user := range d.Users {
+		if !d.isMissing(user, month) {
+			return true
+		}
+	}
+	return false
+}
+
+// Flags a month without data for a user, allocating the flags up to this user (a negative
+// month only allocates them)
+func (d *Dataset) setMissing(user int, month int) {
+	for len(d.Missing) <= user {
+		d.Missing = append(d.Missing, make([]bool, len(d.Months)))
+	}
+	if month >= 0 {
+		d.Missing[user][month] = true
+	}
+}
+
+// Loads a pivot table file as a dataset named after the file
+func loadDataset(ctx context.Context, fileName string) (*Dataset, error) {
+	records, err := loadInputPivotTableContext(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return newDataset(datasetName(fileName), records)
+}
+
+// Returns the dataset as pivot table records (header line first)
+func (d *Dataset) Records() [][]string {
+	records := [][]string{append([]string{""}, d.Months...)}
+	for i, user := range d.Users {
+		dataLine := []string{user}
+		for month, count := range d.Counts[i] {
+			if d.isMissing(i, month) {
+				dataLine = append(dataLine, "")
+			} else {
+				dataLine = append(dataLine, strconv.Itoa(count))
+			}
+		}
+		records = append(records, dataLine)
+	}
+	return records
+}
+
+// Returns the index of the month (-1 if not available)
+func (d *Dataset) monthIndex(month string) int {
+	for i, available := range d.Months {
+		if available == month {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the index of the user (-1 if not available)
+func (d *Dataset) userIndex(user string) int {
+	for i, available := range d.Users {
+		if available == user {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the index of the user, the case being ignored (-1 if not available)
+func (d *Dataset) findUser(user string) int {
+	for i, available := range d.Users {
+		if strings.EqualFold(available, user) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the dataset restricted to the months between from and to (included, empty for
+// no limit). Unlike Slice, the limits don't need to be months of the dataset.
+func (d *Dataset) Between(from string, to string) (*Dataset, error) {
+	var kept []int
+	for i, month := range d.Months {
+		if (from == "" || month >= from) && (to == "" || month <= to) {
+			kept = append(kept, i)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("No month of the dataset is between \"%s\" and \"%s\"", from, to)
+	}
+
+	between := &Dataset{Name: d.Name, Users: d.Users}
+	for _, month := range kept {
+		between.Months = append(between.Months, d.Months[month])
+	}
+	for i, counts := range d.Counts {
+		keptCounts := make([]int, len(kept))
+		for k, month := range kept {
+			keptCounts[k] = counts[month]
+			if d.isMissing(i, month) {
+				between.setMissing(i, k)
+			}
+		}
+		between.Counts = append(between.Counts, keptCounts)
+	}
+	if between.Missing != nil {
+		between.setMissing(len(between.Users)-1, -1)
+	}
+	return between, nil
+}
+
+// Returns the dataset without the users having no activity, and these users
+func (d *Dataset) WithoutInactiveUsers() (*Dataset, []string) {
+	active := &Dataset{Name: d.Name, Months: d.Months}
+	var inactiveUsers []string
+	for i, total := range d.UserTotals() {
+		if total == 0 {
+			inactiveUsers = append(inactiveUsers, d.Users[i])
+			continue
+		}
+		active.Users = append(active.Users, d.Users[i])
+		active.Counts = append(active.Counts, d.Counts[i])
+		if d.Missing != nil {
+			active.Missing = append(active.Missing, d.Missing[i])
+		}
+	}
+	return active, inactiveUsers
+}
+
+// Returns the rank of each user (in the order of the users) for the month. The users
+// with the same count share the same rank ("1, 2, 2, 4" ranking).
+func (d *Dataset) MonthRanks(month int) []int {
+	sortedCounts := make([]int, len(d.Users))
+	for i, counts := range d.Counts {
+		sortedCounts[i] = counts[month]
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sortedCounts)))
+
+	ranks := make([]int, len(d.Users))
+	for i, counts := range d.Counts {
+		// the rank is one more than the number of users with a higher count
+		count := counts[month]
+		ranks[i] = sort.Search(len(sortedCounts), func(k int) bool { return sortedCounts[k] <= count }) + 1
+	}
+	return ranks
+}
+
+// Returns the dataset restricted to the months between from and to (included)
+func (d *Dataset) Slice(from string, to string) (*Dataset, error) {
+	first, last := d.monthIndex(from), d.monthIndex(to)
+	if first == -1 || last == -1 || first > last {
+		return nil, fmt.Errorf("The period %s to %s is not available in \"%s\"", from, to, d.Name)
+	}
+	slice := &Dataset{Name: d.Name, Months: d.Months[first : last+1], Users: d.Users}
+	for _, counts := range d.Counts {
+		slice.Counts = append(slice.Counts, counts[first:last+1])
+	}
+	for _, missing := range d.Missing {
+		slice.Missing = append(slice.Missing, missing[first:last+1])
+	}
+	return slice, nil
+}
+
+// Returns the dataset restricted to the period of months ending with endMonth
+// ("latest" for the last one), like the "--month" and "--period" flags
+func (d *Dataset) Period(endMonth string, period int) (*Dataset, error) {
+	firstColumn, lastColumn, _, _ := getBoundaries([][]string{append([]string{""}, d.Months...)}, endMonth, period, 0)
+	if lastColumn == 0 {
+		return nil, fmt.Errorf("Failed to compute the period")
+	}
+	return d.Slice(d.Months[firstColumn-1], d.Months[lastColumn-1])
+}
+
+// Returns the total of each user (in the order of the users)
+func (d *Dataset) UserTotals() []int {
+	totals := make([]int, len(d.Users))
+	for i, counts := range d.Counts {
+		for _, count := range counts {
+			totals[i] += count
+		}
+	}
+	return totals
+}
+
+// Returns the total of each month (in the order of the months)
+func (d *Dataset) MonthTotals() []int {
+	totals := make([]int, len(d.Months))
+	for _, counts := range d.Counts {
+		for month, count := range counts {
+			totals[month] += count
+		}
+	}
+	return totals
+}
+
+// Returns the users sorted by descending total (ex aequo share the same rank)
+func (d *Dataset) Leaderboard() []leaderboardEntry {
+	var leaderboard []leaderboardEntry
+	for i, total := range d.UserTotals() {
+		leaderboard = append(leaderboard, leaderboardEntry{User: d.Users[i], Total: total})
+	}
+	return rankLeaderboard(leaderboard)
+}
+
+// Merges two datasets: the months are the union of both (sorted), the counts of the same
+// users (the case being ignored) are added. There is no data for a user in a month only if
+// none of the datasets covering the month has data for this user.
+func (d *Dataset) Join(other *Dataset) *Dataset {
+	months := append([]string(nil), d.Months...)
+	for _, month := range other.Months {
+		if d.monthIndex(month) == -1 {
+			months = append(months, month)
+		}
+	}
+	sort.Strings(months)
+
+	joined := &Dataset{Name: d.Name + "+" + other.Name, Months: months}
+	userIndexes := make(map[string]int)
+	var hasData [][]bool
+	for _, source := range []*Dataset{d, other} {
+		for i, user := range source.Users {
+			index, isJoined := userIndexes[strings.ToLower(user)]
+			if !isJoined {
+				index = len(joined.Users)
+				userIndexes[strings.ToLower(user)] = index
+				joined.Users = append(joined.Users, user)
+				joined.Counts = append(joined.Counts, make([]int, len(months)))
+				hasData = append(hasData, make([]bool, len(months)))
+			}
+			for month, count := range source.Counts[i] {
+				joinedMonth := joined.monthIndex(source.Months[month])
+				joined.Counts[index][joinedMonth] += count
+				if !source.isMissing(i, month) {
+					hasData[index][joinedMonth] = true
+				}
+			}
+		}
+	}
+	if d.Missing == nil && other.Missing == nil {
+		return joined
+	}
+
+	// The users absent from a dataset have no activity in its months
+	for _, source := range []*Dataset{d, other} {
+		for user, index := range userIndexes {
+			if sourceHasUser(source, user) {
+				continue
+			}
+			for _, month := range source.Months {
+				hasData[index][joined.monthIndex(month)] = true
+			}
+		}
+	}
+	for index := range joined.Users {
+		for month := range months {
+			if !hasData[index][month] {
+				joined.setMissing(index, month)
+			}
+		}
+	}
+	if joined.Missing != nil {
+		joined.setMissing(len(joined.Users)-1, -1)
+	}
+	return joined
+}
+
+// Tells whether the dataset has the user (the case being ignored)
+func sourceHasUser(source *Dataset, lowerCaseUser string) bool {
+	for _, user := range source.Users {
+		if strings.ToLower(user) == lowerCaseUser {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var datasetRecords = [][]string{
+	{"", "2023-01", "2023-02", "2023-03"},
+	{"alpha", "5", "0", "1"},
+	{"Bravo", "0", "2", "2"},
+	{"charlie", "1", "0", "0"},
+}
+
+func Test_newDataset(t *testing.T) {
+	dataset, err := newDataset("ci", datasetRecords)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-01", "2023-02", "2023-03"}, dataset.Months)
+	assert.Equal(t, []string{"alpha", "Bravo", "charlie"}, dataset.Users)
+	assert.Equal(t, []int{0, 2, 2}, dataset.Counts[1])
+	assert.Equal(t, datasetRecords, dataset.Records())
+
+	_, err = newDataset("bad", [][]string{{"", "2023-01"}, {"alpha", "x"}})
+	assert.ErrorContains(t, err, "Invalid count \"x\" for alpha in 2023-01 (\"bad\")")
+	_, err = newDataset("short", [][]string{{"", "2023-01"}, {"alpha"}})
+	assert.ErrorContains(t, err, "Line 2 of \"short\" has 1 columns instead of 2")
+	_, err = newDataset("empty", [][]string{{""}})
+	assert.ErrorContains(t, err, "No month in the pivot table of \"empty\"")
+}
+
+func Test_newDataset_missing(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "5", "", "0"},
+		{"bravo", "1", "2", "3"},
+	}
+
+	dataset, err := newDataset("ci", records)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]bool{{false, true, false}, {false, false, false}}, dataset.Missing)
+	assert.True(t, dataset.isMissing(0, 1))
+	assert.False(t, dataset.isMissing(0, 2), "a count of 0 is not missing")
+	assert.Equal(t, []int{5, 6}, dataset.UserTotals())
+	assert.Equal(t, records, dataset.Records())
+
+	slice, err := dataset.Slice("2023-02", "2023-03")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]bool{{true, false}, {false, false}}, slice.Missing)
+
+	full, _ := newDataset("ci", datasetRecords)
+	assert.Nil(t, full.Missing)
+}
+
+func Test_loadDataset(t *testing.T) {
+	dataset, err := loadDataset(context.Background(), "../test_data/deleted_user_case.csv")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "deleted_user_case", dataset.Name)
+	assert.Equal(t, len(dataset.Users), len(dataset.Counts))
+
+	// The loading stops when the context is cancelled (ex: Ctrl-C)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = loadDataset(cancelledCtx, "../test_data/deleted_user_case.csv")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func Test_Dataset_Period(t *testing.T) {
+	dataset, _ := newDataset("ci", datasetRecords)
+
+	period, err := dataset.Period("latest", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-02", "2023-03"}, period.Months)
+	assert.Equal(t, []int{1, 4, 0}, period.UserTotals())
+	assert.Equal(t, []int{2, 3}, period.MonthTotals())
+
+	slice, err := dataset.Slice("2023-01", "2023-02")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 2, 1}, slice.UserTotals())
+
+	// The period is cut at the first month of the dataset
+	period, err = dataset.Period("2023-02", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-01", "2023-02"}, period.Months)
+
+	_, err = dataset.Slice("2023-03", "2023-01")
+	assert.ErrorContains(t, err, "The period 2023-03 to 2023-01 is not available in \"ci\"")
+}
+
+func Test_Dataset_Leaderboard(t *testing.T) {
+	dataset, _ := newDataset("ci", datasetRecords)
+
+	assert.Equal(t, []leaderboardEntry{
+		{Rank: 1, User: "alpha", Total: 6},
+		{Rank: 2, User: "Bravo", Total: 4},
+		{Rank: 3, User: "charlie", Total: 1},
+	}, dataset.Leaderboard())
+}
+
+func Test_Dataset_Between(t *testing.T) {
+	dataset, _ := newDataset("ci", datasetRecords)
+
+	between, err := dataset.Between("2023-02", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-02", "2023-03"}, between.Months)
+	assert.Equal(t, []int{1, 4, 0}, between.UserTotals())
+
+	// The limits don't need to be months of the dataset
+	between, err = dataset.Between("2022-06", "2023-01")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-01"}, between.Months)
+
+	_, err = dataset.Between("2024-01", "")
+	assert.ErrorContains(t, err, "No month of the dataset is between \"2024-01\" and \"\"")
+}
+
+func Test_Dataset_WithoutInactiveUsers(t *testing.T) {
+	dataset, _ := newDataset("ci", datasetRecords)
+	period, _ := dataset.Period("latest", 2)
+
+	active, inactiveUsers := period.WithoutInactiveUsers()
+
+	assert.Equal(t, []string{"alpha", "Bravo"}, active.Users)
+	assert.Equal(t, []string{"charlie"}, inactiveUsers)
+}
+
+func Test_Dataset_MonthRanks(t *testing.T) {
+	dataset, _ := newDataset("ci", [][]string{
+		{"", "2023-01", "2023-02"},
+		{"alpha", "1", "2"},
+		{"bravo", "4", "2"},
+		{"charly", "0", "7"},
+		{"delta", "3", "0"},
+	})
+
+	assert.Equal(t, []int{3, 1, 4, 2}, dataset.MonthRanks(0))
+	// The users with the same count share the same rank
+	assert.Equal(t, []int{2, 2, 1, 4}, dataset.MonthRanks(1))
+}
+
+func Test_Dataset_Join(t *testing.T) {
+	datasetA, _ := newDataset("ci", datasetRecords)
+	datasetB, _ := newDataset("infra", [][]string{
+		{"", "2022-12", "2023-03"},
+		{"bravo", "1", "3"},
+		{"delta", "2", "0"},
+	})
+
+	joined := datasetA.Join(datasetB)
+
+	assert.Equal(t, [][]string{
+		{"", "2022-12", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "0", "5", "0", "1"},
+		{"Bravo", "1", "0", "2", "5"},
+		{"charlie", "0", "1", "0", "0"},
+		{"delta", "2", "0", "0", "0"},
+	}, joined.Records())
+	assert.Equal(t, "ci+infra", joined.Name)
+	assert.Nil(t, joined.Missing)
+
+	// No data only if no dataset covering the month has data for the user
+	datasetC, _ := newDataset("infra", [][]string{
+		{"", "2023-03", "2023-04"},
+		{"alpha", "", ""},
+		{"echo", "", "4"},
+	})
+	joined = datasetA.Join(datasetC)
+	assert.Equal(t, [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "5", "0", "1", ""},
+		{"Bravo", "0", "2", "2", "0"},
+		{"charlie", "1", "0", "0", "0"},
+		{"echo", "0", "0", "0", "4"},
+	}, joined.Records())
+}
@@ -0,0 +1,209 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var highlightedUsers []string
+var highlightMarker string
+var isWithMedals bool
+var isWithBars bool
+
+// Width (in characters) of the longest bar
+const maxBarWidth = 20
+
+// Partial blocks used to draw the end of the bars (in eighths of a character)
+var partialBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// Medals of the top three ranks
+var rankMedals = []string{"🥇", "🥈", "🥉"}
+
+// Decorations of the Markdown tables (the first column contains the users)
+type markdownDecorations struct {
+	AnnotationsFile string   // notes rendered as footnotes
+	Highlighted     []string // users to highlight
+	HighlightMarker string   // appended to the highlighted users (bold if empty)
+	IsWithMedals    bool     // decorates the top three ranks with medals
+	IsWithBars      bool     // adds a column with a bar proportional to the total
+	MaxRows         int      // number of rows after which the table is split (0 for no limit)
+	SplitMode       string   // "details" (collapsible sections) or "files"
+}
+
+// Adds the flags to decorate the users of the Markdown output
+func addDecorationFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVarP(&highlightedUsers, "highlight", "", []string{}, "Users to highlight in the Markdown output (comma separated)")
+	cmd.PersistentFlags().StringVarP(&highlightMarker, "highlight-marker", "", "", "Text (ex: an emoji) appended to the highlighted users (bold by default)")
+	cmd.PersistentFlags().BoolVarP(&isWithMedals, "medals", "", false, "Decorates the top three of the Markdown output with medals")
+	cmd.PersistentFlags().BoolVarP(&isWithBars, "bars", "", false, "Adds a column with a bar proportional to the total in the Markdown output")
+	cmd.PersistentFlags().IntVarP(&maxTableRows, "max-rows", "", 0, "Number of rows after which the Markdown table is split (0 for no limit)")
+	cmd.PersistentFlags().StringVarP(&tableSplitMode, "split-mode", "", splitModeDetails, "How the long tables are split: \"details\" (collapsible sections) or \"files\"")
+	_ = cmd.RegisterFlagCompletionFunc("split-mode", cobra.FixedCompletions([]string{splitModeDetails, splitModeFiles}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Returns the decorations requested on the command line
+func getMarkdownDecorations() markdownDecorations {
+	return markdownDecorations{
+		AnnotationsFile: annotationsFileName,
+		Highlighted:     highlightedUsers,
+		HighlightMarker: highlightMarker,
+		IsWithMedals:    isWithMedals,
+		IsWithBars:      isWithBars,
+		MaxRows:         maxTableRows,
+		SplitMode:       tableSplitMode,
+	}
+}
+
+// Highlights the given users (case insensitive) of a table, either in bold or with the marker.
+// Only the name is put in bold, not the enrichments following it (ex: a footnote reference).
+func highlightMarkdownTable(data [][]string, users []string, marker string) [][]string {
+	if len(users) == 0 {
+		return data
+	}
+	highlighted := make(map[string]bool)
+	for _, user := range users {
+		highlighted[strings.ToLower(user)] = true
+	}
+
+	decorated := make([][]string, 0, len(data))
+	for i, line := range data {
+		name, enrichment, _ := strings.Cut(line[0], " ")
+		if i == 0 || !highlighted[strings.ToLower(name)] {
+			decorated = append(decorated, line)
+			continue
+		}
+
+		decoratedLine := append([]string{}, line...)
+		if marker == "" {
+			decoratedLine[0] = "**" + name + "**"
+		} else {
+			decoratedLine[0] = name + " " + marker
+		}
+		if enrichment != "" {
+			decoratedLine[0] += " " + enrichment
+		}
+		decorated = append(decorated, decoratedLine)
+	}
+	return decorated
+}
+
+// Returns the medal of a rank (empty after the third rank)
+func medalOf(rank int) string {
+	if rank < 1 || rank > len(rankMedals) {
+		return ""
+	}
+	return rankMedals[rank-1]
+}
+
+// Adds a medal to the users of the top three ranks of a table (sorted on the totals of the
+// second column). The ex-aequo share the same rank.
+func addMedalsToMarkdownTable(data [][]string) [][]string {
+	decorated := make([][]string, 0, len(data))
+	rank, previousTotal := 0, -1
+	for i, line := range data {
+		total, err := strconv.Atoi(line[1])
+		if i == 0 || err != nil {
+			decorated = append(decorated, line)
+			continue
+		}
+		if total != previousTotal {
+			rank = i
+			previousTotal = total
+		}
+
+		medal := medalOf(rank)
+		if medal == "" {
+			decorated = append(decorated, line)
+			continue
+		}
+		decoratedLine := append([]string{}, line...)
+		name, enrichment, _ := strings.Cut(line[0], " ")
+		decoratedLine[0] = name + " " + medal
+		if enrichment != "" {
+			decoratedLine[0] += " " + enrichment
+		}
+		decorated = append(decorated, decoratedLine)
+	}
+	return decorated
+}
+
+// Adds an "Activity" column with a bar proportional to the total (second column), scaled to the maximum
+func addBarsToMarkdownTable(data [][]string) [][]string {
+	maxTotal := 0
+	for _, line := range data[1:] {
+		if total, _ := strconv.Atoi(line[1]); total > maxTotal {
+			maxTotal = total
+		}
+	}
+
+	decorated := [][]string{append(append([]string{}, data[0]...), "Activity")}
+	for _, line := range data[1:] {
+		total, _ := strconv.Atoi(line[1])
+		decorated = append(decorated, append(append([]string{}, line...), renderBar(total, maxTotal)))
+	}
+	return decorated
+}
+
+// Renders a bar of maxBarWidth characters for the maximum, with a precision of an eighth of character
+func renderBar(value int, maxValue int) string {
+	if value <= 0 || maxValue <= 0 {
+		return ""
+	}
+	eighths := value * maxBarWidth * 8 / maxValue
+	// Any activity is visible
+	if eighths == 0 {
+		eighths = 1
+	}
+	return strings.Repeat("█", eighths/8) + partialBlocks[eighths%8]
+}
+
+// Writes the data as Markdown, with the requested decorations
+func writeDecoratedMarkdown(outputFileName string, data [][]string, introductionText string, decorations markdownDecorations, isHistory bool, inputType InputType) error {
+	if decorations.IsWithMedals {
+		data = addMedalsToMarkdownTable(data)
+	}
+	footnotes := ""
+	if decorations.AnnotationsFile != "" {
+		annotations, err := loadAnnotations(decorations.AnnotationsFile)
+		if err != nil {
+			return err
+		}
+		data, footnotes = annotateMarkdownTable(data, annotations)
+	}
+	data = highlightMarkdownTable(data, decorations.Highlighted, decorations.HighlightMarker)
+	if decorations.IsWithBars {
+		data = addBarsToMarkdownTable(data)
+	}
+
+	if decorations.MaxRows > 0 && len(data)-1 > decorations.MaxRows {
+		if decorations.SplitMode != splitModeDetails && decorations.SplitMode != splitModeFiles {
+			return fmt.Errorf("Invalid split mode \"%s\" (should be \"%s\" or \"%s\")", decorations.SplitMode, splitModeDetails, splitModeFiles)
+		}
+		return writePaginatedMarkdown(outputFileName, data, introductionText, footnotes, isHistory, inputType, decorations.MaxRows, decorations.SplitMode)
+	}
+	return writeDataAsMarkdownWithNotes(outputFileName, data, introductionText, footnotes, isHistory, inputType)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_highlightMarkdownTable(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"bravo [^1]", "7"},
+		{"charlie", "3"},
+	}
+
+	tests := []struct {
+		name   string
+		users  []string
+		marker string
+		want   [][]string
+	}{
+		{
+			"bold",
+			[]string{"ALPHA", "bravo"},
+			"",
+			[][]string{{"Submitter", "Total_PRs"}, {"**alpha**", "12"}, {"**bravo** [^1]", "7"}, {"charlie", "3"}},
+		},
+		{
+			"marker",
+			[]string{"bravo", "charlie"},
+			"🚀",
+			[][]string{{"Submitter", "Total_PRs"}, {"alpha", "12"}, {"bravo 🚀 [^1]", "7"}, {"charlie 🚀", "3"}},
+		},
+		{
+			"nobody",
+			nil,
+			"",
+			data,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, highlightMarkdownTable(data, tt.users, tt.marker))
+		})
+	}
+	// The original data is not modified
+	assert.Equal(t, "alpha", data[1][0])
+}
+
+func Test_addMedalsToMarkdownTable(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"alpha", "12", ""},
+		{"bravo", "7", "new"},
+		{"charlie", "7", ""},
+		{"delta", "5", ""},
+		{"echo", "", "churned"},
+	}
+
+	decorated := addMedalsToMarkdownTable(data)
+
+	// The ex-aequo share the same medal
+	expected := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"alpha 🥇", "12", ""},
+		{"bravo 🥈", "7", "new"},
+		{"charlie 🥈", "7", ""},
+		{"delta", "5", ""},
+		{"echo", "", "churned"},
+	}
+	assert.Equal(t, expected, decorated)
+	assert.Equal(t, "alpha", data[1][0])
+
+	assert.Equal(t, "🥉", medalOf(3))
+	assert.Equal(t, "", medalOf(4))
+	assert.Equal(t, "", medalOf(0))
+}
+
+func Test_addBarsToMarkdownTable(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"alpha", "16", ""},
+		{"bravo", "9", "new"},
+		{"charlie", "", "churned"},
+	}
+
+	decorated := addBarsToMarkdownTable(data)
+
+	expected := [][]string{
+		{"Submitter", "Total_PRs", "Status", "Activity"},
+		{"alpha", "16", "", "████████████████████"},
+		{"bravo", "9", "new", "███████████▎"},
+		{"charlie", "", "churned", ""},
+	}
+	assert.Equal(t, expected, decorated)
+	assert.Len(t, data[0], 3)
+}
+
+func Test_renderBar(t *testing.T) {
+	assert.Equal(t, "██████████", renderBar(5, 10))
+	assert.Equal(t, "█▌", renderBar(3, 40))
+	// The smallest activity is visible
+	assert.Equal(t, "▏", renderBar(1, 1000))
+	assert.Equal(t, "", renderBar(0, 10))
+	assert.Equal(t, "", renderBar(3, 0))
+}
+
+func Test_writeDecoratedMarkdown(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "top.md")
+	createPlotFiles(t, tempDir, "basil", "bravo")
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"basil", "12"},
+		{"bravo", "7"},
+	}
+	decorations := markdownDecorations{AnnotationsFile: "../test_data/annotations.csv", Highlighted: []string{"basil"}, IsWithMedals: true}
+
+	err := writeDecoratedMarkdown(outputFile, data, "", decorations, true, InputTypeSubmitters)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	// The link to the plot uses the plain name
+	assert.Contains(t, string(content), "| [**basil** 🥇 [^1]](plot/basil.png) |")
+	assert.Contains(t, string(content), "| [bravo 🥈](plot/bravo.png) |")
+	assert.Contains(t, string(content), "[^1]: basil: Release lead; Company X sponsored\n")
+
+	decorations.AnnotationsFile = "../test_data/blaah.csv"
+	assert.Error(t, writeDecoratedMarkdown(outputFile, data, "", decorations, false, InputTypeSubmitters))
+}
+
+func Test_ExecuteExtract_highlight(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+	defer resetFlags(extractCmd)
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "10", "--history=false", "-o", outputFile, "--highlight", "basil,MarkEWaite", "--highlight-marker", "⭐"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "| basil ⭐")
+	assert.Contains(t, string(content), "| MarkEWaite ⭐")
+}
@@ -0,0 +1,264 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var dedupeOutputFileName string
+var dedupeReportFileName string
+var dedupeKeyColumns []string
+var dedupeKeep string
+
+// A row of an export file
+type exportRow struct {
+	File   string
+	Line   int // line in the file (starting at 1)
+	Values []string
+}
+
+// A row found again (identical or near-identical) after its first occurrence
+type duplicateRow struct {
+	Checksum  string
+	Kind      string // "identical" or "similar"
+	Row       exportRow
+	Duplicate exportRow // the first occurrence
+}
+
+// dedupeCmd represents the dedupe command
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe [export files...]",
+	Short: "Detects and merges the duplicated rows of several export files",
+	Long: `The DEDUPE command detects the rows found several times in a set of CSV export
+files (ex: the monthly files of pipeline re-runs) before they are pivoted. The files
+must have the same header.
+
+Each row gets a checksum computed on its normalized values (trimmed, case insensitive).
+Rows with the same checksum are duplicates: "identical" if they are exactly the same,
+"similar" otherwise. With "--key", only the given columns are used (ex: "--key url"
+for PRs exported twice with a different state).
+
+The duplicates are listed (with their file and line), and written as CSV with
+"--report". With "--out", the rows are merged in a single file without the duplicates
+(the first occurrence is kept, or the last one with "--keep last").`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		for _, fileName := range args {
+			if !isFileValid(fileName) {
+				return fmt.Errorf("Invalid input file \"%s\"\n", fileName)
+			}
+		}
+		if dedupeKeep != "first" && dedupeKeep != "last" {
+			return fmt.Errorf("Invalid \"--keep\" value \"%s\" (should be \"first\" or \"last\")\n", dedupeKeep)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		header, rows, err := loadExportFiles(args)
+		if err != nil {
+			return err
+		}
+		keyColumns, err := getKeyColumns(header, dedupeKeyColumns)
+		if err != nil {
+			return err
+		}
+
+		kept, duplicates := dedupeRows(rows, keyColumns, dedupeKeep == "last")
+		for _, duplicate := range duplicates {
+			logInfo("%s:%d is %s to %s:%d\n", duplicate.Row.File, duplicate.Row.Line, duplicate.Kind, duplicate.Duplicate.File, duplicate.Duplicate.Line)
+		}
+		logInfo("%d duplicated rows found in %d rows\n", len(duplicates), len(rows))
+
+		if dedupeReportFileName != "" {
+			if err := CheckDir(dedupeReportFileName); err != nil {
+				return err
+			}
+			if err := writeCSVtoFile(dedupeReportFileName, duplicatesAsTable(duplicates)); err != nil {
+				return err
+			}
+		}
+		if dedupeOutputFileName != "" {
+			for _, duplicate := range duplicates {
+				recordTransformation(auditDeduplication, nil, "%s:%d removed (%s to %s:%d)", duplicate.Row.File, duplicate.Row.Line, duplicate.Kind, duplicate.Duplicate.File, duplicate.Duplicate.Line)
+			}
+			if err := CheckDir(dedupeOutputFileName); err != nil {
+				return err
+			}
+			merged := [][]string{header}
+			for _, row := range kept {
+				merged = append(merged, row.Values)
+			}
+			if err := writeCSVtoFile(dedupeOutputFileName, merged); err != nil {
+				return err
+			}
+			logInfo("%d rows written to \"%s\"\n", len(kept), dedupeOutputFileName)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+
+	dedupeCmd.Flags().StringVarP(&dedupeOutputFileName, "out", "o", "", "Writes the merged rows, without the duplicates, in that file")
+	dedupeCmd.Flags().StringVarP(&dedupeReportFileName, "report", "", "", "Writes the duplicated rows in that CSV file")
+	dedupeCmd.Flags().StringSliceVarP(&dedupeKeyColumns, "key", "", nil, "Columns identifying a row (comma separated, all the columns by default)")
+	dedupeCmd.Flags().StringVarP(&dedupeKeep, "keep", "", "first", "Occurrence kept in the merged file (\"first\" or \"last\")")
+
+	dedupeCmd.ValidArgsFunction = completeInputFile
+	_ = dedupeCmd.RegisterFlagCompletionFunc("keep", cobra.FixedCompletions([]string{"first", "last"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Loads the rows of the export files, which must have the same header
+func loadExportFiles(fileNames []string) (header []string, rows []exportRow, err error) {
+	span := startSpan("load")
+	span.setAttribute("file", strings.Join(fileNames, ","))
+	defer func() {
+		span.setAttribute("rows", len(rows))
+		span.finish(err)
+	}()
+
+	for _, fileName := range fileNames {
+		f, err := os.Open(fileName)
+		if err != nil {
+			return nil, nil, err
+		}
+		r := csv.NewReader(f)
+		records, err := r.ReadAll()
+		f.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to read %s: %v", fileName, err)
+		}
+		countProcessedRows(len(records))
+		if len(records) == 0 {
+			continue
+		}
+
+		if header == nil {
+			header = records[0]
+		} else if strings.Join(records[0], ",") != strings.Join(header, ",") {
+			return nil, nil, fmt.Errorf("The header of %s is not the same as the one of %s", fileName, fileNames[0])
+		}
+		for i, record := range records[1:] {
+			rows = append(rows, exportRow{File: fileName, Line: i + 2, Values: record})
+		}
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("No data in the export files")
+	}
+	return header, rows, nil
+}
+
+// Returns the indexes of the key columns (all the columns if none is given)
+func getKeyColumns(header []string, names []string) ([]int, error) {
+	var columns []int
+	if len(names) == 0 {
+		for i := range header {
+			columns = append(columns, i)
+		}
+		return columns, nil
+	}
+	for _, name := range names {
+		index := -1
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), strings.TrimSpace(name)) {
+				index = i
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("Unknown key column \"%s\"", name)
+		}
+		columns = append(columns, index)
+	}
+	return columns, nil
+}
+
+// Returns the checksum of the normalized values of the key columns
+func rowChecksum(values []string, keyColumns []int) string {
+	hash := sha256.New()
+	for _, column := range keyColumns {
+		value := ""
+		if column < len(values) {
+			value = strings.ToLower(strings.TrimSpace(values[column]))
+		}
+		// The separator avoids ("ab", "c") having the checksum of ("a", "bc")
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// Separates the rows kept from their duplicates. The kept rows stay in their original order.
+func dedupeRows(rows []exportRow, keyColumns []int, isKeepingLast bool) (kept []exportRow, duplicates []duplicateRow) {
+	firstOccurrence := make(map[string]int) // index in rows
+	keptIndex := make(map[string]int)       // index in rows of the kept occurrence
+	checksums := make([]string, len(rows))
+
+	for i, row := range rows {
+		checksum := rowChecksum(row.Values, keyColumns)
+		checksums[i] = checksum
+		first, isDuplicate := firstOccurrence[checksum]
+		if !isDuplicate {
+			firstOccurrence[checksum] = i
+			keptIndex[checksum] = i
+			continue
+		}
+
+		kind := "similar"
+		if strings.Join(row.Values, "\x00") == strings.Join(rows[first].Values, "\x00") {
+			kind = "identical"
+		}
+		duplicates = append(duplicates, duplicateRow{Checksum: checksum, Kind: kind, Row: row, Duplicate: rows[first]})
+		if isKeepingLast {
+			keptIndex[checksum] = i
+		}
+	}
+
+	for i, row := range rows {
+		if keptIndex[checksums[i]] == i {
+			kept = append(kept, row)
+		}
+	}
+	return kept, duplicates
+}
+
+// Returns the duplicates as a table (with a header)
+func duplicatesAsTable(duplicates []duplicateRow) [][]string {
+	table := [][]string{{"checksum", "kind", "file", "line", "first_file", "first_line"}}
+	for _, duplicate := range duplicates {
+		table = append(table, []string{duplicate.Checksum, duplicate.Kind, duplicate.Row.File, strconv.Itoa(duplicate.Row.Line),
+			duplicate.Duplicate.File, strconv.Itoa(duplicate.Duplicate.Line)})
+	}
+	return table
+}
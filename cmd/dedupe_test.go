@@ -0,0 +1,111 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var dedupeTestFiles = []string{"../test_data/export_2023-03.csv", "../test_data/export_2023-03_rerun.csv"}
+
+func Test_dedupeRows(t *testing.T) {
+	header, rows, err := loadExportFiles(dedupeTestFiles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user", "url", "state", "month"}, header)
+	assert.Len(t, rows, 7)
+
+	tests := []struct {
+		name          string
+		key           []string
+		isKeepingLast bool
+		wantKept      []string // file:line of the kept rows
+		wantKinds     []string
+	}{
+		{"all columns", nil, false, []string{"export_2023-03.csv:2", "export_2023-03.csv:3", "export_2023-03.csv:4", "export_2023-03_rerun.csv:4", "export_2023-03_rerun.csv:5"}, []string{"identical", "similar"}},
+		{"url key", []string{"URL"}, false, []string{"export_2023-03.csv:2", "export_2023-03.csv:3", "export_2023-03.csv:4", "export_2023-03_rerun.csv:5"}, []string{"identical", "similar", "similar"}},
+		{"url key, last kept", []string{"url"}, true, []string{"export_2023-03_rerun.csv:2", "export_2023-03_rerun.csv:3", "export_2023-03_rerun.csv:4", "export_2023-03_rerun.csv:5"}, []string{"identical", "similar", "similar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyColumns, err := getKeyColumns(header, tt.key)
+			assert.NoError(t, err)
+
+			kept, duplicates := dedupeRows(rows, keyColumns, tt.isKeepingLast)
+
+			var gotKept []string
+			for _, row := range kept {
+				gotKept = append(gotKept, fmt.Sprintf("%s:%d", filepath.Base(row.File), row.Line))
+			}
+			assert.Equal(t, tt.wantKept, gotKept)
+			var gotKinds []string
+			for _, duplicate := range duplicates {
+				gotKinds = append(gotKinds, duplicate.Kind)
+			}
+			assert.Equal(t, tt.wantKinds, gotKinds)
+		})
+	}
+
+	_, err = getKeyColumns(header, []string{"blaah"})
+	assert.Error(t, err)
+}
+
+func Test_rowChecksum(t *testing.T) {
+	assert.Equal(t, rowChecksum([]string{"Bravo ", "x"}, []int{0, 1}), rowChecksum([]string{"bravo", "X"}, []int{0, 1}))
+	assert.NotEqual(t, rowChecksum([]string{"ab", "c"}, []int{0, 1}), rowChecksum([]string{"a", "bc"}, []int{0, 1}))
+	assert.Len(t, rowChecksum([]string{"a"}, []int{0}), 16)
+}
+
+func Test_loadExportFiles_differentHeaders(t *testing.T) {
+	_, _, err := loadExportFiles([]string{"../test_data/export_2023-03.csv", "../test_data/overview.csv"})
+	assert.ErrorContains(t, err, "is not the same")
+}
+
+func Test_ExecuteDedupe(t *testing.T) {
+	tempDir := t.TempDir()
+	mergedFile := filepath.Join(tempDir, "merged.csv")
+	reportFile := filepath.Join(tempDir, "duplicates.csv")
+	defer func() {
+		_ = dedupeCmd.Flags().Set("out", "")
+		_ = dedupeCmd.Flags().Set("report", "")
+		dedupeKeyColumns = nil
+	}()
+
+	rootCmd.SetArgs(append([]string{"dedupe", "-o", mergedFile, "--report", reportFile, "--key", "url"}, dedupeTestFiles...))
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	merged, err := os.ReadFile(mergedFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "user,url,state,month\n"+
+		"alpha,https://github.com/jenkinsci/a/pull/1,merged,2023-03\n"+
+		"bravo,https://github.com/jenkinsci/a/pull/2,open,2023-03\n"+
+		"charlie,https://github.com/jenkinsci/b/pull/7,merged,2023-03\n"+
+		"delta,https://github.com/jenkinsci/c/pull/3,merged,2023-03\n", string(merged))
+	report, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), ",similar,../test_data/export_2023-03_rerun.csv,4,../test_data/export_2023-03.csv,4\n")
+}
@@ -22,14 +22,18 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/jenkins-infra/jenkins-contribution-aggregator/pivottable"
 	"github.com/spf13/cobra"
 )
 
@@ -38,7 +42,6 @@ var outputFileName string
 var topSize int
 var period int
 var endMonth string
-var isVerboseExtract bool
 var argInputType string
 var isOutputHistory bool
 var inputType InputType
@@ -83,22 +86,24 @@ the list (resulting in more thant the specified number of top users).
 		if !isFileValid(args[0]) {
 			return fmt.Errorf("Invalid input file\n")
 		}
-		if !isValidMonth(endMonth, isVerboseExtract) {
+		if !isValidMonth(endMonth, isVerbose()) {
 			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
 		}
 
-		// check the input type
-		switch strings.ToLower(argInputType) {
-		case "submitters":
-			inputType = InputTypeSubmitters
-		case "commenters":
-			inputType = InputTypeCommenters
-		default:
-			inputType = InputTypeUnknown
+		// check the input type (or detect it)
+		var err error
+		if inputType, err = selectInputType(argInputType, args[0]); err != nil {
+			return err
 		}
 
-		if inputType == InputTypeUnknown {
-			return fmt.Errorf("%s is an invalid input type\n", argInputType)
+		if isWithZScore && !isOutputHistory {
+			return fmt.Errorf("\"--zscore\" requires \"--history\"\n")
+		}
+		if err := checkHistoryFlags(); err != nil {
+			return err
+		}
+		if sampleSize < 0 {
+			return fmt.Errorf("\"--sample\" can't be negative\n")
 		}
 
 		return nil
@@ -113,11 +118,25 @@ the list (resulting in more thant the specified number of top users).
 		if !checkFile(inputPivotTableName, isSilent) {
 			return fmt.Errorf("Invalid input file.")
 		}
+		if err := reportSpikes(cmd.Context(), inputPivotTableName); err != nil {
+			return err
+		}
 
 		// Extract the data (with no offset)
-		result, real_endDate, csv_output_slice := extractData(inputPivotTableName, topSize, endMonth, period, 0, inputType, isVerboseExtract)
+		result, real_endDate, csv_output_slice := extractData(cmd.Context(), inputPivotTableName, topSize, endMonth, period, 0, inputType)
 		if !result {
-			return fmt.Errorf("Failed to extract data")
+			return extractionError(cmd.Context(), "Failed to extract data")
+		}
+
+		// The history is based on the top users only (without the added columns)
+		topUsers := csv_output_slice
+
+		// If requested, add the trend compared with the previous month
+		if isWithTrend {
+			var err error
+			if csv_output_slice, err = addTrendColumn(inputPivotTableName, csv_output_slice, endMonth, period); err != nil {
+				return err
+			}
 		}
 
 		//FIXME: change default filename when specifying another type of input
@@ -125,15 +144,11 @@ the list (resulting in more thant the specified number of top users).
 		if outputFileName == "top-submitters_YYYY-MM.csv" {
 			outputFileName = "top-submitters_" + strings.ToUpper(endMonth) + ".csv"
 		}
-		isMDoutput := isWithMDfileExtension(outputFileName)
-
-		if isVerboseExtract {
-			fileTypeText := "(CSV format)"
-			if isMDoutput {
-				fileTypeText = "(Markdown format)"
-			}
-			fmt.Printf("Writing extraction to \"%s\" %s\n\n", outputFileName, fileTypeText)
+		outputFormat, err := selectRenderFormat(outputFileName, resultFormat)
+		if err != nil {
+			return err
 		}
+		isMDoutput := outputFormat == renderMarkdown
 
 		// Check that the output directory exists
 		dirErr := CheckDir(outputFileName)
@@ -141,34 +156,120 @@ the list (resulting in more thant the specified number of top users).
 			return dirErr
 		}
 
+		previous, err := keepPreviousOutput(outputFileName)
+		if err != nil {
+			return err
+		}
+
+		// If requested, the files are generated in a staging directory
+		targetFileName := outputFileName
+		var transaction *outputTransaction
+		if isAtomicOutput {
+			if transaction, err = beginOutputTransaction(outputFileName); err != nil {
+				return err
+			}
+			defer transaction.rollback()
+			targetFileName = transaction.stagedName(outputFileName)
+		}
+
+		options := renderOptions{Title: getTopTitle(inputType), IsHistory: isOutputHistory, InputType: inputType}
 		if isMDoutput {
-			introduction := ""
-			if inputType == InputTypeSubmitters {
-				introduction = "# Top Submitters\n"
-				buffer := fmt.Sprintf("\nExtraction of the %d top submitters (non-bot PR creators) \nover the %d months before \"%s\".\n\n", topSize, period, real_endDate)
-				introduction = introduction + buffer
+			options.Introduction = getExtractIntroduction(inputType, topSize, period, real_endDate)
+			if introFileName != "" {
+				header, err := loadPivotTableHeader(inputPivotTableName)
+				if err != nil {
+					return err
+				}
+				options.Introduction, err = renderIntroductionFile(introFileName, newIntroductionData(header, endMonth, period, topSize, 0, inputType, csv_output_slice))
+				if err != nil {
+					return err
+				}
 			}
-			if inputType == InputTypeCommenters {
-				introduction = "# Top Commenters\n"
-				buffer := fmt.Sprintf("\nExtraction of the %d top (non-bot) commenters \nover the %d months before \"%s\".\n\n", topSize, period, real_endDate)
-				introduction = introduction + buffer
+			decorations := getMarkdownDecorations()
+			options.Decorations = &decorations
+		}
+		// The history (and the plots) is written first, for the Markdown to only link to the generated plots
+		historyOutputFilename := generateHistoryFilename(targetFileName, inputType, false)
+		if isOutputHistory {
+			if err := writeHistoryOutput(historyOutputFilename, inputPivotTableName, inputType, topUsers); err != nil {
+				return err
+			}
+		}
+		if err := renderTable(targetFileName, outputFormat, csv_output_slice, options); err != nil {
+			return err
+		}
+
+		artifacts := []string{targetFileName}
+
+		if sampleSize > 0 {
+			sampleOutputFilename := generateSampleFilename(targetFileName)
+			artifacts = append(artifacts, sampleOutputFilename)
+			if err := writeSample(cmd.Context(), sampleOutputFilename, inputPivotTableName, topUsers, endMonth, period, sampleSize, sampleSeed); err != nil {
+				return err
+			}
+		}
+
+		if isWithAnniversaries {
+			anniversariesOutputFilename := generateAnniversariesFilename(targetFileName)
+			artifacts = append(artifacts, anniversariesOutputFilename)
+			markdownFilename := ""
+			if isMDoutput {
+				markdownFilename = targetFileName
+			}
+			if err := writeAnniversaries(cmd.Context(), anniversariesOutputFilename, inputPivotTableName, endMonth, anniversaryYears, markdownFilename); err != nil {
+				return err
 			}
-			writeDataAsMarkdown(outputFileName, csv_output_slice, introduction, isOutputHistory, inputType)
-		} else {
-			writeCSVtoFile(outputFileName, csv_output_slice)
 		}
 
 		//if requested, write the history based the supplied top user slice
 		if isOutputHistory {
-			isCompare := false
-			historyOutputFilename := generateHistoryFilename(outputFileName, inputType, isCompare)
+			artifacts = append(artifacts, historyOutputFilename)
+			if isMDoutput {
+				if err := appendHistoryToMarkdown(targetFileName, historyOutputFilename, inputType); err != nil {
+					return err
+				}
+			}
+			if isWithZScore {
+				zscoreOutputFilename := generateZScoreFilename(historyOutputFilename)
+				artifacts = append(artifacts, zscoreOutputFilename)
+				if err := writeZScoreHistory(historyOutputFilename, zscoreOutputFilename); err != nil {
+					return err
+				}
+			}
+			if isWithLongHistory {
+				longHistoryFilename := generateLongHistoryFilename(historyOutputFilename)
+				artifacts = append(artifacts, longHistoryFilename)
+				if err := writeLongHistory(historyOutputFilename, longHistoryFilename, isLongHistorySparse); err != nil {
+					return err
+				}
+			}
+			if isWithLeaderboardHistory {
+				leaderboardFilename := generateLeaderboardHistoryFilename(historyOutputFilename)
+				artifacts = append(artifacts, leaderboardFilename)
+				if err := writeLeaderboardHistory(cmd.Context(), leaderboardFilename, inputPivotTableName, topSize); err != nil {
+					return err
+				}
+			}
+			if isWithDashboardData {
+				dashboardFilename := generateDashboardFilename(historyOutputFilename)
+				artifacts = append(artifacts, dashboardFilename)
+				if err := writeDashboardData(cmd.Context(), dashboardFilename, historyOutputFilename, inputPivotTableName, inputType); err != nil {
+					return err
+				}
+			}
+		}
 
-			if err := writeHistoryOutput(historyOutputFilename, inputPivotTableName, inputType, csv_output_slice); err != nil {
+		if transaction != nil {
+			if err := transaction.commit(); err != nil {
 				return err
 			}
+			artifacts = transaction.finalNames(artifacts)
+		}
+		if err := previous.showDiff(outputFileName); err != nil {
+			return err
 		}
 
-		return nil
+		return notifyGeneration(cmd.Context(), "extract", inputPivotTableName, csv_output_slice, artifacts)
 	},
 }
 
@@ -177,29 +278,92 @@ func init() {
 	rootCmd.AddCommand(extractCmd)
 
 	// definition of flags and configuration settings.
-	extractCmd.PersistentFlags().StringVarP(&outputFileName, "out", "o", "top-submitters_YYYY-MM.csv", "Output file name. Using the \".md\" extension will generate a markdown file ")
-	extractCmd.PersistentFlags().StringVarP(&argInputType, "type", "", "submitters", "The type of data being analyzed. Can be either \"submitters\" or \"commenters\"")
+	extractCmd.PersistentFlags().StringVarP(&outputFileName, "out", "o", "top-submitters_YYYY-MM.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	extractCmd.PersistentFlags().StringVarP(&argInputType, "type", "", inputTypeAuto, "The type of data being analyzed: \"submitters\", \"commenters\" or \"auto\" (detected from the input file)")
 	extractCmd.PersistentFlags().IntVarP(&topSize, "topSize", "t", 35, "Number of top submitters to extract.")
 	extractCmd.PersistentFlags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate.")
 	extractCmd.PersistentFlags().StringVarP(&endMonth, "month", "m", "latest", "Month to extract top submitters.")
 	extractCmd.PersistentFlags().BoolVarP(&isOutputHistory, "history", "", false, "Outputs the available activity history for the top submitters")
+	extractCmd.PersistentFlags().IntVarP(&maxReportedProblems, "max-errors", "", 20, "Maximum number of input problems reported (0 for all)")
+
+	extractCmd.PersistentFlags().BoolVarP(&isWithTrend, "trend", "", false, "Adds a column with the trend of the rank compared with the previous month")
+
+	addIntroFileFlag(extractCmd)
+	addAnnotationsFlag(extractCmd)
+	addDecorationFlags(extractCmd)
+	addNotifyFlags(extractCmd)
+	addZScoreFlag(extractCmd)
+	addHistoryFlags(extractCmd)
+	addSpikeFlags(extractCmd)
+	addFormatFlag(extractCmd)
+	addAtomicOutputFlag(extractCmd)
+	addShowDiffFlag(extractCmd)
+	addSampleFlags(extractCmd)
+	addAnniversariesFlags(extractCmd)
+
+	// dynamic completion of the arguments and flags
+	extractCmd.ValidArgsFunction = completeInputFile
+	_ = extractCmd.RegisterFlagCompletionFunc("type", completeInputType)
+	_ = extractCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
 
-	extractCmd.PersistentFlags().BoolVarP(&isVerboseExtract, "verbose", "v", false, "Displays useful info during the extraction")
+// Returns the title of the top users documents
+func getTopTitle(inputType InputType) string {
+	if inputType == InputTypeCommenters {
+		return "Top Commenters"
+	}
+	return "Top Submitters"
+}
+
+// Returns the introduction of the Markdown output of an extraction
+func getExtractIntroduction(inputType InputType, topSize int, period int, real_endDate string) string {
+	introduction := ""
+	if inputType == InputTypeSubmitters {
+		introduction = "# Top Submitters\n"
+		buffer := fmt.Sprintf("\nExtraction of the %d top submitters (non-bot PR creators) \nover the %d months before \"%s\".\n\n", topSize, period, real_endDate)
+		introduction = introduction + buffer
+	}
+	if inputType == InputTypeCommenters {
+		introduction = "# Top Commenters\n"
+		buffer := fmt.Sprintf("\nExtraction of the %d top (non-bot) commenters \nover the %d months before \"%s\".\n\n", topSize, period, real_endDate)
+		introduction = introduction + buffer
+	}
+	return introduction
+}
+
+// Returns the error of a failed extraction, the interruption if the context was cancelled
+func extractionError(ctx context.Context, message string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New(message)
 }
 
 // Extracts the top submitters for a given period and writes it to a file.
 // Offset defines the number of months before the specified endMonth the extraction must be done (needed for the COMPARE command).
-func extractData(inputFilename string, topSize int, endMonth string, period int, offset int, inputType InputType, isVerboseExtract bool) (result bool, real_endDate string, outputSlice [][]string) {
-	if isVerboseExtract {
-		fmt.Printf("Extracting from \"%s\" the %d top submitters during the last %d months\n\n", inputFilename, topSize, period)
-	}
+func extractData(ctx context.Context, inputFilename string, topSize int, endMonth string, period int, offset int, inputType InputType) (result bool, real_endDate string, outputSlice [][]string) {
+	logVerbose("Extracting from \"%s\" the %d top %s during the last %d months\n\n", inputFilename, topSize, inputTypeName(inputType), period)
+	span := startSpan("compute")
+	span.setAttribute("period", period)
+	span.setAttribute("offset", offset)
+	defer func() {
+		if !result {
+			span.finish(fmt.Errorf("Failed to extract data"))
+			return
+		}
+		span.finish(nil)
+	}()
 
-	records, loadErr := loadInputPivotTable(inputFilename)
+	// Only the header is needed to compute the boundaries
+	header, loadErr := loadPivotTableHeaderContext(ctx, inputFilename)
 	if loadErr != nil {
 		return false, "", nil
 	}
 
-	firstDataColumn, lastDataColumn, oldestDate, mostRecentDate := getBoundaries(records, endMonth, period, offset)
+	firstDataColumn, lastDataColumn, oldestDate, mostRecentDate := getBoundaries([][]string{header}, endMonth, period, offset)
+	if lastDataColumn == 0 {
+		return false, "", nil
+	}
 
 	if strings.ToUpper(endMonth) != "LATEST" {
 		if endMonth != mostRecentDate {
@@ -211,9 +375,21 @@ func extractData(inputFilename string, topSize int, endMonth string, period int,
 	//We need to make that information available to caller
 	real_endDate = mostRecentDate
 
-	fmt.Printf("Accumulating data between %s and  %s (columns %d and %d)\n",
+	logInfo("Accumulating data between %s and  %s (columns %d and %d)\n",
 		oldestDate, mostRecentDate, firstDataColumn, lastDataColumn)
 
+	// Load only the columns of the requested period
+	records, loadErr := loadProjectedPivotTableContext(ctx, inputFilename, firstDataColumn, lastDataColumn)
+	if loadErr != nil {
+		return false, "", nil
+	}
+
+	return true, real_endDate, rankTopUsers(records, topSize, inputType)
+}
+
+// Ranks the users of a projected pivot table (name and the columns of the period) and returns
+// the top ones (with the ex-aequo), preceded by the header line.
+func rankTopUsers(records [][]string, topSize int, inputType InputType) [][]string {
 	//Slice that will contain all the totalized records
 	var new_output_slice []totalized_record
 
@@ -226,9 +402,8 @@ func extractData(inputFilename string, topSize int, endMonth string, period int,
 
 		recordTotal := 0
 		for ii, column := range dataLine {
-			if ii >= firstDataColumn && ii <= lastDataColumn {
-				// fmt.Printf(", %s", column)
-
+			// the projected records only contain the name and the columns of the period
+			if ii != 0 {
 				// We don't treat conversion errors or negative values as the file has already been checked
 				columnValue, _ := strconv.Atoi(column)
 				recordTotal = recordTotal + columnValue
@@ -280,12 +455,38 @@ func extractData(inputFilename string, topSize int, endMonth string, period int,
 		}
 	}
 
-	return true, real_endDate, csv_output_slice
+	return csv_output_slice
+}
+
+// Normalizes the header of a pivot table generated by any datamash version: the name of the
+// first column ("GroupBy(...)") is removed and the spaces around the months are trimmed.
+func normalizePivotTableHeader(header []string) {
+	pivottable.NormalizeHeader(header)
 }
 
 // Opens and reads the input as a CSV file
 func loadInputPivotTable(inputFilename string) (loadedRecords [][]string, err error) {
-	//TODO: add some unit tests here ?
+	return loadInputPivotTableContext(context.Background(), inputFilename)
+}
+
+// Loads the input pivot table, the loading being stopped if the context is cancelled (ex: Ctrl-C)
+func loadInputPivotTableContext(ctx context.Context, inputFilename string) (loadedRecords [][]string, err error) {
+	span := startSpan("load")
+	span.setAttribute("file", inputFilename)
+	defer func() {
+		span.setAttribute("rows", len(loadedRecords))
+		span.finish(err)
+	}()
+
+	// The other formats (long CSV, JSON lines, Excel...) are converted by their parser
+	if format := parsedInputFormat(inputFilename); format != "" {
+		if loadedRecords, err = readPivot(inputFilename, format); err != nil {
+			return nil, err
+		}
+		countProcessedRows(len(loadedRecords))
+		return loadedRecords, nil
+	}
+
 	//At this stage of the processing, we assume that the input file is correctly formatted
 	f, err := os.Open(inputFilename)
 	if err != nil {
@@ -294,12 +495,210 @@ func loadInputPivotTable(inputFilename string) (loadedRecords [][]string, err er
 	defer f.Close()
 
 	r := csv.NewReader(f)
-	records, err := r.ReadAll()
+	// The record buffer is reused as we copy the fields
+	r.ReuseRecord = true
+	pool := newStringPool()
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected error loading"+inputFilename+"\n", err)
+		}
+		if len(loadedRecords) == 0 {
+			normalizePivotTableHeader(record)
+		}
+		// Checking every line would be too costly for the large files
+		if len(loadedRecords)%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		loadedRecords = append(loadedRecords, pool.internRecord(record, 0, len(record)-1))
+	}
+	countProcessedRows(len(loadedRecords))
+
+	return pool.finalize(loadedRecords), nil
+}
+
+// Interns the strings of the loaded pivot tables.
+// The counts (mostly "0") and the months are heavily repeated: sharing a single copy
+// of each value drastically reduces the number of allocations and the GC pressure.
+// The names are unique: they are concatenated in a single buffer and the rows are
+// allocated from larger blocks.
+type stringPool struct {
+	values   map[string]string
+	names    []byte   // all the names, concatenated
+	nameEnds []int    // end offset of each name in the buffer
+	block    []string // memory block the rows are allocated from
+}
+
+// Number of rows allocated at once
+const stringPoolBlockRows = 256
+
+func newStringPool() *stringPool {
+	return &stringPool{values: make(map[string]string)}
+}
+
+// Returns the shared copy of the value
+func (pool *stringPool) intern(value string) string {
+	if shared, found := pool.values[value]; found {
+		return shared
+	}
+	// The value may be a slice of a bigger string (the CSV line): we keep a copy
+	shared := strings.Clone(value)
+	pool.values[shared] = shared
+	return shared
+}
+
+// Copies the name column and the columns between firstColumn and lastColumn (included).
+// The name is only set by finalize() once all the records are loaded.
+func (pool *stringPool) internRecord(record []string, firstColumn int, lastColumn int) []string {
+	if firstColumn == 0 {
+		firstColumn = 1
+	}
+	size := lastColumn - firstColumn + 2
+
+	if cap(pool.block)-len(pool.block) < size {
+		pool.block = make([]string, 0, size*stringPoolBlockRows)
+	}
+	start := len(pool.block)
+	pool.block = pool.block[:start+size]
+	row := pool.block[start : start+size : start+size]
+
+	pool.names = append(pool.names, record[0]...)
+	pool.nameEnds = append(pool.nameEnds, len(pool.names))
+
+	for i, field := range record[firstColumn : lastColumn+1] {
+		row[i+1] = pool.intern(field)
+	}
+	return row
+}
+
+// Sets the names of the records (in the order they were interned) from the shared buffer
+func (pool *stringPool) finalize(records [][]string) [][]string {
+	allNames := string(pool.names)
+	start := 0
+	for i, end := range pool.nameEnds {
+		records[i][0] = allNames[start:end]
+		start = end
+	}
+	return records
+}
+
+// Reads the first line (header) of the input pivot table
+func loadPivotTableHeader(inputFilename string) (header []string, err error) {
+	return loadPivotTableHeaderContext(context.Background(), inputFilename)
+}
+
+// Reads the header of the input pivot table, the reading being stopped if the context is cancelled
+func loadPivotTableHeaderContext(ctx context.Context, inputFilename string) (header []string, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if format := parsedInputFormat(inputFilename); format != "" {
+		records, err := readPivot(inputFilename, format)
+		if err != nil {
+			return nil, err
+		}
+		return records[0], nil
+	}
+
+	f, err := os.Open(inputFilename)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read input file %s: %v\n", inputFilename, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err = r.Read()
 	if err != nil {
-		return nil, fmt.Errorf("Unexpected error loading"+inputFilename+"\n", err)
+		return nil, fmt.Errorf("Unexpected error loading %s: %v\n", inputFilename, err)
+	}
+	normalizePivotTableHeader(header)
+	return header, nil
+}
+
+// Reads the input pivot table keeping only the name column and the columns between firstColumn
+// and lastColumn (included). The other columns are never materialized, which keeps the
+// memory footprint low for large multi-year pivot tables.
+// The first column of the period becomes column 1 in the returned records.
+func loadProjectedPivotTable(inputFilename string, firstColumn int, lastColumn int) (loadedRecords [][]string, err error) {
+	return loadProjectedPivotTableContext(context.Background(), inputFilename, firstColumn, lastColumn)
+}
+
+// Loads the columns of the period of the input pivot table, the loading being stopped if the
+// context is cancelled (ex: Ctrl-C)
+func loadProjectedPivotTableContext(ctx context.Context, inputFilename string, firstColumn int, lastColumn int) (loadedRecords [][]string, err error) {
+	if firstColumn < 1 || lastColumn < firstColumn {
+		return nil, fmt.Errorf("Invalid column range (%d to %d)", firstColumn, lastColumn)
+	}
+	span := startSpan("load")
+	span.setAttribute("file", inputFilename)
+	defer func() {
+		span.setAttribute("rows", len(loadedRecords))
+		span.finish(err)
+	}()
+
+	// The other formats are converted by their parser, then projected
+	if format := parsedInputFormat(inputFilename); format != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		records, err := readPivot(inputFilename, format)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if len(record) <= lastColumn {
+				return nil, fmt.Errorf("Line \"%s\" of %s is too short (%d columns)", record[0], inputFilename, len(record))
+			}
+			loadedRecords = append(loadedRecords, append([]string{record[0]}, record[firstColumn:lastColumn+1]...))
+		}
+		countProcessedRows(len(loadedRecords))
+		return loadedRecords, nil
+	}
+
+	f, err := os.Open(inputFilename)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read input file %s: %v\n", inputFilename, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	// The record buffer is reused as we copy the needed fields
+	r.ReuseRecord = true
+	pool := newStringPool()
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected error loading %s: %v\n", inputFilename, err)
+		}
+		if len(record) <= lastColumn {
+			return nil, fmt.Errorf("Line \"%s\" of %s is too short (%d columns)", record[0], inputFilename, len(record))
+		}
+		if len(loadedRecords) == 0 {
+			normalizePivotTableHeader(record)
+		}
+		// Checking every line would be too costly for the large files
+		if len(loadedRecords)%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		// The fields share the memory of the full line: they are copied so that the line can be freed
+		loadedRecords = append(loadedRecords, pool.internRecord(record, firstColumn, lastColumn))
 	}
+	countProcessedRows(len(loadedRecords))
 
-	return records, nil
+	return pool.finalize(loadedRecords), nil
 }
 
 // Based on the number of months requested, computes the start/end column and associated date for the given dataset.
@@ -315,7 +714,7 @@ func getBoundaries(records [][]string, endMonthStr string, period int, offset in
 		endColumn = searchStringMonth(records[0], endMonthStr)
 		//If not found, reset to "latest"
 		if endColumn == -1 {
-			fmt.Printf("Warning: %s not found in dataset, reverting to latest available month\n", endMonthStr)
+			warn("%s not found in dataset, reverting to latest available month", endMonthStr)
 			endColumn = nbrOfColumns - 1
 		}
 	}
@@ -332,7 +731,8 @@ func getBoundaries(records [][]string, endMonthStr string, period int, offset in
 		period = 0
 	}
 
-	if period == 0 {
+	// As for a period longer than the file, the period starts with the first month available
+	if period == 0 || endColumn-period < 0 {
 		startColumn = 1
 	} else {
 		startColumn = (endColumn - period) + 1
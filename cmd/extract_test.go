@@ -23,6 +23,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -106,6 +107,16 @@ func Test_getBoundaries(t *testing.T) {
 			args{records: records_1, endMonthStr: "2023-08", months: 12, offset: 0},
 			5, 16, "2022-05", "2023-04",
 		},
+		{
+			"Specify end month - period starting before the first month",
+			args{records: records_1, endMonthStr: "2022-03", months: 12, offset: 0},
+			1, 3, "2022-01", "2022-03",
+		},
+		{
+			"offset - period starting before the first month",
+			args{records: records_1, endMonthStr: "latest", months: 12, offset: 6},
+			1, 10, "2022-01", "2022-10",
+		},
 		{
 			"short month set",
 			args{records: records_2, endMonthStr: "latest", months: 12, offset: 0},
@@ -153,13 +164,12 @@ func Test_getBoundaries(t *testing.T) {
 
 func Test_extractData(t *testing.T) {
 	type args struct {
-		inputFilename    string
-		topSize          int
-		endMonth         string
-		period           int
-		offset           int
-		inputType        InputType
-		isVerboseExtract bool
+		inputFilename string
+		topSize       int
+		endMonth      string
+		period        int
+		offset        int
+		inputType     InputType
 	}
 	tests := []struct {
 		name             string
@@ -171,19 +181,18 @@ func Test_extractData(t *testing.T) {
 		{
 			"Happy case",
 			args{
-				inputFilename:    "../test_data/short_overview.csv",
-				topSize:          7,
-				endMonth:         "latest",
-				period:           12,
-				inputType:        InputTypeSubmitters,
-				isVerboseExtract: false,
+				inputFilename: "../test_data/short_overview.csv",
+				topSize:       7,
+				endMonth:      "latest",
+				period:        12,
+				inputType:     InputTypeSubmitters,
 			},
 			true, "2023-04", resultSlice_1,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotResult, gotReal_endDate, gotOutputSlice := extractData(tt.args.inputFilename, tt.args.topSize, tt.args.endMonth, tt.args.period, tt.args.offset, tt.args.inputType, tt.args.isVerboseExtract)
+			gotResult, gotReal_endDate, gotOutputSlice := extractData(context.Background(), tt.args.inputFilename, tt.args.topSize, tt.args.endMonth, tt.args.period, tt.args.offset, tt.args.inputType)
 			if gotResult != tt.wantResult {
 				t.Errorf("extractData() gotResult = %v, want %v", gotResult, tt.wantResult)
 			}
@@ -210,7 +219,7 @@ func Test_ExecuteSubmittersExtractToMarkdown_integrationTest(t *testing.T) {
 	actual := new(bytes.Buffer)
 	rootCmd.SetOut(actual)
 	rootCmd.SetErr(actual)
-	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "--month=latest", "--period=12", "--topSize=35", "--type=submitters", "--history" ,"--out=" + testOutputFilename})
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "--month=latest", "--period=12", "--topSize=35", "--type=submitters", "--history", "--out=" + testOutputFilename})
 
 	// Execute the module under test
 	error := rootCmd.Execute()
@@ -285,8 +294,6 @@ func Test_ExecuteExtractWithUnknownInputType_mustFail(t *testing.T) {
 	assert.Equal(t, expectedMsg, lines[0], "Function did not fail for the expected cause")
 }
 
-
-
 func Test_ExecuteExtractWithInvalidOutputDir_mustFail(t *testing.T) {
 	// setup the command line
 	actual := new(bytes.Buffer)
@@ -360,3 +367,108 @@ func Test_ExecuteExtractWithInvalidEndMonth_mustFail(t *testing.T) {
 }
 
 //TODO: integration test for CSV output
+
+func Test_loadProjectedPivotTable(t *testing.T) {
+	type args struct {
+		inputFilename string
+		firstColumn   int
+		lastColumn    int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			"Happy case",
+			args{inputFilename: "../test_data/deleted_user_case.csv", firstColumn: 5, lastColumn: 7},
+			[][]string{
+				{"", "2020-05", "2020-06", "2020-07"},
+				{"0x41head", "0", "0", "0"},
+				{"deleted_user", "0", "0", "0"},
+				{"95-jonpet", "3", "0", "1"},
+				{"ADI10HERO", "0", "0", "0"},
+				{"ADITYADAS1999", "0", "0", "0"},
+				{"APEdevelopment", "0", "0", "0"},
+			},
+			false,
+		},
+		{
+			"Column out of range",
+			args{inputFilename: "../test_data/deleted_user_case.csv", firstColumn: 2, lastColumn: 99},
+			nil,
+			true,
+		},
+		{
+			"Invalid range",
+			args{inputFilename: "../test_data/deleted_user_case.csv", firstColumn: 0, lastColumn: 0},
+			nil,
+			true,
+		},
+		{
+			"File not found",
+			args{inputFilename: "../test_data/blaah.csv", firstColumn: 1, lastColumn: 2},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadProjectedPivotTable(tt.args.inputFilename, tt.args.firstColumn, tt.args.lastColumn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadProjectedPivotTable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProjectedPivotTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractData_cancelled(t *testing.T) {
+	// The loading stops when the context is cancelled (ex: Ctrl-C)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := loadPivotTableHeaderContext(cancelledCtx, "../test_data/overview.csv")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = loadProjectedPivotTableContext(cancelledCtx, "../test_data/overview.csv", 29, 40)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	result, _, _ := extractData(cancelledCtx, "../test_data/overview.csv", 10, "latest", 12, 0, InputTypeSubmitters)
+	assert.False(t, result)
+	assert.ErrorIs(t, extractionError(cancelledCtx, "Failed to extract data"), context.Canceled)
+	assert.EqualError(t, extractionError(context.Background(), "Failed to extract data"), "Failed to extract data")
+}
+
+func Test_stringPool(t *testing.T) {
+	pool := newStringPool()
+
+	var records [][]string
+	records = append(records, pool.internRecord([]string{"", "2023-01", "2023-02"}, 0, 2))
+	records = append(records, pool.internRecord([]string{"alpha", "0", "12"}, 0, 2))
+	records = append(records, pool.internRecord([]string{"bravo", "12", "0"}, 1, 1))
+	records = pool.finalize(records)
+
+	assert.Equal(t, [][]string{{"", "2023-01", "2023-02"}, {"alpha", "0", "12"}, {"bravo", "12"}}, records)
+	assert.Len(t, pool.values, 4, "Repeated values should be shared")
+
+	// Rows can't overwrite their neighbors
+	records[1] = append(records[1], "junk")
+	assert.Equal(t, []string{"bravo", "12"}, records[2])
+}
+
+func Test_loadInputPivotTable(t *testing.T) {
+	records, err := loadInputPivotTable("../test_data/deleted_user_case.csv")
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 7)
+	assert.Equal(t, "deleted_user", records[2][0])
+	assert.Equal(t, "2020-01", records[0][1])
+	assert.Equal(t, "3", records[3][5])
+
+	_, err = loadInputPivotTable("../test_data/blaah.csv")
+	assert.Error(t, err)
+}
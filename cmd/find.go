@@ -0,0 +1,179 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var findMaxResults int
+var isFindAllMonths bool
+
+// Quality of a match (the lower the better)
+const (
+	matchExact = iota
+	matchPrefix
+	matchSubstring
+	matchFuzzy
+)
+
+// A submitter matching the search pattern
+type submitterMatch struct {
+	Index int // index of the user in the dataset
+	User  string
+	Score int // one of the matchXXX values
+}
+
+// findCmd represents the find command
+var findCmd = &cobra.Command{
+	Use:   "find [input file] [pattern]",
+	Short: "Searches submitters matching a (partial) name and prints their history",
+	Long: `The FIND command searches the submitters whose name matches the given pattern
+and prints their monthly history.
+
+The search is case insensitive. Exact matches are listed first, followed by names
+starting with the pattern, names containing it and finally "fuzzy" matches (names
+containing the characters of the pattern in the same order, like "mwt" for "MarkEWaite").
+
+By default only the months with activity are printed (see "--all"). With "--all",
+the months without data are printed as "-".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		matches := findSubmitters(dataset, args[1])
+		if len(matches) == 0 {
+			return fmt.Errorf("No submitter matching \"%s\" found", args[1])
+		}
+		if findMaxResults > 0 && len(matches) > findMaxResults {
+			fmt.Fprintf(cmd.OutOrStdout(), "%d submitters found, showing the first %d\n\n", len(matches), findMaxResults)
+			matches = matches[:findMaxResults]
+		}
+
+		for _, match := range matches {
+			printSubmitterHistory(cmd.OutOrStdout(), dataset, match.Index, isFindAllMonths)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(findCmd)
+
+	findCmd.Flags().IntVarP(&findMaxResults, "max", "", 10, "Maximum number of submitters to display (0 for all)")
+	findCmd.Flags().BoolVarP(&isFindAllMonths, "all", "a", false, "Prints all the months, including those without activity")
+	findCmd.ValidArgsFunction = completeInputFile
+}
+
+// Returns the submitters matching the pattern, best matches first
+func findSubmitters(dataset *Dataset, pattern string) []submitterMatch {
+	var matches []submitterMatch
+
+	for i, user := range dataset.Users {
+		if score, isMatch := matchSubmitterName(user, pattern); isMatch {
+			matches = append(matches, submitterMatch{Index: i, User: user, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score < matches[j].Score
+		}
+		return strings.ToLower(matches[i].User) < strings.ToLower(matches[j].User)
+	})
+	return matches
+}
+
+// Checks (case insensitive) whether the name matches the pattern and how well
+func matchSubmitterName(name string, pattern string) (score int, isMatch bool) {
+	lowerName := strings.ToLower(name)
+	lowerPattern := strings.ToLower(pattern)
+
+	switch {
+	case lowerPattern == "":
+		return 0, false
+	case lowerName == lowerPattern:
+		return matchExact, true
+	case strings.HasPrefix(lowerName, lowerPattern):
+		return matchPrefix, true
+	case strings.Contains(lowerName, lowerPattern):
+		return matchSubstring, true
+	}
+
+	// Fuzzy: all the pattern's characters must appear in the name, in the same order
+	patternRunes := []rune(lowerPattern)
+	position := 0
+	for _, r := range lowerName {
+		if r == patternRunes[position] {
+			position++
+			if position == len(patternRunes) {
+				return matchFuzzy, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Prints the monthly activity of a submitter, followed by the total.
+// The months without data are printed as "-" (with "--all" only).
+func printSubmitterHistory(out io.Writer, dataset *Dataset, user int, isAllMonths bool) {
+	fmt.Fprintf(out, "%s\n", dataset.Users[user])
+
+	total := 0
+	for month, value := range dataset.Counts[user] {
+		if dataset.isMissing(user, month) {
+			if isAllMonths {
+				fmt.Fprintf(out, "  %s: %5s\n", dataset.Months[month], "-")
+			}
+			continue
+		}
+		total = total + value
+		if value != 0 || isAllMonths {
+			fmt.Fprintf(out, "  %s: %5d\n", dataset.Months[month], value)
+		}
+	}
+	fmt.Fprintf(out, "  Total:   %5d\n\n", total)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchSubmitterName(t *testing.T) {
+	type args struct {
+		name    string
+		pattern string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantScore   int
+		wantIsMatch bool
+	}{
+		{"exact", args{"MarkEWaite", "markewaite"}, matchExact, true},
+		{"prefix", args{"MarkEWaite", "mark"}, matchPrefix, true},
+		{"substring", args{"MarkEWaite", "wait"}, matchSubstring, true},
+		{"fuzzy", args{"MarkEWaite", "mwt"}, matchFuzzy, true},
+		{"wrong order", args{"MarkEWaite", "twm"}, 0, false},
+		{"no match", args{"MarkEWaite", "basil"}, 0, false},
+		{"empty pattern", args{"MarkEWaite", ""}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotScore, gotIsMatch := matchSubmitterName(tt.args.name, tt.args.pattern)
+			if gotScore != tt.wantScore || gotIsMatch != tt.wantIsMatch {
+				t.Errorf("matchSubmitterName() = %v, %v, want %v, %v", gotScore, gotIsMatch, tt.wantScore, tt.wantIsMatch)
+			}
+		})
+	}
+}
+
+func Test_findSubmitters(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01"},
+		{"alphabet", "1"},
+		{"bravo-alpha", "1"},
+		{"alpha", "1"},
+		{"a-l-p-h-a", "1"},
+		{"zulu", "1"},
+	}
+	dataset, err := newDataset("test", records)
+	assert.NoError(t, err)
+
+	got := findSubmitters(dataset, "alpha")
+
+	want := []submitterMatch{
+		{Index: 2, User: "alpha", Score: matchExact},
+		{Index: 0, User: "alphabet", Score: matchPrefix},
+		{Index: 1, User: "bravo-alpha", Score: matchSubstring},
+		{Index: 3, User: "a-l-p-h-a", Score: matchFuzzy},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findSubmitters() = %v, want %v", got, want)
+	}
+}
+
+func Test_printSubmitterHistory(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "2", "0", "5", ""},
+	})
+	assert.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	printSubmitterHistory(out, dataset, 0, false)
+
+	expected := "alpha\n  2023-01:     2\n  2023-03:     5\n  Total:       7\n\n"
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	printSubmitterHistory(out, dataset, 0, true)
+
+	expected = "alpha\n  2023-01:     2\n  2023-02:     0\n  2023-03:     5\n  2023-04:     -\n  Total:       7\n\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_ExecuteFindWithUnknownSubmitter_mustFail(t *testing.T) {
+	// setup the command line
+	actual := new(bytes.Buffer)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"find", "../test_data/overview.csv", "zzzzzzzzzzzzzz"})
+
+	// Execute the module under test
+	error := rootCmd.Execute()
+
+	assert.Error(t, error, "Function call should have failed")
+
+	//Error is expected
+	expectedMsg := "Error: No submitter matching \"zzzzzzzzzzzzzz\" found"
+	lines := strings.Split(actual.String(), "\n")
+	assert.Equal(t, expectedMsg, lines[0], "Function did not fail for the expected cause")
+}
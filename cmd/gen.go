@@ -0,0 +1,146 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var genOutputFileName string
+
+// genCmd represents the gen command
+var genCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "Generates the man pages and the shell completion scripts",
+	Long: `The GEN command generates the artifacts needed by packagers (Homebrew, Scoop, etc.).
+
+"gen man [directory]" writes the man pages of all the commands in the given directory.
+"gen completion [bash|zsh|fish|powershell]" writes the completion script for the given shell.`,
+}
+
+// genManCmd represents the "gen man" command
+var genManCmd = &cobra.Command{
+	Use:   "man [output directory]",
+	Short: "Generates the man pages in the given directory",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateManPages(args[0])
+	},
+}
+
+// genCompletionCmd represents the "gen completion" command
+var genCompletionCmd = &cobra.Command{
+	Use:       "completion [bash|zsh|fish|powershell]",
+	Short:     "Generates the completion script for the given shell",
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if genOutputFileName != "" {
+			f, err := os.Create(genOutputFileName)
+			if err != nil {
+				return fmt.Errorf("Unable to create completion file: %v", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		return generateCompletion(out, args[0])
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(genCmd)
+	genCmd.AddCommand(genManCmd)
+	genCmd.AddCommand(genCompletionCmd)
+
+	genCompletionCmd.Flags().StringVarP(&genOutputFileName, "out", "o", "", "Output file name (default is the standard output)")
+}
+
+// Writes the man pages of the complete command tree in the given directory
+func generateManPages(outputDir string) error {
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("Failed to create the man page directory: %v", err)
+	}
+
+	header := &doc.GenManHeader{
+		Title:   "JENKINS-CONTRIBUTION-AGGREGATOR",
+		Section: "1",
+		Source:  "jenkins-contribution-aggregator " + version,
+	}
+	// Avoids the generation date making the pages differ at each build
+	rootCmd.DisableAutoGenTag = true
+
+	return doc.GenManTree(rootCmd, header, outputDir)
+}
+
+// Writes the completion script for the requested shell
+func generateCompletion(out io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(out, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(out)
+	case "fish":
+		return rootCmd.GenFishCompletion(out, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("%s is not a supported shell", shell)
+	}
+}
+
+// Completes the input file argument with the CSV files
+func completeInputFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{"csv"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// Completes the "--type" flag with the supported input types
+func completeInputType(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"submitters", "commenters", inputTypeAuto}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// Completes the "--month" flag with the months available in the input file (most recent first)
+func completeMonth(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	months := []string{"latest"}
+	if len(args) == 0 {
+		return months, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	records, err := loadInputPivotTable(args[0])
+	if err != nil || len(records) == 0 {
+		return months, cobra.ShellCompDirectiveNoFileComp
+	}
+	header := records[0]
+	for i := len(header) - 1; i > 0; i-- {
+		months = append(months, header[i])
+	}
+	return months, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_generateManPages(t *testing.T) {
+	tempDir := t.TempDir()
+
+	err := generateManPages(tempDir)
+
+	assert.NoError(t, err, "Function should not have failed")
+	assert.FileExists(t, filepath.Join(tempDir, "jenkins-contribution-aggregator.1"))
+	assert.FileExists(t, filepath.Join(tempDir, "jenkins-contribution-aggregator-extract.1"))
+	assert.FileExists(t, filepath.Join(tempDir, "jenkins-contribution-aggregator-compare.1"))
+}
+
+func Test_generateCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := generateCompletion(out, shell)
+			assert.NoError(t, err, "Function should not have failed")
+			assert.Contains(t, out.String(), "jenkins-contribution-aggregator")
+		})
+	}
+
+	err := generateCompletion(new(bytes.Buffer), "tcsh")
+	assert.Error(t, err, "Unsupported shell should fail")
+}
+
+func Test_completeMonth(t *testing.T) {
+	got, directive := completeMonth(extractCmd, []string{"../test_data/short_overview.csv"}, "")
+
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, "latest", got[0])
+	assert.Equal(t, "2023-04", got[1])
+	assert.Equal(t, "2020-01", got[len(got)-1])
+
+	got, _ = completeMonth(extractCmd, []string{}, "")
+	assert.Equal(t, []string{"latest"}, got)
+}
+
+func Test_ExecuteGenCompletionWithInvalidShell_mustFail(t *testing.T) {
+	// setup the command line
+	actual := new(bytes.Buffer)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"gen", "completion", "tcsh"})
+
+	// Execute the module under test
+	error := rootCmd.Execute()
+
+	assert.Error(t, error, "Function call should have failed")
+
+	//Error is expected
+	lines := strings.Split(actual.String(), "\n")
+	assert.Equal(t, "Error: invalid argument \"tcsh\" for \"jenkins-contribution-aggregator gen completion\"", lines[0], "Function did not fail for the expected cause")
+}
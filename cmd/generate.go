@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var generateSubmitters int
+var generateMonths int
+var generateSkew float64
+var generateMaxMean float64
+var generateEndMonth string
+var generateSeed int64
+var generateOutputFileName string
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generates a large synthetic pivot table",
+	Long: `The GENERATE command creates a synthetic pivot table with the layout of the ones
+generated by datamash. The usernames are made up, so that the generated files can be
+shared as anonymized demo datasets. Large files can be used to exercise the
+performance of the tool (see the PERF command).
+
+The activity of the n-th most active submitter averages "max-mean / n^skew" per month:
+a skew of 0 gives the same activity to everybody, the higher the skew the more the
+activity is concentrated on the top submitters. The same "--seed" generates the same table.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.NoArgs(cmd, args); err != nil {
+			return err
+		}
+		if !isValidMonth(generateEndMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", generateEndMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endMonth := time.Now().AddDate(0, -1, 0)
+		if strings.ToUpper(generateEndMonth) != "LATEST" {
+			endMonth, _ = time.Parse("2006-01", generateEndMonth)
+		}
+
+		spec := syntheticPivotSpec{
+			Submitters: generateSubmitters,
+			Months:     generateMonths,
+			EndMonth:   endMonth,
+			Skew:       generateSkew,
+			MaxMean:    generateMaxMean,
+			Seed:       generateSeed,
+		}
+
+		if dirErr := CheckDir(generateOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		out, err := os.Create(generateOutputFileName)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		if err := writeSyntheticPivot(out, spec); err != nil {
+			return err
+		}
+		logInfo("Generated %d submitters over %d months in \"%s\"\n", generateSubmitters, generateMonths, generateOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(generateCmd)
+
+	generateCmd.Flags().IntVarP(&generateSubmitters, "submitters", "s", 1000, "Number of submitters")
+	generateCmd.Flags().IntVarP(&generateMonths, "months", "", 36, "Number of months")
+	generateCmd.Flags().Float64VarP(&generateSkew, "skew", "", 1.0, "Concentration of the activity on the top submitters (0 for uniform)")
+	generateCmd.Flags().Float64VarP(&generateMaxMean, "max-mean", "", 20, "Average monthly count of the most active submitter")
+	generateCmd.Flags().StringVarP(&generateEndMonth, "month", "m", "latest", "Most recent month of the table (\"latest\" is last month)")
+	generateCmd.Flags().Int64VarP(&generateSeed, "seed", "", 1, "Seed of the random generator")
+	generateCmd.Flags().StringVarP(&generateOutputFileName, "out", "o", "synthetic_pivot.csv", "Output file name")
+}
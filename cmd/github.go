@@ -0,0 +1,155 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Base URL of the GitHub REST API (changed by the tests)
+var githubAPIURL = "https://api.github.com"
+
+var githubToken string
+
+// Returned when the requested object (ex: user) does not exist (anymore)
+var errGitHubNotFound = errors.New("Not found on GitHub")
+
+// Subset of the GitHub user information we use
+type githubUser struct {
+	Login    string `json:"login"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+// Minimal GitHub REST API client
+type githubClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// Creates a client using the given token (anonymous if empty)
+func newGitHubClient(token string) *githubClient {
+	return &githubClient{
+		baseURL:    strings.TrimSuffix(githubAPIURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Adds the authentication flags ("--github-token" or the GitHub App ones) to a command using the GitHub API
+func addGitHubTokenFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&githubToken, "github-token", "", "", "GitHub token (default is the GITHUB_TOKEN environment variable)")
+	addGitHubAppFlags(cmd)
+}
+
+// Creates a client authenticated as the GitHub App installation if configured, or else with the token
+func newAuthenticatedGitHubClient(ctx context.Context) (*githubClient, error) {
+	app, err := getGitHubAppConfig()
+	if err != nil {
+		return nil, err
+	}
+	if app == nil {
+		return newGitHubClient(getGitHubToken()), nil
+	}
+	token, err := fetchGitHubAppInstallationToken(ctx, *app, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return newGitHubClient(token), nil
+}
+
+// Returns the token given on the command line or in the environment
+func getGitHubToken() string {
+	if githubToken != "" {
+		return githubToken
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// Retrieves the information of a GitHub user. The redirections of the renamed
+// accounts are followed, so the returned login can differ from the requested one.
+func (c *githubClient) getUser(ctx context.Context, login string) (*githubUser, error) {
+	var user githubUser
+	if err := c.get(ctx, "/users/"+url.PathEscape(login), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Performs a GET on the API and decodes the JSON answer
+func (c *githubClient) get(ctx context.Context, path string, result any) error {
+	return c.call(ctx, http.MethodGet, path, result)
+}
+
+// Calls the API and decodes the JSON answer
+func (c *githubClient) call(ctx context.Context, method string, path string, result any) error {
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return json.NewDecoder(response.Body).Decode(result)
+	case http.StatusNotFound:
+		return errGitHubNotFound
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if response.Header.Get("X-RateLimit-Remaining") == "0" {
+			return fmt.Errorf("GitHub API rate limit exceeded (resets at %s)", formatRateLimitReset(response.Header.Get("X-RateLimit-Reset")))
+		}
+		return fmt.Errorf("GitHub API access denied for %s (%s)", path, response.Status)
+	default:
+		return fmt.Errorf("Unexpected GitHub API answer for %s (%s)", path, response.Status)
+	}
+}
+
+// Formats the rate limit reset time (epoch seconds) given by GitHub
+func formatRateLimitReset(reset string) string {
+	var epoch int64
+	if _, err := fmt.Sscan(reset, &epoch); err != nil {
+		return "unknown time"
+	}
+	return time.Unix(epoch, 0).Format(time.RFC3339)
+}
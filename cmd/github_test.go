@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Starts a fake GitHub API knowing the given users (indexed by lower case login).
+// The API is used by the GitHub client until the end of the test.
+func startFakeGitHub(t *testing.T, users map[string]githubUser) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rate-limited" {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		login := strings.TrimPrefix(r.URL.Path, "/users/")
+		user, isKnown := users[strings.ToLower(login)]
+		if !isKnown {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"login":%q,"id":%d,"name":%q,"location":%q}`, user.Login, user.ID, user.Name, user.Location)
+	}))
+	previousURL := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() {
+		githubAPIURL = previousURL
+		server.Close()
+	})
+	return server
+}
+
+func Test_githubClient_getUser(t *testing.T) {
+	startFakeGitHub(t, map[string]githubUser{
+		"markewaite": {Login: "MarkEWaite", ID: 1, Name: "Mark Waite", Location: "Colorado"},
+	})
+	client := newGitHubClient("token")
+
+	user, err := client.getUser(context.Background(), "markewaite")
+	assert.NoError(t, err)
+	assert.Equal(t, &githubUser{Login: "MarkEWaite", ID: 1, Name: "Mark Waite", Location: "Colorado"}, user)
+
+	_, err = client.getUser(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, errGitHubNotFound))
+
+	err = client.get(context.Background(), "/rate-limited", &user)
+	assert.ErrorContains(t, err, "rate limit exceeded")
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.getUser(cancelledCtx, "markewaite")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func Test_getGitHubToken(t *testing.T) {
+	defer func() { githubToken = "" }()
+	t.Setenv("GITHUB_TOKEN", "from-env")
+
+	assert.Equal(t, "from-env", getGitHubToken())
+	githubToken = "from-flag"
+	assert.Equal(t, "from-flag", getGitHubToken())
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var githubAppID string
+var githubAppKeyFileName string
+var githubAppInstallationID string
+var githubAppOwner string
+
+// The GitHub App used to authenticate the API calls (instead of a personal token)
+type githubAppConfig struct {
+	ID             string
+	PrivateKey     []byte
+	InstallationID string // looked up from the owner, or the only installation, if empty
+	Owner          string // organization or user where the App is installed
+}
+
+// Adds the flags to authenticate as a GitHub App
+func addGitHubAppFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&githubAppID, "github-app-id", "", "", "ID of the GitHub App to authenticate with (default is the GITHUB_APP_ID environment variable)")
+	cmd.Flags().StringVarP(&githubAppKeyFileName, "github-app-key", "", "", "Private key file (PEM) of the GitHub App (default is the GITHUB_APP_PRIVATE_KEY_FILE environment variable)")
+	cmd.Flags().StringVarP(&githubAppInstallationID, "github-app-installation", "", "", "Installation ID of the GitHub App (default is the GITHUB_APP_INSTALLATION_ID environment variable)")
+	cmd.Flags().StringVarP(&githubAppOwner, "github-app-owner", "", "", "Organization or user where the GitHub App is installed (used to find the installation)")
+}
+
+// Returns the value of the flag or else of the environment variable
+func flagOrEnv(value string, variable string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(variable)
+}
+
+// Returns the GitHub App configuration, or nil if no App is configured
+func getGitHubAppConfig() (*githubAppConfig, error) {
+	appID := flagOrEnv(githubAppID, "GITHUB_APP_ID")
+	if appID == "" {
+		return nil, nil
+	}
+	app := &githubAppConfig{
+		ID:             appID,
+		InstallationID: flagOrEnv(githubAppInstallationID, "GITHUB_APP_INSTALLATION_ID"),
+		Owner:          githubAppOwner,
+	}
+
+	// The key can also be given directly in GITHUB_APP_PRIVATE_KEY (ex: a CI secret)
+	keyFileName := flagOrEnv(githubAppKeyFileName, "GITHUB_APP_PRIVATE_KEY_FILE")
+	switch {
+	case keyFileName != "":
+		key, err := os.ReadFile(keyFileName)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the GitHub App private key: %v", err)
+		}
+		app.PrivateKey = key
+	case os.Getenv("GITHUB_APP_PRIVATE_KEY") != "":
+		app.PrivateKey = []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	default:
+		return nil, fmt.Errorf("The private key of the GitHub App %s is missing (see \"--github-app-key\")", appID)
+	}
+	return app, nil
+}
+
+// Returns the JSON Web Token authenticating as the App itself (valid 10 minutes)
+func signGitHubAppJWT(app githubAppConfig, now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey(app.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return signJWT(map[string]any{
+		// Issued a bit in the past to allow for clock drift, as advised by GitHub
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": app.ID,
+	}, key)
+}
+
+// Returns a short-lived installation access token of the GitHub App
+func fetchGitHubAppInstallationToken(ctx context.Context, app githubAppConfig, now time.Time) (string, error) {
+	jwt, err := signGitHubAppJWT(app, now)
+	if err != nil {
+		return "", err
+	}
+	appClient := newGitHubClient(jwt)
+
+	installationID := app.InstallationID
+	if installationID == "" {
+		if installationID, err = findGitHubAppInstallation(ctx, appClient, app.Owner); err != nil {
+			return "", err
+		}
+	}
+
+	var token struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := appClient.call(ctx, http.MethodPost, "/app/installations/"+url.PathEscape(installationID)+"/access_tokens", &token); err != nil {
+		return "", fmt.Errorf("Unable to get an access token for the installation %s of the GitHub App %s: %v", installationID, app.ID, err)
+	}
+	logVerbose("Authenticated as the installation %s of the GitHub App %s (until %s)\n", installationID, app.ID, token.ExpiresAt.Format(time.RFC3339))
+	return token.Token, nil
+}
+
+// Finds the installation of the App on the owner (organization or user), or its only installation
+func findGitHubAppInstallation(ctx context.Context, appClient *githubClient, owner string) (string, error) {
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if owner != "" {
+		err := appClient.get(ctx, "/orgs/"+url.PathEscape(owner)+"/installation", &installation)
+		if err == errGitHubNotFound {
+			err = appClient.get(ctx, "/users/"+url.PathEscape(owner)+"/installation", &installation)
+		}
+		if err != nil {
+			return "", fmt.Errorf("GitHub App not installed on \"%s\": %v", owner, err)
+		}
+		return strconv.FormatInt(installation.ID, 10), nil
+	}
+
+	var installations []struct {
+		ID int64 `json:"id"`
+	}
+	if err := appClient.get(ctx, "/app/installations", &installations); err != nil {
+		return "", fmt.Errorf("Unable to list the installations of the GitHub App: %v", err)
+	}
+	if len(installations) != 1 {
+		return "", fmt.Errorf("The GitHub App has %d installations, select one with \"--github-app-installation\" or \"--github-app-owner\"", len(installations))
+	}
+	return strconv.FormatInt(installations[0].ID, 10), nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Starts a fake GitHub API issuing installation tokens to the App
+func startFakeGitHubApp(t *testing.T, installations ...int64) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		if len(parts) != 3 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]any
+		json.Unmarshal(payload, &claims)
+		if claims["iss"] != "1234" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/app/installations":
+			ids := []string{}
+			for _, id := range installations {
+				ids = append(ids, fmt.Sprintf(`{"id":%d}`, id))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(ids, ","))
+		case r.Method == http.MethodGet && r.URL.Path == "/orgs/jenkinsci/installation":
+			fmt.Fprint(w, `{"id":42}`)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/app/installations/"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/app/installations/"), "/access_tokens")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_installation_%s","expires_at":"2026-01-01T01:00:00Z"}`, id)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	previousURL := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() {
+		githubAPIURL = previousURL
+		server.Close()
+	})
+}
+
+// Returns a new PEM encoded private key
+func newTestGitHubAppKey(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func Test_fetchGitHubAppInstallationToken(t *testing.T) {
+	startFakeGitHubApp(t, 7)
+	key := newTestGitHubAppKey(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	token, err := fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "1234", PrivateKey: key, InstallationID: "99"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "ghs_installation_99", token)
+
+	token, err = fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "1234", PrivateKey: key}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "ghs_installation_7", token)
+
+	token, err = fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "1234", PrivateKey: key, Owner: "jenkinsci"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "ghs_installation_42", token)
+
+	_, err = fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "5678", PrivateKey: key, InstallationID: "99"}, now)
+	assert.Error(t, err)
+
+	_, err = fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "1234", PrivateKey: []byte("not a key")}, now)
+	assert.Error(t, err)
+}
+
+func Test_fetchGitHubAppInstallationToken_severalInstallations(t *testing.T) {
+	startFakeGitHubApp(t, 7, 8)
+
+	_, err := fetchGitHubAppInstallationToken(context.Background(), githubAppConfig{ID: "1234", PrivateKey: newTestGitHubAppKey(t)}, time.Now())
+	assert.ErrorContains(t, err, "2 installations")
+}
+
+func Test_signGitHubAppJWT(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	jwt, err := signGitHubAppJWT(githubAppConfig{ID: "1234", PrivateKey: newTestGitHubAppKey(t)}, now)
+	assert.NoError(t, err)
+	payload, _ := base64.RawURLEncoding.DecodeString(strings.Split(jwt, ".")[1])
+	assert.JSONEq(t, fmt.Sprintf(`{"iss":"1234","iat":%d,"exp":%d}`, now.Unix()-60, now.Unix()+540), string(payload))
+}
+
+func Test_getGitHubAppConfig(t *testing.T) {
+	t.Setenv("GITHUB_APP_ID", "")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY", "")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY_FILE", "")
+	t.Setenv("GITHUB_APP_INSTALLATION_ID", "")
+
+	app, err := getGitHubAppConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, app, "No App configured")
+
+	t.Setenv("GITHUB_APP_ID", "1234")
+	_, err = getGitHubAppConfig()
+	assert.ErrorContains(t, err, "private key")
+
+	t.Setenv("GITHUB_APP_PRIVATE_KEY", "the key")
+	t.Setenv("GITHUB_APP_INSTALLATION_ID", "99")
+	app, err = getGitHubAppConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, &githubAppConfig{ID: "1234", PrivateKey: []byte("the key"), InstallationID: "99"}, app)
+}
+
+func Test_newAuthenticatedGitHubClient_githubApp(t *testing.T) {
+	startFakeGitHubApp(t, 7)
+	t.Setenv("GITHUB_APP_ID", "")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY", "")
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	assert.NoError(t, os.WriteFile(keyFile, newTestGitHubAppKey(t), 0600))
+	defer func() {
+		regionsCmd.Flags().Set("github-app-id", "")
+		regionsCmd.Flags().Set("github-app-key", "")
+	}()
+	regionsCmd.Flags().Set("github-app-id", "1234")
+	regionsCmd.Flags().Set("github-app-key", keyFile)
+
+	client, err := newAuthenticatedGitHubClient(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ghs_installation_7", client.token)
+}
@@ -22,10 +22,14 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"path"
+	"io"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
@@ -33,22 +37,54 @@ import (
 	"gonum.org/v1/plot/vg"
 )
 
+// Maximum number of charts rendered at the same time
+var maxPlotWorkers = runtime.NumCPU()
+
+// A chart to render
+type plotJob struct {
+	name   string
+	values []string
+}
+
+// Renders the chart of each line of the history in the plot directory.
+// The charts are rendered concurrently by a bounded pool of workers. All the errors are
+// collected and returned together.
 func plotAllHistoryFiles(plotDirectory string, historicDataSlice [][]string, dataType InputType) error {
+	if len(historicDataSlice) == 0 {
+		return nil
+	}
+	header := historicDataSlice[0][1:]
 
-	var header []string
+	jobs := make(chan plotJob)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
 
-	for row, historyRow := range historicDataSlice {
-		if row == 0 {
-			header = historyRow[1:]
-		} else {
-			err := plot_bargraph(plotDirectory, historyRow[0], dataType, header, historyRow[1:])
-			if err != nil {
-				return err
+	nbrOfWorkers := maxPlotWorkers
+	if nbrOfWorkers < 1 {
+		nbrOfWorkers = 1
+	}
+	for i := 0; i < nbrOfWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := plot_bargraph(plotDirectory, job.name, dataType, header, job.values); err != nil {
+					mutex.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", job.name, err))
+					mutex.Unlock()
+				}
 			}
-		}
+		}()
 	}
 
-	return nil
+	for _, historyRow := range historicDataSlice[1:] {
+		jobs <- plotJob{name: historyRow[0], values: historyRow[1:]}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errors.Join(errs...)
 }
 
 // TODO: add type for legends
@@ -63,12 +99,12 @@ func plot_bargraph(plotDirectory string, name string, dataType InputType, xLabel
 	name_element := strings.Split(name, " ")
 	cleanedName := name_element[0]
 
-	plotFileName := path.Join(plotDirectory, cleanedName+".png")
+	plotFileName := filepath.Join(plotDirectory, cleanedName+".png")
 
 	if dataType == InputTypeCommenters {
 		p.Title.Text = "Comments by " + cleanedName
 	} else {
-	p.Title.Text = "Submissions by " + cleanedName
+		p.Title.Text = "Submissions by " + cleanedName
 	}
 	p.Y.Label.Text = "Count"
 
@@ -116,10 +152,15 @@ func simplifyAxisLabels(inputLabels []string) []string {
 }
 
 // Converts a slice of numerical strings into a slice of floats.
+// An empty value (a month without data) is converted to 0, so that it is plotted as a gap.
 func convertValuesToInts(stringValues []string) ([]float64, error) {
 	var floatValues []float64
 
 	for _, stringValue := range stringValues {
+		if strings.TrimSpace(stringValue) == "" {
+			floatValues = append(floatValues, 0)
+			continue
+		}
 		value, err := strconv.ParseFloat(strings.TrimSpace(stringValue), 64)
 		if err != nil {
 			return nil, fmt.Errorf("Unexpected error converting data to int (%v)", err)
@@ -129,3 +170,111 @@ func convertValuesToInts(stringValues []string) ([]float64, error) {
 	}
 	return floatValues, nil
 }
+
+// Plots the rank of a submitter for each month ("bump chart") in a PNG or SVG file (based on the extension).
+// The months without activity have no rank and are not plotted.
+func plotRankHistory(plotFileName string, profile submitterProfile) error {
+	p := plot.New()
+	p.Title.Text = "Monthly rank of " + profile.User
+	p.Y.Label.Text = "Rank"
+	// The best rank (1) is at the top
+	p.Y.Scale = plot.InvertedScale{Normalizer: plot.LinearScale{}}
+	p.Y.Tick.Marker = plot.TickerFunc(integerTicks)
+
+	var points plotter.XYs
+	var ticks []plot.Tick
+	var months []string
+	for i, activity := range profile.Months {
+		months = append(months, activity.Month)
+		if activity.Rank != 0 {
+			points = append(points, plotter.XY{X: float64(i), Y: float64(activity.Rank)})
+		}
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("No activity to plot for %s", profile.User)
+	}
+
+	for i, label := range simplifyAxisLabels(months) {
+		ticks = append(ticks, plot.Tick{Value: float64(i), Label: label})
+	}
+	p.X.Tick.Marker = plot.ConstantTicks(ticks)
+	p.X.Min = 0
+	p.X.Max = float64(len(months) - 1)
+
+	line, scatter, err := plotter.NewLinePoints(points)
+	if err != nil {
+		return err
+	}
+	line.Color = plotutil.Color(0)
+	scatter.Color = plotutil.Color(0)
+	p.Add(line, scatter)
+	p.Y.Min = 1
+
+	return p.Save(10*vg.Inch, 6*vg.Inch, plotFileName)
+}
+
+// Plots the average activity of each calendar month as a bar chart
+func plotSeasonality(plotFileName string, seasonality []seasonalMonth) error {
+	p := plot.New()
+	p.Title.Text = "Average activity per calendar month"
+	p.Y.Label.Text = "Average"
+
+	var averages plotter.Values
+	var labels []string
+	for _, stats := range seasonality {
+		averages = append(averages, stats.Average)
+		labels = append(labels, stats.Month.String()[:3])
+	}
+
+	bars, err := plotter.NewBarChart(averages, vg.Points(30))
+	if err != nil {
+		return err
+	}
+	bars.LineStyle.Width = vg.Length(0)
+	bars.Color = plotutil.Color(0)
+	p.Add(bars)
+	p.NominalX(labels...)
+
+	return p.Save(8*vg.Inch, 5*vg.Inch, plotFileName)
+}
+
+// Writes the monthly rank of a submitter as a Mermaid chart.
+// The months without activity have no rank and are not listed.
+func writeRankHistoryMermaid(out io.Writer, profile submitterProfile) error {
+	var months []string
+	var ranks []string
+	maxRank := 1
+	for _, activity := range profile.Months {
+		if activity.Rank == 0 {
+			continue
+		}
+		months = append(months, "\""+activity.Month+"\"")
+		ranks = append(ranks, strconv.Itoa(activity.Rank))
+		if activity.Rank > maxRank {
+			maxRank = activity.Rank
+		}
+	}
+	if len(ranks) == 0 {
+		return fmt.Errorf("No activity to plot for %s", profile.User)
+	}
+
+	fmt.Fprintf(out, "xychart-beta\n")
+	fmt.Fprintf(out, "    title \"Monthly rank of %s (lower is better)\"\n", profile.User)
+	fmt.Fprintf(out, "    x-axis [%s]\n", strings.Join(months, ", "))
+	fmt.Fprintf(out, "    y-axis \"Rank\" 1 --> %d\n", maxRank)
+	fmt.Fprintf(out, "    line [%s]\n", strings.Join(ranks, ", "))
+	return nil
+}
+
+// Generates about ten ticks with integer values (ranks can't be fractional)
+func integerTicks(min, max float64) []plot.Tick {
+	step := int(max-min) / 10
+	if step < 1 {
+		step = 1
+	}
+	var ticks []plot.Tick
+	for value := int(min); value <= int(max); value = value + step {
+		ticks = append(ticks, plot.Tick{Value: float64(value), Label: strconv.Itoa(value)})
+	}
+	return ticks
+}
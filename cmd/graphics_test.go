@@ -22,6 +22,7 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -94,17 +95,18 @@ func Test_convertValuesToFloats(t *testing.T) {
 			false,
 		},
 		//TODO: empty input
+		// A month without data is plotted as a gap
 		{
 			"empty element",
 			args{stringValues: []string{"1", "2", ""}},
-			nil,
-			true,
+			[]float64{1, 2, 0},
+			false,
 		},
 		{
 			"space element",
 			args{stringValues: []string{"1", "2", " "}},
-			nil,
-			true,
+			[]float64{1, 2, 0},
+			false,
 		},
 		{
 			"invalid value",
@@ -126,3 +128,82 @@ func Test_convertValuesToFloats(t *testing.T) {
 		})
 	}
 }
+
+func Test_plotRankHistory(t *testing.T) {
+	//setup environment
+	tempDir := t.TempDir()
+	profile := submitterProfile{
+		User: "test",
+		Months: []monthActivity{
+			{Month: "2022-12", Count: 3, Rank: 4},
+			{Month: "2023-01", Count: 0},
+			{Month: "2023-02", Count: 9, Rank: 1},
+		},
+	}
+
+	for _, extension := range []string{".png", ".svg"} {
+		outputFileName := filepath.Join(tempDir, "rank"+extension)
+		err := plotRankHistory(outputFileName, profile)
+		assert.NoError(t, err, "Function should not have failed")
+		assert.FileExists(t, outputFileName, "No graphic file generated")
+	}
+
+	err := plotRankHistory(filepath.Join(tempDir, "empty.png"), submitterProfile{User: "empty", Months: []monthActivity{{Month: "2023-01"}}})
+	assert.Error(t, err, "No data to plot should fail")
+}
+
+func Test_writeRankHistoryMermaid(t *testing.T) {
+	profile := submitterProfile{
+		User: "test",
+		Months: []monthActivity{
+			{Month: "2022-12", Count: 3, Rank: 4},
+			{Month: "2023-01", Count: 0},
+			{Month: "2023-02", Count: 9, Rank: 1},
+		},
+	}
+	out := new(bytes.Buffer)
+
+	err := writeRankHistoryMermaid(out, profile)
+
+	assert.NoError(t, err)
+	expected := `xychart-beta
+    title "Monthly rank of test (lower is better)"
+    x-axis ["2022-12", "2023-02"]
+    y-axis "Rank" 1 --> 4
+    line [4, 1]
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_integerTicks(t *testing.T) {
+	got := integerTicks(1, 4)
+	assert.Len(t, got, 4)
+	assert.Equal(t, "1", got[0].Label)
+	assert.Equal(t, "4", got[3].Label)
+}
+
+func Test_plotAllHistoryFiles(t *testing.T) {
+	//setup environment
+	tempDir := t.TempDir()
+	history := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "1", "5", "2"},
+		{"bravo", "4", "junk", "2"},
+		{"charly", "0", "1", "7"},
+		{"delta", "3", "junk", "0"},
+		{"echo", "3", "", "0"},
+	}
+
+	err := plotAllHistoryFiles(tempDir, history, InputTypeSubmitters)
+
+	// All the errors are reported
+	assert.Error(t, err, "Function should have failed")
+	assert.Contains(t, err.Error(), "bravo: ")
+	assert.Contains(t, err.Error(), "delta: ")
+
+	// The valid lines are still plotted (a month without data is a gap)
+	assert.FileExists(t, filepath.Join(tempDir, "alpha.png"))
+	assert.FileExists(t, filepath.Join(tempDir, "charly.png"))
+	assert.FileExists(t, filepath.Join(tempDir, "echo.png"))
+	assert.NoFileExists(t, filepath.Join(tempDir, "bravo.png"))
+}
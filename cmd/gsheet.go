@@ -0,0 +1,338 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Base URL of the Google Sheets API (changed by the tests)
+var sheetsAPIURL = "https://sheets.googleapis.com/v4"
+
+const sheetsScope = "https://www.googleapis.com/auth/spreadsheets"
+
+// The fields of a Google service account key file we use
+type googleServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// Minimal Google Sheets API client
+type sheetsClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// Parses a Google Sheet destination ("gsheet://spreadsheet-id" or "gsheet://spreadsheet-id/tab")
+func parseGoogleSheetURL(destination string) (spreadsheetID string, tab string, err error) {
+	u, err := url.Parse(destination)
+	if err != nil || u.Scheme != "gsheet" || u.Host == "" {
+		return "", "", fmt.Errorf("Invalid destination \"%s\" (expecting \"gsheet://spreadsheet-id/tab\")", destination)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// Returns the month ("YYYY-MM") found in a file name, or an empty string
+func monthOfFileName(fileName string) string {
+	return regexp.MustCompile(`20[0-9]{2}-[0-9]{2}`).FindString(fileName)
+}
+
+// Loads the key file of a Google service account
+func loadGoogleServiceAccount(fileName string) (googleServiceAccount, error) {
+	var account googleServiceAccount
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return account, err
+	}
+	if err := json.Unmarshal(content, &account); err != nil {
+		return account, fmt.Errorf("Invalid service account file %s: %v", fileName, err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" || account.TokenURI == "" {
+		return account, fmt.Errorf("Invalid service account file %s (client_email, private_key or token_uri missing)", fileName)
+	}
+	return account, nil
+}
+
+// Exchanges a JWT signed with the service account key for an OAuth2 access token
+func fetchGoogleAccessToken(ctx context.Context, httpClient *http.Client, account googleServiceAccount, scope string, now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return "", err
+	}
+	assertion, err := signJWT(map[string]any{
+		"iss":   account.ClientEmail,
+		"scope": scope,
+		"aud":   account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}, key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return "", fmt.Errorf("Failed to get a Google access token (%s): %s", response.Status, strings.TrimSpace(string(body)))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// Creates a client using the given access token
+func newSheetsClient(token string) *sheetsClient {
+	return &sheetsClient{
+		baseURL:    strings.TrimSuffix(sheetsAPIURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Replaces the content of a tab of the spreadsheet (the tab is created if needed)
+func (c *sheetsClient) updateTab(ctx context.Context, spreadsheetID string, tab string, values [][]string) error {
+	var spreadsheet struct {
+		Sheets []struct {
+			Properties struct {
+				Title string `json:"title"`
+			} `json:"properties"`
+		} `json:"sheets"`
+	}
+	if err := c.call(ctx, http.MethodGet, "/spreadsheets/"+url.PathEscape(spreadsheetID)+"?fields=sheets.properties.title", nil, &spreadsheet); err != nil {
+		return err
+	}
+
+	isExisting := false
+	for _, sheet := range spreadsheet.Sheets {
+		isExisting = isExisting || sheet.Properties.Title == tab
+	}
+	if !isExisting {
+		addSheet := map[string]any{"requests": []any{map[string]any{"addSheet": map[string]any{"properties": map[string]string{"title": tab}}}}}
+		if err := c.call(ctx, http.MethodPost, "/spreadsheets/"+url.PathEscape(spreadsheetID)+":batchUpdate", addSheet, nil); err != nil {
+			return err
+		}
+	}
+
+	// The quotes allow any tab name (ex: "2023-04" would otherwise be a formula)
+	sheetRange := url.PathEscape("'" + strings.ReplaceAll(tab, "'", "''") + "'")
+	valuesPath := "/spreadsheets/" + url.PathEscape(spreadsheetID) + "/values/" + sheetRange
+	if err := c.call(ctx, http.MethodPost, valuesPath+":clear", map[string]any{}, nil); err != nil {
+		return err
+	}
+	return c.call(ctx, http.MethodPut, valuesPath+"?valueInputOption=USER_ENTERED", googleSheetUpdate(values), nil)
+}
+
+// Performs a call to the API, encoding the body and decoding the answer as JSON (if not nil)
+func (c *sheetsClient) call(ctx context.Context, method string, path string, body any, result any) error {
+	var content io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, content)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		answer, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("Unexpected Google Sheets API answer for %s (%s): %s", path, response.Status, strings.TrimSpace(string(answer)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// Publishes CSV files in the tabs of a Google Sheet ("gsheet://spreadsheet-id/tab")
+type googleSheetPublisher struct {
+	destination string
+}
+
+// Initialize the publisher
+func init() {
+	registerPublisher("gsheet", func(destination string) (publisher, error) {
+		if _, _, err := parseGoogleSheetURL(destination); err != nil {
+			return nil, err
+		}
+		return &googleSheetPublisher{destination: destination}, nil
+	})
+}
+
+func (p *googleSheetPublisher) publish(ctx context.Context, paths []string, isDryRun bool) error {
+	return publishToGoogleSheet(ctx, p.destination, paths, publishGSheetCredentials, isDryRun)
+}
+
+// Writes the update of each tab, as sent to the Sheets API, in "gsheet/<spreadsheet id>/<tab>.json"
+func (p *googleSheetPublisher) preview(paths []string, directory string) error {
+	spreadsheetID, tabs, err := googleSheetTabs(p.destination, paths)
+	if err != nil {
+		return err
+	}
+	for _, fileName := range paths {
+		values, err := readCSVFile(fileName)
+		if err != nil {
+			return err
+		}
+		content, err := json.MarshalIndent(googleSheetUpdate(values), "", "  ")
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(directory, "gsheet", spreadsheetID, tabs[fileName]+".json")
+		if err := writeFileWithDirs(target, content); err != nil {
+			return err
+		}
+		logInfo("%s -> %s\n", fileName, target)
+	}
+	return nil
+}
+
+// Returns the tab of each CSV file. Without a tab in the destination, each file goes in
+// the tab named after the month found in its name.
+func googleSheetTabs(destination string, files []string) (spreadsheetID string, tabs map[string]string, err error) {
+	spreadsheetID, tab, err := parseGoogleSheetURL(destination)
+	if err != nil {
+		return "", nil, err
+	}
+	if tab != "" && len(files) > 1 {
+		return "", nil, fmt.Errorf("Only one file can be published in the tab \"%s\"", tab)
+	}
+
+	tabs = make(map[string]string)
+	for _, fileName := range files {
+		if !strings.HasSuffix(strings.ToLower(fileName), ".csv") {
+			return "", nil, fmt.Errorf("Only CSV files can be published to a Google Sheet (%s)", fileName)
+		}
+		tabs[fileName] = tab
+		if tab == "" {
+			if tabs[fileName] = monthOfFileName(fileName); tabs[fileName] == "" {
+				return "", nil, fmt.Errorf("No month in the name of %s: the tab must be given (\"gsheet://%s/tab\")", fileName, spreadsheetID)
+			}
+		}
+	}
+	return spreadsheetID, tabs, nil
+}
+
+// Returns the body of the update of a tab with the values
+func googleSheetUpdate(values [][]string) map[string]any {
+	return map[string]any{"majorDimension": "ROWS", "values": values}
+}
+
+// Publishes CSV files in the tabs of a Google Sheet (see googleSheetTabs)
+func publishToGoogleSheet(ctx context.Context, destination string, files []string, credentialsFileName string, isDryRun bool) error {
+	spreadsheetID, tabs, err := googleSheetTabs(destination, files)
+	if err != nil {
+		return err
+	}
+
+	if isDryRun {
+		for _, fileName := range files {
+			logInfo("%s -> gsheet://%s/%s\n", fileName, spreadsheetID, tabs[fileName])
+		}
+		return nil
+	}
+
+	if credentialsFileName == "" {
+		credentialsFileName = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	if credentialsFileName == "" {
+		return fmt.Errorf("A service account key file is required (\"--gsheet-credentials\" or GOOGLE_APPLICATION_CREDENTIALS)")
+	}
+	account, err := loadGoogleServiceAccount(credentialsFileName)
+	if err != nil {
+		return err
+	}
+	client := newSheetsClient("")
+	if client.token, err = fetchGoogleAccessToken(ctx, client.httpClient, account, sheetsScope, time.Now()); err != nil {
+		return err
+	}
+
+	for _, fileName := range files {
+		values, err := readCSVFile(fileName)
+		if err != nil {
+			return err
+		}
+		if err := client.updateTab(ctx, spreadsheetID, tabs[fileName], values); err != nil {
+			return err
+		}
+		logVerbose("Published \"%s\" to the tab \"%s\"\n", fileName, tabs[fileName])
+	}
+	logInfo("%d files published to \"%s\"\n", len(files), destination)
+	return nil
+}
+
+// Reads all the lines of a CSV file
+func readCSVFile(fileName string) ([][]string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	// Skips the "# schema: v2" line of the versioned result tables
+	r.Comment = '#'
+	return r.ReadAll()
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseGoogleSheetURL(t *testing.T) {
+	tests := []struct {
+		destination string
+		wantID      string
+		wantTab     string
+		wantErr     bool
+	}{
+		{"gsheet://1AbC", "1AbC", "", false},
+		{"gsheet://1AbC/2023-04", "1AbC", "2023-04", false},
+		{"gsheet://1AbC/Top%20submitters/", "1AbC", "Top submitters", false},
+		{"gsheet:///tab", "", "", true},
+		{"gs://bucket/path", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			id, tab, err := parseGoogleSheetURL(tt.destination)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantTab, tab)
+		})
+	}
+}
+
+func Test_monthOfFileName(t *testing.T) {
+	assert.Equal(t, "2023-04", monthOfFileName("data/top-submitters_2023-04.csv"))
+	assert.Equal(t, "", monthOfFileName("top-submitters_LATEST.csv"))
+}
+
+func Test_signJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	token, err := signJWT(map[string]any{"iss": "someone"}, key)
+
+	assert.NoError(t, err)
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+	header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.JSONEq(t, `{"alg":"RS256","typ":"JWT"}`, string(header))
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.JSONEq(t, `{"iss":"someone"}`, string(payload))
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+}
+
+func Test_parseRSAPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	parsed, err := parseRSAPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	assert.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	parsed, err = parseRSAPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	assert.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = parseRSAPrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func Test_ExecutePublish_gsheet(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	var calls []string
+	var assertion string
+	var written map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assertion = r.FormValue("assertion")
+			_, _ = io.WriteString(w, `{"access_token":"sheets-token","token_type":"Bearer"}`)
+			return
+		}
+		assert.Equal(t, "Bearer sheets-token", r.Header.Get("Authorization"))
+		calls = append(calls, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = io.WriteString(w, `{"sheets":[{"properties":{"title":"2023-03"}}]}`)
+		case r.Method == http.MethodPut:
+			assert.Equal(t, "USER_ENTERED", r.URL.Query().Get("valueInputOption"))
+			_ = json.NewDecoder(r.Body).Decode(&written)
+			_, _ = io.WriteString(w, `{}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}))
+	defer server.Close()
+	defer func(previous string) { sheetsAPIURL = previous }(sheetsAPIURL)
+	sheetsAPIURL = server.URL + "/v4"
+	defer func() { _ = publishCmd.Flags().Set("gsheet-credentials", "") }()
+
+	tempDir := t.TempDir()
+	credentials := filepath.Join(tempDir, "service-account.json")
+	account, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "aggregator@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+		"token_uri":    server.URL + "/token",
+	})
+	assert.NoError(t, os.WriteFile(credentials, account, 0600))
+	dataFile := filepath.Join(tempDir, "top-submitters_2023-04.csv")
+	assert.NoError(t, os.WriteFile(dataFile, []byte("Submitter,Total_PRs\nalpha,12\nbravo,8\n"), 0644))
+
+	rootCmd.SetArgs([]string{"publish", "gsheet://sheet-id", dataFile, "--gsheet-credentials", credentials})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	claims, _ := base64.RawURLEncoding.DecodeString(strings.Split(assertion, ".")[1])
+	assert.Contains(t, string(claims), `"scope":"https://www.googleapis.com/auth/spreadsheets"`)
+	expectedCalls := []string{
+		"GET /v4/spreadsheets/sheet-id",
+		"POST /v4/spreadsheets/sheet-id:batchUpdate",
+		"POST /v4/spreadsheets/sheet-id/values/%272023-04%27:clear",
+		"PUT /v4/spreadsheets/sheet-id/values/%272023-04%27",
+	}
+	assert.Equal(t, expectedCalls, calls)
+	assert.Equal(t, []any{[]any{"Submitter", "Total_PRs"}, []any{"alpha", "12"}, []any{"bravo", "8"}}, written["values"])
+}
+
+func Test_publishToGoogleSheet_errors(t *testing.T) {
+	err := publishToGoogleSheet(context.Background(), "gsheet://sheet-id", []string{"../test_data/overview.csv"}, "", true)
+	assert.ErrorContains(t, err, "No month in the name")
+
+	err = publishToGoogleSheet(context.Background(), "gsheet://sheet-id/tab", []string{"a_2023-01.csv", "b_2023-02.csv"}, "", true)
+	assert.ErrorContains(t, err, "Only one file")
+
+	err = publishToGoogleSheet(context.Background(), "gsheet://sheet-id/tab", []string{"../test_data/overview.md"}, "", true)
+	assert.ErrorContains(t, err, "Only CSV files")
+
+	err = publishToGoogleSheet(context.Background(), "gsheet://sheet-id/tab", []string{"../test_data/overview.csv"}, "", true)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var historyFromMonth string
+var historyMonths int
+var isWithLongHistory bool
+var isLongHistorySparse bool
+var isWithLeaderboardHistory bool
+var historyRank int
+var isWithDashboardData bool
+
+// The single data file of a static dashboard: the monthly figures of the whole community
+// and the series of the top users, over the months of the history
+type dashboardData struct {
+	Type         string            `json:"type"` // "submitters" or "commenters"
+	Months       []string          `json:"months"`
+	Totals       []int             `json:"totals"`       // of all the users, per month
+	Contributors []int             `json:"contributors"` // number of distinct active users, per month
+	Top          []dashboardSeries `json:"top"`          // in the order of the history
+}
+
+// The monthly counts of a top user
+type dashboardSeries struct {
+	User   string `json:"user"`
+	Total  int    `json:"total"`  // over the months of the history
+	Counts []*int `json:"counts"` // nil for the months without data
+}
+
+// Adds the flags shaping the history
+func addHistoryFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&historyFromMonth, "history-from", "", "", "First month (YYYY-MM) of the history (default is the first month of the data)")
+	cmd.PersistentFlags().IntVarP(&historyMonths, "history-months", "", 0, "Number of months of the history: the last ones, or the ones from \"--history-from\" (0 for all)")
+	cmd.PersistentFlags().BoolVarP(&isWithLongHistory, "history-long", "", false, "Also writes the history in the long format (\"user,month,count\" lines)")
+	cmd.PersistentFlags().BoolVarP(&isLongHistorySparse, "history-sparse", "", false, "Omits the months without activity from the long history")
+	cmd.PersistentFlags().IntVarP(&historyRank, "history-rank", "", 0, "Only includes the submitters who reached at least once this rank in a month of the history (0 for all)")
+	cmd.PersistentFlags().BoolVarP(&isWithDashboardData, "history-json", "", false, "Also writes the monthly totals, contributor counts and top submitters series as a single JSON file")
+	cmd.PersistentFlags().BoolVarP(&isWithLeaderboardHistory, "history-leaderboard", "", false, "Also writes, for each month of the history, the top submitters of that month")
+}
+
+// Checks the flags of the history
+func checkHistoryFlags() error {
+	if (historyFromMonth != "" || historyMonths != 0) && !isOutputHistory {
+		return fmt.Errorf("\"--history-from\" and \"--history-months\" require \"--history\"\n")
+	}
+	if historyFromMonth != "" {
+		if _, err := time.Parse("2006-01", historyFromMonth); err != nil {
+			return fmt.Errorf("\"%s\" is an invalid month (expecting YYYY-MM)\n", historyFromMonth)
+		}
+	}
+	if historyMonths < 0 {
+		return fmt.Errorf("\"--history-months\" can't be negative\n")
+	}
+	if isWithLongHistory && !isOutputHistory {
+		return fmt.Errorf("\"--history-long\" requires \"--history\"\n")
+	}
+	if historyRank < 0 {
+		return fmt.Errorf("\"--history-rank\" can't be negative\n")
+	}
+	if historyRank > 0 && !isOutputHistory {
+		return fmt.Errorf("\"--history-rank\" requires \"--history\"\n")
+	}
+	if isWithDashboardData && !isOutputHistory {
+		return fmt.Errorf("\"--history-json\" requires \"--history\"\n")
+	}
+	if isWithLeaderboardHistory && !isOutputHistory {
+		return fmt.Errorf("\"--history-leaderboard\" requires \"--history\"\n")
+	}
+	if isLongHistorySparse && !isWithLongHistory {
+		return fmt.Errorf("\"--history-sparse\" requires \"--history-long\"\n")
+	}
+	return nil
+}
+
+// Keeps only the months of the history starting at fromMonth (if given) and, if months is not 0,
+// that number of months: from fromMonth or else the last ones.
+func selectHistoryWindow(history [][]string, fromMonth string, months int) ([][]string, error) {
+	header := history[0]
+	firstColumn, lastColumn := 1, len(header)-1
+	if fromMonth != "" {
+		// The months are "YYYY-MM": their order is the alphabetical one
+		for firstColumn <= lastColumn && header[firstColumn] < fromMonth {
+			firstColumn++
+		}
+		if firstColumn > lastColumn {
+			return nil, fmt.Errorf("No month of the history from %s (the last one is %s)", fromMonth, header[lastColumn])
+		}
+	}
+	if months > 0 {
+		if fromMonth != "" && firstColumn+months-1 < lastColumn {
+			lastColumn = firstColumn + months - 1
+		}
+		if fromMonth == "" && lastColumn-months+1 > firstColumn {
+			firstColumn = lastColumn - months + 1
+		}
+	}
+	if firstColumn == 1 && lastColumn == len(header)-1 {
+		return history, nil
+	}
+
+	window := make([][]string, 0, len(history))
+	for _, line := range history {
+		windowLine := append([]string{line[0]}, line[firstColumn:lastColumn+1]...)
+		window = append(window, windowLine)
+	}
+	return window, nil
+}
+
+// Returns the name of the long format version of a history file
+func generateLongHistoryFilename(historyFilename string) string {
+	return strings.TrimSuffix(historyFilename, ".csv") + "_long.csv"
+}
+
+// Writes the long format version of a history file. A sparse history omits the zero values,
+// which makes it much smaller for the plotting tools that tolerate gaps.
+func writeLongHistory(historyFilename string, longFilename string, isSparse bool) error {
+	history, err := loadInputPivotTable(historyFilename)
+	if err != nil {
+		return err
+	}
+	records, err := wideToLong(history)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{longFormatHeader}
+	for _, record := range records {
+		if isSparse && record.Count == 0 {
+			continue
+		}
+		data = append(data, []string{record.User, record.Month, strconv.Itoa(record.Count)})
+	}
+	return writeCSVtoFile(longFilename, data)
+}
+
+// Returns the best rank reached by each user in the monthly leaderboards of the history window
+// (the users never active are not listed)
+func bestMonthlyRanks(pivotRecords [][]string) (map[string]int, error) {
+	window, err := selectHistoryWindow(pivotRecords, historyFromMonth, historyMonths)
+	if err != nil {
+		return nil, err
+	}
+	dataset, err := newDataset("history", window)
+	if err != nil {
+		return nil, err
+	}
+
+	bestRanks := make(map[string]int)
+	for _, month := range dataset.Months {
+		monthDataset, err := dataset.Slice(month, month)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range monthDataset.Leaderboard() {
+			if entry.Total == 0 {
+				break
+			}
+			if best, isRanked := bestRanks[entry.User]; !isRanked || entry.Rank < best {
+				bestRanks[entry.User] = entry.Rank
+			}
+		}
+	}
+	return bestRanks, nil
+}
+
+// Returns the name of the monthly leaderboard version of a history file
+func generateLeaderboardHistoryFilename(historyFilename string) string {
+	return strings.TrimSuffix(historyFilename, ".csv") + "_leaderboard.csv"
+}
+
+// Writes, for each month of the history window, the top users of that month alone
+// ("month,rank,user,count" lines) rather than the history of the overall top users.
+// It is what a "leaderboard over time" animation needs.
+func writeLeaderboardHistory(ctx context.Context, leaderboardFilename string, inputFilename string, topSize int) error {
+	dataset, err := loadDataset(ctx, inputFilename)
+	if err != nil {
+		return err
+	}
+	window, err := selectHistoryWindow([][]string{append([]string{""}, dataset.Months...)}, historyFromMonth, historyMonths)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{{"month", "rank", "user", "count"}}
+	for _, month := range window[0][1:] {
+		monthDataset, err := dataset.Slice(month, month)
+		if err != nil {
+			return err
+		}
+		for i, entry := range monthDataset.Leaderboard() {
+			if i == topSize || entry.Total == 0 {
+				break
+			}
+			data = append(data, []string{month, strconv.Itoa(entry.Rank), entry.User, strconv.Itoa(entry.Total)})
+		}
+	}
+	return writeCSVtoFile(leaderboardFilename, data)
+}
+
+// Returns the name of the dashboard data file of a history file
+func generateDashboardFilename(historyFilename string) string {
+	return strings.TrimSuffix(historyFilename, ".csv") + "_dashboard.json"
+}
+
+// Builds the dashboard data from the input pivot table and the (wide) history of the top users
+func buildDashboardData(pivotRecords [][]string, history [][]string, dataType InputType) (dashboardData, error) {
+	window, err := selectHistoryWindow(pivotRecords, historyFromMonth, historyMonths)
+	if err != nil {
+		return dashboardData{}, err
+	}
+	dataset, err := newDataset("input", window)
+	if err != nil {
+		return dashboardData{}, err
+	}
+	topDataset, err := newDataset("history", history)
+	if err != nil {
+		return dashboardData{}, err
+	}
+
+	data := dashboardData{
+		Type:         "submitters",
+		Months:       dataset.Months,
+		Totals:       dataset.MonthTotals(),
+		Contributors: make([]int, len(dataset.Months)),
+		Top:          []dashboardSeries{},
+	}
+	if dataType == InputTypeCommenters {
+		data.Type = "commenters"
+	}
+	for _, counts := range dataset.Counts {
+		for month, count := range counts {
+			if count > 0 {
+				data.Contributors[month]++
+			}
+		}
+	}
+	for i, total := range topDataset.UserTotals() {
+		series := dashboardSeries{User: topDataset.Users[i], Total: total, Counts: make([]*int, len(topDataset.Months))}
+		for month := range topDataset.Months {
+			if !topDataset.isMissing(i, month) {
+				series.Counts[month] = &topDataset.Counts[i][month]
+			}
+		}
+		data.Top = append(data.Top, series)
+	}
+	return data, nil
+}
+
+// Writes the dashboard data of a history file
+func writeDashboardData(ctx context.Context, dashboardFilename string, historyFilename string, inputFilename string, dataType InputType) error {
+	pivotRecords, err := loadInputPivotTableContext(ctx, inputFilename)
+	if err != nil {
+		return err
+	}
+	history, err := loadInputPivotTable(historyFilename)
+	if err != nil {
+		return err
+	}
+	data, err := buildDashboardData(pivotRecords, history, dataType)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dashboardFilename, append(content, '\n'), 0644)
+}
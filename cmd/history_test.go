@@ -0,0 +1,231 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_selectHistoryWindow(t *testing.T) {
+	history := [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "1", "2", "3", "4"},
+	}
+	tests := []struct {
+		name      string
+		fromMonth string
+		months    int
+		want      [][]string
+	}{
+		{"all", "", 0, history},
+		{"last months", "", 2, [][]string{{"", "2023-03", "2023-04"}, {"alpha", "3", "4"}}},
+		{"more months than available", "", 12, history},
+		{"from", "2023-02", 0, [][]string{{"", "2023-02", "2023-03", "2023-04"}, {"alpha", "2", "3", "4"}}},
+		{"from with months", "2023-02", 2, [][]string{{"", "2023-02", "2023-03"}, {"alpha", "2", "3"}}},
+		{"from before the data", "2020-01", 0, history},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := selectHistoryWindow(history, tt.fromMonth, tt.months)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, window)
+		})
+	}
+
+	_, err := selectHistoryWindow(history, "2023-05", 0)
+	assert.Error(t, err)
+}
+
+func Test_ExecuteExtract_historyWindow(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isOutputHistory = false
+		historyFromMonth = ""
+		historyMonths = 0
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "3", "--history", "--history-months", "24", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.NoError(t, rootCmd.Execute())
+
+	history, err := loadInputPivotTable(filepath.Join(tempDir, "top_submitters_fullHistory.csv"))
+	assert.NoError(t, err)
+	assert.Len(t, history, 4)
+	assert.Len(t, history[0], 25)
+	assert.Equal(t, "2021-05", history[0][1])
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "3", "--history=false", "--history-from", "2022-01", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.Error(t, rootCmd.Execute())
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "3", "--history", "--history-from", "2022", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.Error(t, rootCmd.Execute())
+}
+
+func Test_ExecuteExtract_historyWithoutDataMonths(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isOutputHistory = false
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/no_data_months.csv", "--history", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.NoError(t, rootCmd.Execute())
+
+	// The months without data stay empty in the history and are plotted as gaps
+	history, err := loadInputPivotTable(filepath.Join(tempDir, "top_submitters_fullHistory.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bravo", "", "", "2"}, history[1])
+	assert.FileExists(t, filepath.Join(tempDir, "plot", "bravo.png"))
+}
+
+func Test_ExecuteExtract_longHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isOutputHistory = false
+		historyMonths = 0
+		isWithLongHistory = false
+		isLongHistorySparse = false
+		isWithLeaderboardHistory = false
+		isWithDashboardData = false
+	}()
+	longHistoryFile := filepath.Join(tempDir, "top_submitters_fullHistory_long.csv")
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "3", "--history", "--history-months", "24", "--history-long", "--history-leaderboard", "--history-json", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.NoError(t, rootCmd.Execute())
+
+	content, err := os.ReadFile(longHistoryFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, "user,month,count", lines[0])
+	assert.Len(t, lines, 1+3*24)
+	assert.FileExists(t, filepath.Join(tempDir, "top_submitters_fullHistory_leaderboard.csv"))
+	assert.FileExists(t, filepath.Join(tempDir, "top_submitters_fullHistory_dashboard.json"))
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "3", "--history", "--history-months", "24", "--history-long", "--history-sparse", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.NoError(t, rootCmd.Execute())
+
+	content, err = os.ReadFile(longHistoryFile)
+	assert.NoError(t, err)
+	sparseLines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Less(t, len(sparseLines), len(lines))
+	for _, line := range sparseLines {
+		assert.False(t, strings.HasSuffix(line, ",0"), line)
+	}
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "3", "--history", "--history-long=false", "--history-sparse", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.Error(t, rootCmd.Execute())
+}
+
+func Test_writeLeaderboardHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "pivot.csv")
+	writeCSVtoFile(inputFile, [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "5", "0", "1"},
+		{"bravo", "3", "4", "1"},
+		{"charlie", "1", "2", "0"},
+	})
+	leaderboardFile := filepath.Join(tempDir, "leaderboard.csv")
+	defer func() { historyMonths = 0 }()
+	historyMonths = 2
+
+	err := writeLeaderboardHistory(context.Background(), leaderboardFile, inputFile, 2)
+
+	assert.NoError(t, err)
+	leaderboard, err := loadInputPivotTable(leaderboardFile)
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"month", "rank", "user", "count"},
+		{"2023-02", "1", "bravo", "4"},
+		{"2023-02", "2", "charlie", "2"},
+		{"2023-03", "1", "alpha", "1"},
+		{"2023-03", "1", "bravo", "1"},
+	}
+	assert.Equal(t, expected, leaderboard)
+}
+
+func Test_bestMonthlyRanks(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "5", "0", "1"},
+		{"bravo", "3", "4", "1"},
+		{"charlie", "1", "2", "0"},
+		{"delta", "0", "0", "0"},
+	}
+
+	bestRanks, err := bestMonthlyRanks(records)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"alpha": 1, "bravo": 1, "charlie": 2}, bestRanks)
+}
+
+func Test_ExecuteExtract_historyRank(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isOutputHistory = false
+		historyRank = 0
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "10", "--history", "--history-rank", "1", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.NoError(t, rootCmd.Execute())
+
+	history, err := loadInputPivotTable(filepath.Join(tempDir, "top_submitters_fullHistory.csv"))
+	assert.NoError(t, err)
+	assert.Greater(t, len(history), 1)
+	assert.Less(t, len(history), 11)
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-t", "10", "--history=false", "--history-rank", "1", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.Error(t, rootCmd.Execute())
+}
+
+func Test_buildDashboardData(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "5", "0", "1"},
+		{"bravo", "3", "4", "1"},
+		{"charlie", "1", "2", ""},
+	}
+	defer func() { historyMonths = 0 }()
+	historyMonths = 2
+
+	data, err := buildDashboardData(records, [][]string{{"", "2023-02", "2023-03"}, {"bravo", "4", "1"}, {"charlie", "2", ""}}, InputTypeCommenters)
+
+	assert.NoError(t, err)
+	four, one, two := 4, 1, 2
+	expected := dashboardData{
+		Type:         "commenters",
+		Months:       []string{"2023-02", "2023-03"},
+		Totals:       []int{6, 2},
+		Contributors: []int{2, 2},
+		Top: []dashboardSeries{
+			{User: "bravo", Total: 5, Counts: []*int{&four, &one}},
+			{User: "charlie", Total: 2, Counts: []*int{&two, nil}},
+		},
+	}
+	assert.Equal(t, expected, data)
+	content, _ := json.Marshal(data.Top[1])
+	assert.Equal(t, `{"user":"charlie","total":2,"counts":[2,null]}`, string(content))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var isHumanized bool
+
+// Matches a count humanized in Markdown (the exact value being the tooltip)
+var humanizedMarkdownRegexp = regexp.MustCompile(`^<abbr title="([+-]?[0-9]+)">[^<]*</abbr>$`)
+
+// Returns a count of at least a thousand in a short form (ex: "1.2k" or "+3.4M"). The other
+// values (small counts, metrics, text) are not humanized.
+func humanizeCount(value string) (string, bool) {
+	number, isNumeric := numericText(value)
+	count, err := strconv.Atoi(number)
+	if !isNumeric || err != nil {
+		return value, false
+	}
+	sign := ""
+	if strings.HasPrefix(value, "+") {
+		sign = "+"
+	} else if count < 0 {
+		sign = "-"
+		count = -count
+	}
+
+	if count < 1000 {
+		return value, false
+	}
+
+	shortened, suffix := float64(count)/1000, "k"
+	for _, next := range []string{"M", "G"} {
+		// 999950 is rounded to 1000.0k, better written 1M
+		if math.Round(shortened*10)/10 < 1000 {
+			break
+		}
+		shortened, suffix = shortened/1000, next
+	}
+	return sign + strings.TrimSuffix(fmt.Sprintf("%.1f", shortened), ".0") + suffix, true
+}
+
+// Returns a copy of the table with the large counts humanized, the exact values being
+// available as tooltips (an <abbr> element, rendered by GitHub)
+func humanizeMarkdownTable(table [][]string) [][]string {
+	humanized := make([][]string, len(table))
+	for i, dataLine := range table {
+		humanized[i] = dataLine
+		if i == 0 {
+			continue
+		}
+		humanizedLine := append([]string{}, dataLine...)
+		for column := 1; column < len(dataLine); column++ {
+			if short, isHumanizedCount := humanizeCount(dataLine[column]); isHumanizedCount {
+				humanizedLine[column] = fmt.Sprintf("<abbr title=\"%s\">%s</abbr>", html.EscapeString(dataLine[column]), short)
+			}
+		}
+		humanized[i] = humanizedLine
+	}
+	return humanized
+}
+
+// Returns the exact value of a cell of a Markdown table (the value itself if it isn't humanized)
+func exactCount(value string) string {
+	if matches := humanizedMarkdownRegexp.FindStringSubmatch(value); matches != nil {
+		return matches[1]
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_humanizeCount(t *testing.T) {
+	tests := []struct {
+		value       string
+		want        string
+		isHumanized bool
+	}{
+		{"999", "999", false},
+		{"1000", "1k", true},
+		{"1234", "1.2k", true},
+		{"+1500", "+1.5k", true},
+		{"-25000", "-25k", true},
+		{"999950", "1M", true},
+		{"3400000", "3.4M", true},
+		{"2500000000", "2.5G", true},
+		{"1234.5", "1234.5", false},
+		{"alpha", "alpha", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, isHumanized := humanizeCount(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.isHumanized, isHumanized)
+		})
+	}
+}
+
+func Test_humanizedRendering(t *testing.T) {
+	tempDir := t.TempDir()
+	table := [][]string{{"Submitter", "Total_PRs"}, {"alpha", "1234"}, {"bravo", "12"}}
+	defer func() { isHumanized = false }()
+	isHumanized = true
+
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "top.md"), renderMarkdown, table, renderOptions{}))
+	content, _ := os.ReadFile(filepath.Join(tempDir, "top.md"))
+	assert.Contains(t, string(content), "| alpha     | <abbr title=\"1234\">1.2k</abbr> |")
+	assert.Contains(t, string(content), "| --------- | -----------------------------: |")
+
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "top.html"), renderHTML, table, renderOptions{}))
+	content, _ = os.ReadFile(filepath.Join(tempDir, "top.html"))
+	assert.Contains(t, string(content), "<td style=\"text-align: right\" title=\"1234\">1.2k</td>")
+	assert.Contains(t, string(content), "<td style=\"text-align: right\">12</td>")
+
+	// The exact values are kept in the formats read by programs
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "top.csv"), renderCSV, table, renderOptions{}))
+	content, _ = os.ReadFile(filepath.Join(tempDir, "top.csv"))
+	assert.Equal(t, "Submitter,Total_PRs\nalpha,1234\nbravo,12\n", string(content))
+}
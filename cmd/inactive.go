@@ -0,0 +1,194 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var inactiveOutputFileName string
+var inactiveTopSize int
+var inactiveMonths int
+
+// A previously significant contributor who is no longer active
+type inactiveContributor struct {
+	User           string
+	BestRank       int
+	BestMonth      string // last month of the period where the best rank was reached
+	LastActive     string
+	InactiveMonths int
+	Total          int // all time
+}
+
+// inactiveCmd represents the inactive command
+var inactiveCmd = &cobra.Command{
+	Use:   "inactive [input file]",
+	Short: "Lists the previously significant contributors who are no longer active",
+	Long: `The INACTIVE command lists the contributors who were once among the most active (in
+the top 20 of a 12 months period, see "--top" and "--period") but have not contributed
+for at least 6 consecutive months (see "--inactive"). The list is meant for the outreach
+team, to re-engage them.
+
+The inactivity is measured up to the month given with "--month" (the last one by default).
+For each contributor, the output gives the best rank reached (and the last month of that
+period), the last month with a contribution, the number of inactive months and the all
+time total. The most inactive contributors come last.
+
+The output is a CSV file (or any format selected by its extension or with "--format").`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		if inactiveTopSize < 1 || inactiveMonths < 1 {
+			return fmt.Errorf("\"--top\" and \"--inactive\" must be at least 1\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		inactiveContributors, err := findInactiveContributors(dataset, endMonth, period, inactiveTopSize, inactiveMonths)
+		if err != nil {
+			return err
+		}
+
+		if dirErr := CheckDir(inactiveOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		introduction := fmt.Sprintf("# Inactive contributors\n\nContributors once in the top %d of a %d months period, inactive for at least %d months.\n\n", inactiveTopSize, period, inactiveMonths)
+		options := renderOptions{Title: "Inactive contributors", Introduction: introduction, InputType: InputTypeSubmitters}
+		if err := writeResultTable(inactiveOutputFileName, inactiveAsTable(inactiveContributors), options); err != nil {
+			return err
+		}
+		logInfo("%d inactive contributors written to \"%s\"\n", len(inactiveContributors), inactiveOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(inactiveCmd)
+
+	inactiveCmd.Flags().StringVarP(&inactiveOutputFileName, "out", "o", "inactive-contributors.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	inactiveCmd.Flags().IntVarP(&inactiveTopSize, "top", "t", 20, "Rank to have reached to be considered a significant contributor")
+	inactiveCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months of the periods the ranks are computed on (0 for all the previous months).")
+	inactiveCmd.Flags().IntVarP(&inactiveMonths, "inactive", "", 6, "Minimum number of consecutive months without contribution")
+	inactiveCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Month up to which the inactivity is measured.")
+	addFormatFlag(inactiveCmd)
+
+	inactiveCmd.ValidArgsFunction = completeInputFile
+	_ = inactiveCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Returns the contributors who reached the top (of a period ending at any month up to endMonth)
+// and have been inactive for at least inactiveMonths months at endMonth
+func findInactiveContributors(dataset *Dataset, endMonth string, period int, topSize int, inactiveMonths int) ([]inactiveContributor, error) {
+	lastMonth, err := dataset.Period(endMonth, 1)
+	if err != nil {
+		return nil, err
+	}
+	history, err := dataset.Slice(dataset.Months[0], lastMonth.Months[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// The best rank of each user over all the periods
+	bestRanks := make(map[string]inactiveContributor)
+	for last := range history.Months {
+		first := 0
+		if period > 0 && last-period+1 > 0 {
+			first = last - period + 1
+		}
+		window, _ := history.Slice(history.Months[first], history.Months[last])
+		for _, entry := range window.Leaderboard() {
+			if entry.Rank > topSize || entry.Total == 0 {
+				break
+			}
+			if best, isKnown := bestRanks[entry.User]; !isKnown || entry.Rank < best.BestRank {
+				bestRanks[entry.User] = inactiveContributor{User: entry.User, BestRank: entry.Rank, BestMonth: history.Months[last]}
+			}
+		}
+	}
+
+	var inactiveContributors []inactiveContributor
+	totals := history.UserTotals()
+	for i, user := range history.Users {
+		contributor, isSignificant := bestRanks[user]
+		if !isSignificant || user == "deleted_user" {
+			continue
+		}
+		// The months without data neither break nor extend the inactivity
+		counts := history.Counts[i]
+		lastActive := len(counts) - 1
+		for lastActive >= 0 && counts[lastActive] == 0 {
+			if !history.isMissing(i, lastActive) {
+				contributor.InactiveMonths++
+			}
+			lastActive--
+		}
+		if contributor.InactiveMonths < inactiveMonths {
+			continue
+		}
+		contributor.LastActive = history.Months[lastActive]
+		contributor.Total = totals[i]
+		inactiveContributors = append(inactiveContributors, contributor)
+	}
+
+	// The most recently active first, as they are the easiest to re-engage
+	sort.SliceStable(inactiveContributors, func(i, j int) bool {
+		if inactiveContributors[i].InactiveMonths != inactiveContributors[j].InactiveMonths {
+			return inactiveContributors[i].InactiveMonths < inactiveContributors[j].InactiveMonths
+		}
+		return strings.ToLower(inactiveContributors[i].User) < strings.ToLower(inactiveContributors[j].User)
+	})
+	return inactiveContributors, nil
+}
+
+// Returns the inactive contributors as a table (with a header line)
+func inactiveAsTable(inactiveContributors []inactiveContributor) [][]string {
+	table := [][]string{{"User", "Best_Rank", "Best_Month", "Last_Active", "Inactive_Months", "Total"}}
+	for _, contributor := range inactiveContributors {
+		table = append(table, []string{contributor.User, strconv.Itoa(contributor.BestRank), contributor.BestMonth,
+			contributor.LastActive, strconv.Itoa(contributor.InactiveMonths), strconv.Itoa(contributor.Total)})
+	}
+	return table
+}
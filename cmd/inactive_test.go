@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findInactiveContributors(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04", "2023-05", "2023-06"},
+		{"alpha", "9", "0", "0", "0", "0", "0"},
+		{"bravo", "1", "1", "1", "1", "1", "1"},
+		{"charlie", "0", "8", "0", "0", "0", "0"},
+		{"delta", "2", "2", "0", "0", "0", "3"},
+		{"echo", "0", "0", "0", "0", "0", "0"},
+	})
+	assert.NoError(t, err)
+
+	inactiveContributors, err := findInactiveContributors(dataset, "latest", 2, 2, 3)
+
+	assert.NoError(t, err)
+	// "bravo" and "delta" are still active, "echo" never was. "charlie" is first in the period ending in 2023-03.
+	assert.Equal(t, []inactiveContributor{
+		{User: "charlie", BestRank: 1, BestMonth: "2023-03", LastActive: "2023-02", InactiveMonths: 4, Total: 8},
+		{User: "alpha", BestRank: 1, BestMonth: "2023-01", LastActive: "2023-01", InactiveMonths: 5, Total: 9},
+	}, inactiveContributors)
+
+	// Measured in 2023-04, "alpha" is the only one inactive for 3 months
+	inactiveContributors, err = findInactiveContributors(dataset, "2023-04", 2, 2, 3)
+	assert.NoError(t, err)
+	assert.Len(t, inactiveContributors, 1)
+	assert.Equal(t, "alpha", inactiveContributors[0].User)
+
+	// The months without data are not counted as inactive
+	dataset, err = newDataset("test", [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "9", "0", "", ""},
+	})
+	assert.NoError(t, err)
+	inactiveContributors, err = findInactiveContributors(dataset, "latest", 2, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []inactiveContributor{
+		{User: "alpha", BestRank: 1, BestMonth: "2023-01", LastActive: "2023-01", InactiveMonths: 1, Total: 9},
+	}, inactiveContributors)
+}
+
+func Test_ExecuteInactive(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "inactive.csv")
+
+	rootCmd.SetArgs([]string{"inactive", "../test_data/overview.csv", "-m", "latest", "--top", "20", "--inactive", "6", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "User,Best_Rank,Best_Month,Last_Active,Inactive_Months,Total\n"))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Value of "--type" detecting the type of data from the input file
+const inputTypeAuto = "auto"
+
+// Hints of the type of data in the name of the first column of the header or in the file name
+var commentersHintRegexp = regexp.MustCompile(`(?i)comment`)
+var submittersHintRegexp = regexp.MustCompile(`(?i)submitter|pull|(^|[^a-z])prs?([^a-z]|$)`)
+
+// Returns the type of data selected with "--type" ("submitters", "commenters" or "auto")
+func selectInputType(typeName string, inputFilename string) (InputType, error) {
+	switch strings.ToLower(typeName) {
+	case "submitters":
+		return InputTypeSubmitters, nil
+	case "commenters":
+		return InputTypeCommenters, nil
+	case inputTypeAuto:
+		detected, origin := detectInputType(inputFilename)
+		logVerbose("Type of data: %s (%s)\n", inputTypeName(detected), origin)
+		return detected, nil
+	}
+	return InputTypeUnknown, fmt.Errorf("%s is an invalid input type\n", typeName)
+}
+
+// Detects the type of data of a pivot table, with the origin of the detection. The name of the
+// first column of the header (ex: "GroupBy(comment.user.login)", generated by the newer datamash
+// versions) comes first, then the file name (ex: "jenkins-commenters.csv"). Without any hint,
+// the data is about submitters (the historical default).
+func detectInputType(inputFilename string) (InputType, string) {
+	if f, err := os.Open(inputFilename); err == nil {
+		defer f.Close()
+		if header, err := csv.NewReader(f).Read(); err == nil {
+			if detected, isDetected := inputTypeOfHint(header[0]); isDetected {
+				return detected, fmt.Sprintf("header column \"%s\"", header[0])
+			}
+		}
+	}
+	if detected, isDetected := inputTypeOfHint(filepath.Base(inputFilename)); isDetected {
+		return detected, fmt.Sprintf("file name \"%s\"", filepath.Base(inputFilename))
+	}
+	return InputTypeSubmitters, "default, no hint found"
+}
+
+// Returns the type of data a text (column or file name) hints at
+func inputTypeOfHint(text string) (InputType, bool) {
+	switch {
+	case commentersHintRegexp.MatchString(text):
+		return InputTypeCommenters, true
+	case submittersHintRegexp.MatchString(text):
+		return InputTypeSubmitters, true
+	}
+	return InputTypeUnknown, false
+}
+
+// Returns the name of the type of data, as given to "--type"
+func inputTypeName(dataType InputType) string {
+	if dataType == InputTypeCommenters {
+		return "commenters"
+	}
+	return "submitters"
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_detectInputType(t *testing.T) {
+	tempDir := t.TempDir()
+	content, err := os.ReadFile("../test_data/short_overview.csv")
+	assert.NoError(t, err)
+	commentersFile := filepath.Join(tempDir, "jenkins-commenters.csv")
+	assert.NoError(t, os.WriteFile(commentersFile, content, 0644))
+	// The header wins over the file name
+	headerFile := filepath.Join(tempDir, "pr-stats.csv")
+	assert.NoError(t, os.WriteFile(headerFile, []byte("GroupBy(comment.user.login),2023-01\nalpha,1\n"), 0644))
+
+	tests := []struct {
+		fileName string
+		want     InputType
+		origin   string
+	}{
+		{"../test_data/overview.csv", InputTypeSubmitters, "default, no hint found"},
+		{"../test_data/datamash_groupby_header.csv", InputTypeSubmitters, "default, no hint found"},
+		{commentersFile, InputTypeCommenters, "file name \"jenkins-commenters.csv\""},
+		{headerFile, InputTypeCommenters, "header column \"GroupBy(comment.user.login)\""},
+		{filepath.Join(tempDir, "submitters_2023.csv"), InputTypeSubmitters, "file name \"submitters_2023.csv\""},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.fileName), func(t *testing.T) {
+			got, origin := detectInputType(tt.fileName)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.origin, origin)
+		})
+	}
+}
+
+func Test_selectInputType(t *testing.T) {
+	inputType, err := selectInputType("Commenters", "../test_data/overview.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, InputTypeCommenters, inputType)
+
+	inputType, err = selectInputType("auto", "../test_data/overview.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, InputTypeSubmitters, inputType)
+
+	_, err = selectInputType("reviewers", "../test_data/overview.csv")
+	assert.ErrorContains(t, err, "reviewers is an invalid input type")
+}
+
+func Test_ExecuteExtract_detectedType(t *testing.T) {
+	tempDir := t.TempDir()
+	content, err := os.ReadFile("../test_data/overview.csv")
+	assert.NoError(t, err)
+	inputFile := filepath.Join(tempDir, "commenters.csv")
+	assert.NoError(t, os.WriteFile(inputFile, content, 0644))
+	outputFile := filepath.Join(tempDir, "top.csv")
+
+	rootCmd.SetArgs([]string{"extract", inputFile, "--type=auto", "-m", "latest", "-t", "3", "-o", outputFile})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	output, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(output), "Commenter,"), string(output))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+var introFileName string
+
+// Variables available in the introduction templates
+type introductionData struct {
+	Month      string // last month of the period
+	FirstMonth string // first month of the period
+	Period     int    // number of months of the period
+	TopSize    int    // requested number of top users
+	TopCount   int    // number of users listed (ex-aequo included, churned excluded)
+	TotalPRs   int    // total of the listed users
+	Type       string // "submitters" or "commenters"
+	Compare    int    // number of months compared with (0 for an extraction)
+}
+
+// Adds the flag to supply the introduction of the Markdown output
+func addIntroFileFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&introFileName, "intro-file", "", "", "Template of the introduction of the Markdown output (ex: \"{{.TopCount}} submitters in {{.Month}}\")")
+}
+
+// Computes the template variables of an output
+func newIntroductionData(header []string, endMonth string, period int, topSize int, compareWith int, inputType InputType, data [][]string) introductionData {
+	firstColumn, lastColumn, firstMonth, lastMonth := getBoundaries([][]string{header}, endMonth, period, 0)
+
+	introduction := introductionData{
+		Month:      lastMonth,
+		FirstMonth: firstMonth,
+		Period:     lastColumn - firstColumn + 1,
+		TopSize:    topSize,
+		Type:       "submitters",
+		Compare:    compareWith,
+	}
+	if inputType == InputTypeCommenters {
+		introduction.Type = "commenters"
+	}
+	for i, line := range data {
+		if i == 0 || len(line) < 2 {
+			continue
+		}
+		// The churned users (of a compare) have no total
+		if total, err := strconv.Atoi(line[1]); err == nil {
+			introduction.TopCount++
+			introduction.TotalPRs += total
+		}
+	}
+	return introduction
+}
+
+// Renders the introduction template file
+func renderIntroductionFile(fileName string, data introductionData) (string, error) {
+	content, err := readTextFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read the introduction file: %v", err)
+	}
+	introductionTemplate, err := template.New(fileName).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("Invalid introduction template \"%s\": %v", fileName, err)
+	}
+
+	var b strings.Builder
+	if err := introductionTemplate.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("Failed to render the introduction \"%s\": %v", fileName, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newIntroductionData(t *testing.T) {
+	header := []string{"", "2023-01", "2023-02", "2023-03", "2023-04"}
+	data := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"alpha", "12", ""},
+		{"bravo", "7", "new"},
+		{"charlie", "", "churned"},
+	}
+
+	introduction := newIntroductionData(header, "2023-03", 2, 35, 3, InputTypeSubmitters, data)
+
+	expected := introductionData{Month: "2023-03", FirstMonth: "2023-02", Period: 2, TopSize: 35, TopCount: 2, TotalPRs: 19, Type: "submitters", Compare: 3}
+	assert.Equal(t, expected, introduction)
+
+	// All the months
+	introduction = newIntroductionData(header, "latest", 0, 10, 0, InputTypeCommenters, data[:2])
+	assert.Equal(t, introductionData{Month: "2023-04", FirstMonth: "2023-01", Period: 4, TopSize: 10, TopCount: 1, TotalPRs: 12, Type: "commenters"}, introduction)
+}
+
+func Test_renderIntroductionFile(t *testing.T) {
+	data := introductionData{Month: "2023-03", FirstMonth: "2023-02", Period: 2, TopCount: 2, TotalPRs: 19, Type: "submitters"}
+
+	introduction, err := renderIntroductionFile("../test_data/intro_template.md", data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "# Jenkins contributors of 2023-03\n\nThe 2 most active submitters opened 19 pull requests\nbetween 2023-02 and 2023-03 (2 months).\n", introduction)
+
+	tempDir := t.TempDir()
+	unknownVariable := filepath.Join(tempDir, "unknown.md")
+	assert.NoError(t, os.WriteFile(unknownVariable, []byte("{{.Year}}"), 0644))
+	_, err = renderIntroductionFile(unknownVariable, data)
+	assert.Error(t, err)
+
+	invalidTemplate := filepath.Join(tempDir, "invalid.md")
+	assert.NoError(t, os.WriteFile(invalidTemplate, []byte("{{.Month"), 0644))
+	_, err = renderIntroductionFile(invalidTemplate, data)
+	assert.Error(t, err)
+
+	_, err = renderIntroductionFile(filepath.Join(tempDir, "missing.md"), data)
+	assert.Error(t, err)
+
+	// Template edited on Windows
+	windowsTemplate := filepath.Join(tempDir, "windows.md")
+	assert.NoError(t, os.WriteFile(windowsTemplate, []byte("# {{.Month}}\r\n\r\nTop {{.TopCount}}\r\n"), 0644))
+	introduction, err = renderIntroductionFile(windowsTemplate, data)
+	assert.NoError(t, err)
+	assert.Equal(t, "# 2023-03\n\nTop 2\n", introduction)
+}
+
+func Test_ExecuteExtract_introFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+	defer func() { _ = extractCmd.PersistentFlags().Set("intro-file", "") }()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "2023-03", "-p", "12", "-t", "10", "--history=false", "-o", outputFile, "--intro-file", "../test_data/intro_template.md"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "# Jenkins contributors of 2023-03\n\nThe 10 most active submitters opened ")
+	assert.Contains(t, string(content), "between 2022-04 and 2023-03 (12 months).\n")
+	assert.NotContains(t, string(content), "# Top Submitters")
+}
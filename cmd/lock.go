@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var lockFileName string
+var lockWait time.Duration
+var lockStaleAfter time.Duration
+
+// The lock held by the current run (nil if none)
+var heldLock *runLock
+
+// A lock file preventing concurrent runs (ex: overlapping cron jobs) writing in the same output directory.
+// It is created exclusively, so it works the same way on all the platforms and on shared file systems.
+type runLock struct {
+	fileName string
+}
+
+// Takes the lock, waiting up to "wait" for the run holding it to finish. A lock older than
+// staleAfter is left over from a crashed run: it is taken over.
+func acquireLock(ctx context.Context, fileName string, wait time.Duration, staleAfter time.Duration, now func() time.Time) (*runLock, error) {
+	deadline := now().Add(wait)
+	for {
+		f, err := os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			hostname, _ := os.Hostname()
+			fmt.Fprintf(f, "pid=%d host=%s started=%s\n", os.Getpid(), hostname, now().UTC().Format(time.RFC3339))
+			if err := f.Close(); err != nil {
+				return nil, err
+			}
+			logVerbose("Lock \"%s\" acquired\n", fileName)
+			return &runLock{fileName: fileName}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("Unable to create the lock file \"%s\": %v", fileName, err)
+		}
+
+		if info, statErr := os.Stat(fileName); statErr == nil && staleAfter > 0 && now().Sub(info.ModTime()) > staleAfter {
+			warn("Stale lock \"%s\" (created %s) taken over", fileName, info.ModTime().UTC().Format(time.RFC3339))
+			if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			continue
+		}
+		if !now().Before(deadline) {
+			holder, _ := os.ReadFile(fileName)
+			return nil, fmt.Errorf("Another run holds the lock \"%s\" (%s)", fileName, strings.TrimSpace(strings.SplitN(string(holder), "\n", 2)[0]))
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Releases the lock (can be called several times)
+func (l *runLock) release() {
+	if l == nil || l.fileName == "" {
+		return
+	}
+	if err := os.Remove(l.fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+		warn("Unable to remove the lock file \"%s\": %v", l.fileName, err)
+	}
+	l.fileName = ""
+}
+
+// Takes the lock requested with "--lock-file", if any
+func lockRun(ctx context.Context) error {
+	if lockFileName == "" {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	lock, err := acquireLock(ctx, lockFileName, lockWait, lockStaleAfter, time.Now)
+	if err != nil {
+		return err
+	}
+	heldLock = lock
+	return nil
+}
+
+// Releases the lock of the run, if any
+func unlockRun() {
+	heldLock.release()
+	heldLock = nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_acquireLock(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "run.lock")
+	ctx := context.Background()
+
+	lock, err := acquireLock(ctx, lockFile, 0, time.Hour, time.Now)
+	assert.NoError(t, err)
+	assert.FileExists(t, lockFile)
+
+	// A concurrent run fails
+	_, err = acquireLock(ctx, lockFile, 0, time.Hour, time.Now)
+	assert.ErrorContains(t, err, "Another run holds the lock")
+
+	lock.release()
+	lock.release()
+	assert.NoFileExists(t, lockFile)
+
+	lock, err = acquireLock(ctx, lockFile, 0, time.Hour, time.Now)
+	assert.NoError(t, err)
+	lock.release()
+}
+
+func Test_acquireLock_stale(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "run.lock")
+	assert.NoError(t, os.WriteFile(lockFile, []byte("pid=1"), 0644))
+	later := func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	lock, err := acquireLock(context.Background(), lockFile, 0, time.Hour, later)
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(lockFile)
+	assert.Contains(t, string(content), "pid=")
+	assert.NotEqual(t, "pid=1", string(content))
+	lock.release()
+}
+
+func Test_acquireLock_canceled(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "run.lock")
+	assert.NoError(t, os.WriteFile(lockFile, []byte("pid=1"), 0644))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := acquireLock(ctx, lockFile, time.Minute, time.Hour, time.Now)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ExecuteCheck_locked(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "run.lock")
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("lock-file", "")
+	}()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/deleted_user_case.csv", "--lock-file", lockFile})
+	assert.NoError(t, rootCmd.Execute())
+	assert.NoFileExists(t, lockFile)
+
+	assert.NoError(t, os.WriteFile(lockFile, []byte("pid=1"), 0644))
+	rootCmd.SetArgs([]string{"check", "../test_data/deleted_user_case.csv", "--lock-file", lockFile})
+	assert.ErrorContains(t, rootCmd.Execute(), "Another run holds the lock")
+}
+
+func Test_executeRoot_releasesLockOnFailure(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "run.lock")
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("lock-file", "")
+		checkCmd.SilenceErrors = false
+		checkCmd.SilenceUsage = false
+	}()
+
+	// A failed check exits with 1, and doesn't leave the lock behind for the next run
+	rootCmd.SetArgs([]string{"check", "../test_data/multiple_errors.csv", "--lock-file", lockFile})
+	assert.Equal(t, 1, executeRoot(context.Background()))
+	assert.NoFileExists(t, lockFile)
+
+	rootCmd.SetArgs([]string{"check", "../test_data/deleted_user_case.csv", "--lock-file", lockFile})
+	assert.Equal(t, 0, executeRoot(context.Background()))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Verbosity levels
+const (
+	levelQuiet   = -1 // only the results and the errors
+	levelNormal  = 0  // progress information
+	levelVerbose = 1  // details of the processing ("-v")
+	levelDebug   = 2  // details of the checks done on the input file ("-vv")
+)
+
+var verbosityCount int
+var isQuiet bool
+
+// Where the progress information is written
+var logOutput io.Writer = os.Stdout
+
+// Returns the verbosity level requested with the global flags
+func verbosityLevel() int {
+	if isQuiet {
+		return levelQuiet
+	}
+	if verbosityCount > levelDebug {
+		return levelDebug
+	}
+	return verbosityCount
+}
+
+// Is the verbose output requested ("-v" or more) ?
+func isVerbose() bool {
+	return verbosityLevel() >= levelVerbose
+}
+
+// Writes the message if the requested verbosity is at least the given level
+func logAt(level int, format string, a ...any) {
+	if verbosityLevel() >= level {
+		fmt.Fprintf(logOutput, format, a...)
+	}
+}
+
+// Writes progress information (suppressed by "--quiet")
+func logInfo(format string, a ...any) {
+	logAt(levelNormal, format, a...)
+}
+
+// Writes processing details ("-v")
+func logVerbose(format string, a ...any) {
+	logAt(levelVerbose, format, a...)
+}
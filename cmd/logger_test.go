@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verbosityLevel(t *testing.T) {
+	defer func() { verbosityCount = 0; isQuiet = false }()
+
+	tests := []struct {
+		name           string
+		verbosityCount int
+		isQuiet        bool
+		want           int
+	}{
+		{"default", 0, false, levelNormal},
+		{"verbose", 1, false, levelVerbose},
+		{"very verbose", 2, false, levelDebug},
+		{"capped", 5, false, levelDebug},
+		{"quiet", 0, true, levelQuiet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verbosityCount = tt.verbosityCount
+			isQuiet = tt.isQuiet
+			assert.Equal(t, tt.want, verbosityLevel())
+		})
+	}
+}
+
+func Test_logLevels(t *testing.T) {
+	out := new(bytes.Buffer)
+	logOutput = out
+	defer func() { logOutput = os.Stdout; verbosityCount = 0; isQuiet = false }()
+
+	logInfo("info\n")
+	logVerbose("verbose\n")
+	assert.Equal(t, "info\n", out.String())
+
+	out.Reset()
+	isQuiet = true
+	logInfo("info\n")
+	assert.Empty(t, out.String())
+
+	out.Reset()
+	isQuiet = false
+	verbosityCount = 2
+	logInfo("info\n")
+	logVerbose("verbose\n")
+	logAt(levelDebug, "debug\n")
+	assert.Equal(t, "info\nverbose\ndebug\n", out.String())
+}
+
+func Test_checkFileDetails(t *testing.T) {
+	out := new(bytes.Buffer)
+	logOutput = out
+	defer func() { logOutput = os.Stdout; verbosityCount = 0 }()
+
+	// With "-v", the details are displayed by the check command but not
+	// when the check is embedded in another command.
+	verbosityCount = 1
+	assert.True(t, checkFile("../test_data/deleted_user_case.csv", true))
+	assert.NotContains(t, out.String(), "Checking file format")
+
+	assert.True(t, checkFile("../test_data/deleted_user_case.csv", false))
+	assert.Contains(t, out.String(), "Checking file format")
+}
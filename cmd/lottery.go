@@ -0,0 +1,225 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var lotteryOutputFileName string
+var lotteryWinners int
+var lotteryPeriod int
+var lotterySeed int64
+var lotteryExcluded []string
+var lotteryExcludeFileName string
+
+// A winner of the lottery
+type lotteryWinner struct {
+	Draw    int
+	User    string
+	Tickets int     // PRs of the period
+	Chance  float64 // chance to win this draw (in percent)
+}
+
+// lotteryCmd represents the lottery command
+var lotteryCmd = &cobra.Command{
+	Use:   "lottery [input file]",
+	Short: "Draws winners among the active contributors, weighted by their activity",
+	Long: `The LOTTERY command draws winners (ex: for a swag giveaway) among the contributors
+active during the period (by default the last 3 months). Each PR of the period is a ticket:
+a contributor with 10 PRs is 10 times more likely to win than one with a single PR. A
+contributor can only win once.
+
+Some contributors (ex: the organizers) can be excluded with "--exclude" or with a file
+listing a user per line ("--exclude-file", the lines starting with "#" are comments).
+
+The draw is reproducible: the same seed, input and exclusions always give the same winners.
+Publish the seed with the results so that anyone can check the draw. By default, the seed
+is derived from the last month of the period (ex: 202304).
+
+The winners are written in the output file, its format being selected by its extension
+or with "--format".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		if lotteryWinners < 1 {
+			return fmt.Errorf("At least one winner must be drawn\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		excluded := lotteryExcluded
+		if lotteryExcludeFileName != "" {
+			users, err := loadUserList(lotteryExcludeFileName)
+			if err != nil {
+				return err
+			}
+			excluded = append(excluded, users...)
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		periodDataset, err := dataset.Period(endMonth, lotteryPeriod)
+		if err != nil {
+			return err
+		}
+		seed := lotterySeed
+		if seed == 0 {
+			seed = defaultSampleSeed(periodDataset.Months[len(periodDataset.Months)-1])
+		}
+
+		winners := drawLottery(periodDataset.Leaderboard(), excluded, lotteryWinners, seed)
+		if len(winners) < lotteryWinners {
+			warn("Only %d contributors can win, less than the %d requested winners", len(winners), lotteryWinners)
+		}
+
+		if dirErr := CheckDir(lotteryOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		introduction := fmt.Sprintf("# Lottery\n\nDraw among the contributors active between %s and %s, with the seed %d.\n\n",
+			periodDataset.Months[0], periodDataset.Months[len(periodDataset.Months)-1], seed)
+		options := renderOptions{Title: "Lottery", Introduction: introduction, InputType: InputTypeSubmitters}
+		if err := writeResultTable(lotteryOutputFileName, lotteryAsTable(winners), options); err != nil {
+			return err
+		}
+		for _, winner := range winners {
+			logInfo("%d. %s (%d tickets, %.1f%% chance)\n", winner.Draw, winner.User, winner.Tickets, winner.Chance)
+		}
+		logInfo("Winners drawn with the seed %d written to \"%s\"\n", seed, lotteryOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(lotteryCmd)
+
+	lotteryCmd.Flags().StringVarP(&lotteryOutputFileName, "out", "o", "lottery-winners.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	lotteryCmd.Flags().IntVarP(&lotteryWinners, "winners", "n", 3, "Number of winners to draw")
+	lotteryCmd.Flags().IntVarP(&lotteryPeriod, "period", "p", 3, "Number of months of the period (0 for all).")
+	lotteryCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Last month of the period.")
+	lotteryCmd.Flags().Int64VarP(&lotterySeed, "seed", "", 0, "Seed of the draw (default is derived from the last month of the period)")
+	lotteryCmd.Flags().StringSliceVarP(&lotteryExcluded, "exclude", "", nil, "Users who can't win (comma separated)")
+	lotteryCmd.Flags().StringVarP(&lotteryExcludeFileName, "exclude-file", "", "", "File listing the users who can't win (one per line)")
+	addFormatFlag(lotteryCmd)
+
+	lotteryCmd.ValidArgsFunction = completeInputFile
+	_ = lotteryCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Loads a list of users (one per line, the empty lines and the ones starting with "#" are ignored)
+func loadUserList(fileName string) ([]string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var users []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		users = append(users, line)
+	}
+	return users, scanner.Err()
+}
+
+// Draws the winners, each ticket (PR) having the same chance. A winner's tickets are
+// removed before the next draw. The same seed always gives the same winners.
+func drawLottery(leaderboard []leaderboardEntry, excluded []string, nbrOfWinners int, seed int64) []lotteryWinner {
+	isExcluded := make(map[string]bool)
+	for _, user := range excluded {
+		isExcluded[strings.ToLower(user)] = true
+	}
+	var candidates []leaderboardEntry
+	totalTickets := 0
+	for _, entry := range leaderboard {
+		if isExcluded[strings.ToLower(entry.User)] && entry.Total > 0 {
+			recordTransformation(auditExclusion, []string{entry.User}, "\"%s\" excluded from the draw", entry.User)
+		}
+		if entry.Total > 0 && !isExcluded[strings.ToLower(entry.User)] && entry.User != "deleted_user" {
+			candidates = append(candidates, entry)
+			totalTickets += entry.Total
+		}
+	}
+	// The draw must not depend on the order of the input file
+	sort.Slice(candidates, func(i, j int) bool {
+		return strings.ToLower(candidates[i].User) < strings.ToLower(candidates[j].User)
+	})
+
+	random := rand.New(rand.NewSource(seed))
+	var winners []lotteryWinner
+	for len(winners) < nbrOfWinners && len(candidates) > 0 {
+		ticket := random.Intn(totalTickets)
+		winner := 0
+		for ticket >= candidates[winner].Total {
+			ticket -= candidates[winner].Total
+			winner++
+		}
+		entry := candidates[winner]
+		winners = append(winners, lotteryWinner{
+			Draw:    len(winners) + 1,
+			User:    entry.User,
+			Tickets: entry.Total,
+			Chance:  100 * float64(entry.Total) / float64(totalTickets),
+		})
+		totalTickets -= entry.Total
+		candidates = append(candidates[:winner], candidates[winner+1:]...)
+	}
+	return winners
+}
+
+// Returns the winners as a table (with a header line)
+func lotteryAsTable(winners []lotteryWinner) [][]string {
+	table := [][]string{{"Draw", "User", "Tickets", "Chance"}}
+	for _, winner := range winners {
+		table = append(table, []string{strconv.Itoa(winner.Draw), winner.User, strconv.Itoa(winner.Tickets), formatMetric(winner.Chance)})
+	}
+	return table
+}
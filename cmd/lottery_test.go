@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_drawLottery(t *testing.T) {
+	leaderboard := []leaderboardEntry{
+		{Rank: 1, User: "alpha", Total: 20},
+		{Rank: 2, User: "bravo", Total: 10},
+		{Rank: 3, User: "charlie", Total: 5},
+		{Rank: 4, User: "deleted_user", Total: 4},
+		{Rank: 5, User: "delta", Total: 1},
+		{Rank: 6, User: "echo", Total: 0},
+	}
+
+	winners := drawLottery(leaderboard, []string{"Alpha"}, 2, 42)
+
+	assert.Len(t, winners, 2)
+	assert.Equal(t, 1, winners[0].Draw)
+	assert.NotEqual(t, winners[0].User, winners[1].User)
+	for _, winner := range winners {
+		assert.Contains(t, []string{"bravo", "charlie", "delta"}, winner.User)
+	}
+	// The first draw is among 16 tickets
+	assert.InDelta(t, 100*float64(winners[0].Tickets)/16, winners[0].Chance, 1e-9)
+
+	// Reproducible
+	assert.Equal(t, winners, drawLottery(leaderboard, []string{"alpha"}, 2, 42))
+
+	// Everybody wins if there are more prizes than contributors
+	assert.Len(t, drawLottery(leaderboard, nil, 10, 42), 4)
+}
+
+func Test_drawLottery_weights(t *testing.T) {
+	leaderboard := []leaderboardEntry{
+		{Rank: 1, User: "alpha", Total: 9},
+		{Rank: 2, User: "bravo", Total: 1},
+	}
+	wins := 0
+	for seed := int64(1); seed <= 1000; seed++ {
+		if drawLottery(leaderboard, nil, 1, seed)[0].User == "alpha" {
+			wins++
+		}
+	}
+	assert.InDelta(t, 900, wins, 50)
+}
+
+func Test_loadUserList(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "excluded.txt")
+	assert.NoError(t, os.WriteFile(fileName, []byte("# organizers\nalpha\n\n  bravo \n"), 0644))
+
+	users, err := loadUserList(fileName)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "bravo"}, users)
+}
+
+func Test_ExecuteLottery(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "winners.csv")
+
+	rootCmd.SetArgs([]string{"lottery", "../test_data/overview.csv", "-n", "3", "--seed", "2023", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "Draw,User,Tickets,Chance", lines[0])
+}
@@ -0,0 +1,278 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var milestonesOutputFileName string
+var milestonesHorizon int
+var milestonesActivePeriod int
+
+// A notable event, exported as an all-day calendar event
+type milestone struct {
+	Date        time.Time
+	UID         string // stable identifier, so that re-importing the file updates the events
+	Summary     string
+	Description string
+	Category    string
+}
+
+// milestonesCmd represents the milestones command
+var milestonesCmd = &cobra.Command{
+	Use:   "milestones [input file]",
+	Short: "Exports the notable milestones as an iCalendar (ICS) file",
+	Long: `The MILESTONES command generates an iCalendar file with the notable milestones
+found in the pivot table, to be imported in a planning calendar:
+  - the months setting a new all-time monthly record (total of the month),
+  - the anniversaries of the first contribution of the contributors still active
+    (during the last "--active-period" months). The anniversaries falling in the
+    "--horizon" months following the last month of the data are exported.
+
+As the data only starts with the first month of the pivot table, the contributors
+already active that month are not considered for the anniversaries.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		milestones, err := findMonthlyRecords(dataset)
+		if err != nil {
+			return err
+		}
+		anniversaries, err := findAnniversaries(dataset, milestonesActivePeriod, milestonesHorizon)
+		if err != nil {
+			return err
+		}
+		milestones = append(milestones, anniversaries...)
+
+		if dirErr := CheckDir(milestonesOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		f, err := os.Create(milestonesOutputFileName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := writeICS(f, milestones, time.Now()); err != nil {
+			return err
+		}
+
+		logInfo("%d milestones written to \"%s\"\n", len(milestones), milestonesOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(milestonesCmd)
+
+	milestonesCmd.Flags().StringVarP(&milestonesOutputFileName, "out", "o", "milestones.ics", "Output file name")
+	milestonesCmd.Flags().IntVarP(&milestonesHorizon, "horizon", "", 12, "Number of months, after the data, for which the anniversaries are exported")
+	milestonesCmd.Flags().IntVarP(&milestonesActivePeriod, "active-period", "", 12, "Number of months used to select the active contributors")
+
+	milestonesCmd.ValidArgsFunction = completeInputFile
+}
+
+// Returns the months whose total is higher than the total of all the previous months.
+// The months without any data are neither records nor compared with.
+func findMonthlyRecords(dataset *Dataset) ([]milestone, error) {
+	var milestones []milestone
+	currentRecord := -1
+	totals := dataset.MonthTotals()
+	for column, monthName := range dataset.Months {
+		month, err := time.Parse("2006-01", monthName)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid month \"%s\" in the header", monthName)
+		}
+		if !dataset.hasMonthData(column) {
+			continue
+		}
+		total := totals[column]
+		// The first month is not a record: there is nothing to compare with
+		if currentRecord >= 0 && total > currentRecord {
+			milestones = append(milestones, milestone{
+				Date:        month,
+				UID:         "record-" + monthName,
+				Summary:     fmt.Sprintf("New all-time monthly record: %d in %s", total, monthName),
+				Description: fmt.Sprintf("%d in %s, the previous record was %d.", total, monthName, currentRecord),
+				Category:    "Record",
+			})
+		}
+		if total > currentRecord {
+			currentRecord = total
+		}
+	}
+	return milestones, nil
+}
+
+// Returns the anniversaries of the first contribution of the active contributors, falling
+// in the horizonMonths months following the last month of the data.
+func findAnniversaries(dataset *Dataset, activePeriod int, horizonMonths int) ([]milestone, error) {
+	months := dataset.Months
+	lastMonth, err := time.Parse("2006-01", months[len(months)-1])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid month \"%s\" in the header", months[len(months)-1])
+	}
+	horizonEnd := lastMonth.AddDate(0, horizonMonths, 0)
+	firstActiveColumn := len(months) - activePeriod
+	if activePeriod <= 0 || firstActiveColumn < 0 {
+		firstActiveColumn = 0
+	}
+	// The data starts with the first month having data
+	firstDataColumn := 0
+	for firstDataColumn < len(months)-1 && !dataset.hasMonthData(firstDataColumn) {
+		firstDataColumn++
+	}
+
+	var milestones []milestone
+	for i, user := range dataset.Users {
+		if user == "deleted_user" {
+			continue
+		}
+		firstColumn, isActive := -1, false
+		for column, value := range dataset.Counts[i] {
+			if value > 0 {
+				if firstColumn == -1 {
+					firstColumn = column
+				}
+				if column >= firstActiveColumn {
+					isActive = true
+				}
+			}
+		}
+		// Contributions before the data can't be excluded for those already active the first month
+		if firstColumn <= firstDataColumn || !isActive {
+			continue
+		}
+
+		firstMonth, err := time.Parse("2006-01", months[firstColumn])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid month \"%s\" in the header", months[firstColumn])
+		}
+		for years := 1; ; years++ {
+			anniversary := firstMonth.AddDate(years, 0, 0)
+			if anniversary.After(horizonEnd) {
+				break
+			}
+			if !anniversary.After(lastMonth) {
+				continue
+			}
+			yearText := "years"
+			if years == 1 {
+				yearText = "year"
+			}
+			milestones = append(milestones, milestone{
+				Date:        anniversary,
+				UID:         fmt.Sprintf("anniversary-%s-%d", strings.ToLower(user), years),
+				Summary:     fmt.Sprintf("%s: %d %s of contributions", user, years, yearText),
+				Description: fmt.Sprintf("%s first contributed in %s.", user, months[firstColumn]),
+				Category:    "Anniversary",
+			})
+		}
+	}
+
+	sort.SliceStable(milestones, func(i, j int) bool { return milestones[i].Date.Before(milestones[j].Date) })
+	return milestones, nil
+}
+
+// Writes the milestones as an iCalendar (RFC 5545) file of all-day events
+func writeICS(out io.Writer, milestones []milestone, now time.Time) error {
+	w := bufio.NewWriter(out)
+	writeLine := func(line string) {
+		w.WriteString(foldICSLine(line) + "\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//jenkins-infra//jenkins-contribution-aggregator//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("X-WR-CALNAME:Jenkins contribution milestones")
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, m := range milestones {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + m.UID + "@jenkins-contribution-aggregator")
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART;VALUE=DATE:" + m.Date.Format("20060102"))
+		writeLine("DTEND;VALUE=DATE:" + m.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine("SUMMARY:" + escapeICSText(m.Summary))
+		writeLine("DESCRIPTION:" + escapeICSText(m.Description))
+		writeLine("CATEGORIES:" + escapeICSText(m.Category))
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+	return w.Flush()
+}
+
+// Escapes the special characters of an iCalendar text value
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// Folds the lines longer than 75 octets (the continuation lines start with a space)
+func foldICSLine(line string) string {
+	const maxLength = 75
+	if len(line) <= maxLength {
+		return line
+	}
+	var b strings.Builder
+	length := 0
+	for _, r := range line {
+		runeLength := len(string(r))
+		if length+runeLength > maxLength {
+			b.WriteString("\r\n ")
+			// the leading space counts in the length of the line
+			length = 1
+		}
+		b.WriteRune(r)
+		length += runeLength
+	}
+	return b.String()
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findMonthlyRecords(t *testing.T) {
+	dataset, _ := newDataset("test", [][]string{
+		{"", "2022-10", "2022-11", "2022-12", "2023-01", "2023-02"},
+		{"alpha", "", "1", "3", "0", "8"},
+		{"bravo", "", "2", "2", "2", "2"},
+	})
+
+	// The first month has no data: 2022-11 is not a record
+	milestones, err := findMonthlyRecords(dataset)
+
+	assert.NoError(t, err)
+	assert.Len(t, milestones, 2)
+	assert.Equal(t, time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), milestones[0].Date)
+	assert.Equal(t, "record-2022-12", milestones[0].UID)
+	assert.Equal(t, "New all-time monthly record: 5 in 2022-12", milestones[0].Summary)
+	assert.Equal(t, "10 in 2023-02, the previous record was 5.", milestones[1].Description)
+}
+
+func Test_findAnniversaries(t *testing.T) {
+	dataset, _ := newDataset("test", [][]string{
+		{"", "2021-01", "2021-02", "2021-03", "2022-01", "2022-02", "2022-03"},
+		{"early", "1", "0", "0", "0", "0", "1"},
+		{"newcomer", "0", "2", "0", "0", "0", "1"},
+		{"gone", "0", "0", "1", "0", "0", "0"},
+		{"deleted_user", "0", "1", "0", "0", "0", "1"},
+	})
+
+	milestones, err := findAnniversaries(dataset, 2, 12)
+
+	assert.NoError(t, err)
+	// "early" may have contributed before the data, "gone" is no longer active
+	assert.Len(t, milestones, 1)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), milestones[0].Date)
+	assert.Equal(t, "anniversary-newcomer-2", milestones[0].UID)
+	assert.Equal(t, "newcomer: 2 years of contributions", milestones[0].Summary)
+
+	// The 2 anniversaries of "newcomer" are out of the horizon
+	milestones, err = findAnniversaries(dataset, 2, 6)
+	assert.NoError(t, err)
+	assert.Empty(t, milestones)
+}
+
+func Test_writeICS(t *testing.T) {
+	milestones := []milestone{
+		{
+			Date:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			UID:         "record-2023-02",
+			Summary:     "New record; 10, yes",
+			Description: strings.Repeat("x", 80),
+			Category:    "Record",
+		},
+	}
+	out := new(bytes.Buffer)
+
+	err := writeICS(out, milestones, time.Date(2023, 3, 4, 5, 6, 7, 0, time.UTC))
+
+	assert.NoError(t, err)
+	expected := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//jenkins-infra//jenkins-contribution-aggregator//EN\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"X-WR-CALNAME:Jenkins contribution milestones\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:record-2023-02@jenkins-contribution-aggregator\r\n" +
+		"DTSTAMP:20230304T050607Z\r\n" +
+		"DTSTART;VALUE=DATE:20230201\r\n" +
+		"DTEND;VALUE=DATE:20230202\r\n" +
+		"SUMMARY:New record\\; 10\\, yes\r\n" +
+		"DESCRIPTION:" + strings.Repeat("x", 63) + "\r\n " + strings.Repeat("x", 17) + "\r\n" +
+		"CATEGORIES:Record\r\n" +
+		"TRANSP:TRANSPARENT\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_ExecuteMilestones(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "milestones.ics")
+
+	rootCmd.SetArgs([]string{"milestones", "../test_data/deleted_user_case.csv", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, string(content), "CATEGORIES:Record\r\n")
+	assert.True(t, strings.HasSuffix(string(content), "END:VCALENDAR\r\n"))
+}
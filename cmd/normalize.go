@@ -0,0 +1,251 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var normalizeOutputFileName string
+var normalizeAliasesFileName string
+var normalizeMergePolicy string
+
+// How the values are combined when two lines are merged
+const (
+	mergePolicySum   = "sum"   // the values are added
+	mergePolicyMax   = "max"   // the highest value is kept
+	mergePolicyError = "error" // merging is not allowed
+)
+
+// Two lines of the pivot table merged as their users have the same (canonical) name
+type pivotMerge struct {
+	From string // the user whose line was merged
+	Into string // the name of the resulting line
+	Line int    // line of the merged user in the input (starting at 1)
+}
+
+// normalizeCmd represents the normalize command
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize [input file]",
+	Short: "Rewrites the usernames with their canonical GitHub login",
+	Long: `The NORMALIZE command resolves each username of the pivot table with the GitHub API.
+The usernames are replaced by the current login of the account, with its canonical
+casing (renamed accounts are followed). The lines mapping to the same account are
+merged (their values are added).
+
+The usernames unknown to GitHub are kept as is (and reported as warnings).
+
+Every merge is logged. By default, the values of the merged lines are added. With
+"--merge-policy max", the highest value of each month is kept instead (ex: when the
+same contributions were counted under both names). With "--merge-policy error", the
+command fails as soon as two lines would be merged.
+
+With "--aliases", the names are instead rewritten using an alias file (as generated
+by the DETECT-RENAMES command), without calling GitHub.
+
+A GitHub token (or a GitHub App, see "--github-app-id") is strongly advised, as anonymous
+calls are limited to 60 per hour.
+By default, the result is written to the input file name with a "_normalized" suffix.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		switch normalizeMergePolicy {
+		case mergePolicySum, mergePolicyMax, mergePolicyError:
+		default:
+			return fmt.Errorf("Invalid merge policy \"%s\" (should be \"sum\", \"max\" or \"error\")\n", normalizeMergePolicy)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		var canonicalNames map[string]string
+		if normalizeAliasesFileName != "" {
+			canonicalNames, err = loadAliases(normalizeAliasesFileName)
+			if err != nil {
+				return err
+			}
+		} else {
+			var unknownUsers []string
+			client, clientErr := newAuthenticatedGitHubClient(cmd.Context())
+			if clientErr != nil {
+				return clientErr
+			}
+			cache, cacheErr := openGitHubCache()
+			if cacheErr != nil {
+				return cacheErr
+			}
+			canonicalNames, unknownUsers, err = resolveCanonicalNames(cmd.Context(), client, cache, dataset.Users)
+			cache.close()
+			if err != nil {
+				return err
+			}
+			for _, user := range unknownUsers {
+				warn("User \"%s\" not found on GitHub, kept as is", user)
+			}
+		}
+		normalized, merges, err := mergeDatasetUsers(dataset, canonicalNames, normalizeMergePolicy)
+		if err != nil {
+			return err
+		}
+		renamedUsers := make([]string, 0, len(canonicalNames))
+		for user := range canonicalNames {
+			renamedUsers = append(renamedUsers, user)
+		}
+		sort.Strings(renamedUsers)
+		for _, user := range renamedUsers {
+			recordTransformation(auditNormalization, []string{user}, "\"%s\" renamed \"%s\"", user, canonicalNames[user])
+		}
+		for _, merge := range merges {
+			logInfo("Line %d: \"%s\" merged into \"%s\" (%s)\n", merge.Line, merge.From, merge.Into, normalizeMergePolicy)
+			recordTransformation(auditMerge, []string{merge.From, merge.Into}, "Line %d: \"%s\" merged into \"%s\" (%s)", merge.Line, merge.From, merge.Into, normalizeMergePolicy)
+		}
+
+		outputFileName := normalizeOutputFileName
+		if outputFileName == "" {
+			extension := filepath.Ext(args[0])
+			outputFileName = strings.TrimSuffix(args[0], extension) + "_normalized" + extension
+		}
+		if dirErr := CheckDir(outputFileName); dirErr != nil {
+			return dirErr
+		}
+		if err := writeCSVtoFile(outputFileName, normalized.Records()); err != nil {
+			return err
+		}
+
+		logInfo("%d usernames rewritten, %d lines merged, written to \"%s\"\n", len(canonicalNames), len(dataset.Users)-len(normalized.Users), outputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(normalizeCmd)
+
+	normalizeCmd.Flags().StringVarP(&normalizeOutputFileName, "out", "o", "", "Output file name (default is the input file name with a \"_normalized\" suffix)")
+	normalizeCmd.Flags().StringVarP(&normalizeAliasesFileName, "aliases", "", "", "Alias file (\"alias,login\" CSV) to use instead of querying GitHub")
+	normalizeCmd.Flags().StringVarP(&normalizeMergePolicy, "merge-policy", "", mergePolicySum, "How the values of merged lines are combined (\"sum\", \"max\" or \"error\")")
+	_ = normalizeCmd.RegisterFlagCompletionFunc("merge-policy", cobra.FixedCompletions([]string{mergePolicySum, mergePolicyMax, mergePolicyError}, cobra.ShellCompDirectiveNoFileComp))
+	addGitHubTokenFlag(normalizeCmd)
+	addGitHubCacheFlags(normalizeCmd)
+
+	normalizeCmd.ValidArgsFunction = completeInputFile
+}
+
+// Queries GitHub for each username and returns the ones whose login differs (casing
+// or rename), associated with their canonical login, and the ones unknown to GitHub.
+func resolveCanonicalNames(ctx context.Context, client *githubClient, cache *githubCache, users []string) (canonicalNames map[string]string, unknownUsers []string, err error) {
+	canonicalNames = make(map[string]string)
+	now := time.Now()
+	for _, name := range users {
+		if name == "deleted_user" {
+			continue
+		}
+		user, err := cache.getUser(ctx, client, name, now)
+		if errors.Is(err, errGitHubNotFound) {
+			unknownUsers = append(unknownUsers, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if user.Login != name {
+			logVerbose("  %s -> %s\n", name, user.Login)
+			canonicalNames[name] = user.Login
+		}
+	}
+	return canonicalNames, unknownUsers, nil
+}
+
+// Renames the users of the dataset. The users ending up with the same name are
+// merged, following the policy, at the position of the first one.
+func mergeDatasetUsers(dataset *Dataset, newNames map[string]string, policy string) (*Dataset, []pivotMerge, error) {
+	merged := &Dataset{Name: dataset.Name, Months: dataset.Months}
+	var merges []pivotMerge
+	position := make(map[string]int)
+	for i, user := range dataset.Users {
+		name := user
+		if newName, isRenamed := newNames[user]; isRenamed {
+			name = newName
+		}
+
+		existing, isKnown := position[name]
+		if !isKnown {
+			position[name] = len(merged.Users)
+			merged.Users = append(merged.Users, name)
+			merged.Counts = append(merged.Counts, append([]int(nil), dataset.Counts[i]...))
+			for month := range dataset.Months {
+				if dataset.isMissing(i, month) {
+					merged.setMissing(len(merged.Users)-1, month)
+				}
+			}
+			continue
+		}
+
+		// The header is line 1 of the input
+		if policy == mergePolicyError {
+			return nil, nil, fmt.Errorf("\"%s\" (line %d) would be merged into \"%s\" (merge policy \"error\")", user, i+2, name)
+		}
+		for month, count := range dataset.Counts[i] {
+			// A month without data doesn't change the other value, and stays without data if both are
+			switch {
+			case dataset.isMissing(i, month):
+			case merged.isMissing(existing, month):
+				merged.Counts[existing][month] = count
+				merged.Missing[existing][month] = false
+			case policy == mergePolicyMax:
+				if count > merged.Counts[existing][month] {
+					merged.Counts[existing][month] = count
+				}
+			default:
+				merged.Counts[existing][month] += count
+			}
+		}
+		merges = append(merges, pivotMerge{From: user, Into: name, Line: i + 2})
+	}
+	if merged.Missing != nil {
+		merged.setMissing(len(merged.Users)-1, -1)
+	}
+	return merged, merges, nil
+}
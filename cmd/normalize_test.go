@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeDatasetUsers(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2023-01", "2023-02"},
+		{"markewaite", "1", "2"},
+		{"alpha", "0", "1"},
+		{"MarkEWaite", "3", "0"},
+		{"old-name", "1", "1"},
+	})
+	assert.NoError(t, err)
+	newNames := map[string]string{"markewaite": "MarkEWaite", "old-name": "alpha"}
+
+	merged, merges, err := mergeDatasetUsers(dataset, newNames, mergePolicySum)
+
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"", "2023-01", "2023-02"},
+		{"MarkEWaite", "4", "2"},
+		{"alpha", "1", "2"},
+	}
+	assert.Equal(t, expected, merged.Records())
+	assert.Equal(t, []pivotMerge{{From: "MarkEWaite", Into: "MarkEWaite", Line: 4}, {From: "old-name", Into: "alpha", Line: 5}}, merges)
+	// The input is not modified
+	assert.Equal(t, "markewaite", dataset.Users[0])
+	assert.Equal(t, 1, dataset.Counts[0][0])
+
+	merged, _, err = mergeDatasetUsers(dataset, newNames, mergePolicyMax)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"", "2023-01", "2023-02"},
+		{"MarkEWaite", "3", "2"},
+		{"alpha", "1", "1"},
+	}, merged.Records())
+
+	_, _, err = mergeDatasetUsers(dataset, newNames, mergePolicyError)
+	assert.ErrorContains(t, err, "\"MarkEWaite\" (line 4) would be merged into \"MarkEWaite\" (merge policy \"error\")")
+}
+
+func Test_mergeDatasetUsers_missingMonths(t *testing.T) {
+	dataset, err := newDataset("test", [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alice", "1", "", ""},
+		{"bob", "", "2", ""},
+	})
+	assert.NoError(t, err)
+
+	merged, _, err := mergeDatasetUsers(dataset, map[string]string{"bob": "alice"}, mergePolicySum)
+
+	assert.NoError(t, err)
+	// The months without data of both users stay empty
+	assert.Equal(t, [][]string{{"", "2023-01", "2023-02", "2023-03"}, {"alice", "1", "2", ""}}, merged.Records())
+}
+
+func Test_resolveCanonicalNames(t *testing.T) {
+	startFakeGitHub(t, map[string]githubUser{
+		"markewaite": {Login: "MarkEWaite", ID: 1},
+		"alpha":      {Login: "alpha", ID: 2},
+		"old-name":   {Login: "new-name", ID: 3},
+	})
+	users := []string{"markewaite", "alpha", "old-name", "ghost", "deleted_user"}
+
+	cache, err := loadGitHubCache("", time.Hour)
+	assert.NoError(t, err)
+
+	canonicalNames, unknownUsers, err := resolveCanonicalNames(context.Background(), newGitHubClient(""), cache, users)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"markewaite": "MarkEWaite", "old-name": "new-name"}, canonicalNames)
+	assert.Equal(t, []string{"ghost"}, unknownUsers)
+	assert.Len(t, cache.namespaces[cacheNamespaceUsers], 4)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Destination of the uploaded files
+type objectStore interface {
+	upload(ctx context.Context, key string, contentType string, content []byte) error
+}
+
+// Parses an object storage URL ("s3://bucket/path" or "gs://bucket/path")
+func parseObjectStoreURL(destination string) (scheme string, bucket string, prefix string, err error) {
+	u, err := url.Parse(destination)
+	if err != nil || u.Host == "" {
+		return "", "", "", fmt.Errorf("Invalid destination \"%s\" (expecting \"s3://bucket/path\" or \"gs://bucket/path\")", destination)
+	}
+	switch u.Scheme {
+	case "s3", "gs":
+	default:
+		return "", "", "", fmt.Errorf("Unsupported destination \"%s\" (only \"s3://\" and \"gs://\" are supported)", destination)
+	}
+	return u.Scheme, u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// AWS credentials, as used to sign the requests
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// Reads the AWS credentials from the standard environment variables
+func awsCredentialsFromEnv() (awsCredentials, error) {
+	credentials := awsCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return credentials, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to upload to S3")
+	}
+	return credentials, nil
+}
+
+// Minimal S3 client (path style PUTs signed with AWS Signature Version 4)
+type s3Store struct {
+	endpoint    string
+	bucket      string
+	region      string
+	credentials awsCredentials
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+// Creates an S3 client. The endpoint defaults to the AWS one of the region (it can be
+// changed for S3 compatible storages).
+func newS3Store(endpoint string, bucket string, region string, credentials awsCredentials) *s3Store {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	return &s3Store{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bucket:      bucket,
+		region:      region,
+		credentials: credentials,
+		httpClient:  &http.Client{Timeout: 5 * time.Minute},
+		now:         time.Now,
+	}
+}
+
+// Uploads an object to the bucket
+func (s *s3Store) upload(ctx context.Context, key string, contentType string, content []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, s.endpoint+"/"+awsURIEncode(s.bucket+"/"+key, false), bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+	signAWSRequestV4(request, content, s.credentials, s.region, "s3", s.now())
+
+	return doObjectStoreRequest(s.httpClient, request, "s3://"+s.bucket+"/"+key)
+}
+
+// Signs the request with AWS Signature Version 4 (the signed headers are "host",
+// "content-type" and the "x-amz-*" ones).
+func signAWSRequestV4(request *http.Request, payload []byte, credentials awsCredentials, region string, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	if service == "s3" {
+		request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	if credentials.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		lowerName := strings.ToLower(name)
+		if lowerName == "content-type" || strings.HasPrefix(lowerName, "x-amz-") {
+			headers[lowerName] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		awsCanonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(credentials.SecretAccessKey, date, region, service), stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// Derives the key used to sign the requests of a day
+func awsSigningKey(secret string, date string, region string, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// Builds the canonical query string (sorted and encoded parameters)
+func awsCanonicalQuery(values url.Values) string {
+	var parameters []string
+	for name, list := range values {
+		for _, value := range list {
+			parameters = append(parameters, awsURIEncode(name, true)+"="+awsURIEncode(value, true))
+		}
+	}
+	sort.Strings(parameters)
+	return strings.Join(parameters, "&")
+}
+
+// Encodes a string as expected by AWS: everything but the unreserved characters
+// (and "/" when not encoding a query parameter) is percent encoded.
+func awsURIEncode(text string, isEncodingSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(text) {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !isEncodingSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Minimal Google Cloud Storage client (JSON API "media" uploads)
+type gcsStore struct {
+	endpoint   string
+	bucket     string
+	token      string
+	httpClient *http.Client
+}
+
+// Creates a GCS client using an OAuth2 access token (ex: "gcloud auth print-access-token")
+func newGCSStore(endpoint string, bucket string, token string) *gcsStore {
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	return &gcsStore{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		bucket:     bucket,
+		token:      token,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Uploads an object to the bucket
+func (g *gcsStore) upload(ctx context.Context, key string, contentType string, content []byte) error {
+	query := url.Values{"uploadType": {"media"}, "name": {key}}
+	uploadURL := g.endpoint + "/upload/storage/v1/b/" + url.PathEscape(g.bucket) + "/o?" + query.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Authorization", "Bearer "+g.token)
+
+	return doObjectStoreRequest(g.httpClient, request, "gs://"+g.bucket+"/"+key)
+}
+
+// Sends an upload request. Any non 2xx answer is an error.
+func doObjectStoreRequest(httpClient *http.Client, request *http.Request, destination string) error {
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to upload %s: %v", destination, err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("Failed to upload %s (%s): %s", destination, response.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
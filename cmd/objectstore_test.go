@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseObjectStoreURL(t *testing.T) {
+	scheme, bucket, prefix, err := parseObjectStoreURL("s3://stats-bucket/jenkins/top/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"s3", "stats-bucket", "jenkins/top"}, []string{scheme, bucket, prefix})
+
+	scheme, bucket, prefix, err = parseObjectStoreURL("gs://stats-bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gs", "stats-bucket", ""}, []string{scheme, bucket, prefix})
+
+	_, _, _, err = parseObjectStoreURL("https://example.com/path")
+	assert.Error(t, err)
+	_, _, _, err = parseObjectStoreURL("stats-bucket/path")
+	assert.Error(t, err)
+}
+
+// Example of the AWS Signature Version 4 documentation
+func Test_signAWSRequestV4(t *testing.T) {
+	credentials := awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	assert.Equal(t, "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9",
+		hex.EncodeToString(awsSigningKey(credentials.SecretAccessKey, "20150830", "us-east-1", "iam")))
+
+	request, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signAWSRequestV4(request, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", request.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		request.Header.Get("Authorization"))
+}
+
+func Test_awsURIEncode(t *testing.T) {
+	assert.Equal(t, "bucket/top%20submitters/a~b_c-d.csv", awsURIEncode("bucket/top submitters/a~b_c-d.csv", false))
+	assert.Equal(t, "a%2Fb%3Dc%2B", awsURIEncode("a/b=c+", true))
+}
+
+func Test_s3Store_upload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/stats/site/index.html", r.URL.Path)
+		assert.Equal(t, "text/html; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.Equal(t, sha256Hex([]byte("<html/>")), r.Header.Get("X-Amz-Content-Sha256"))
+		assert.Equal(t, "token", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/20230401/eu-west-1/s3/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token, Signature="))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "<html/>", string(body))
+	}))
+	defer server.Close()
+
+	store := newS3Store(server.URL, "stats", "eu-west-1", awsCredentials{AccessKeyID: "key", SecretAccessKey: "secret", SessionToken: "token"})
+	store.now = func() time.Time { return time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC) }
+
+	assert.NoError(t, store.upload(context.Background(), "site/index.html", "text/html; charset=utf-8", []byte("<html/>")))
+
+	// The default endpoint is the one of the region
+	assert.Equal(t, "https://s3.eu-west-1.amazonaws.com", newS3Store("", "stats", "eu-west-1", awsCredentials{}).endpoint)
+}
+
+func Test_gcsStore_upload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "denied.csv" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("access denied"))
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/upload/storage/v1/b/stats/o", r.URL.Path)
+		assert.Equal(t, "media", r.URL.Query().Get("uploadType"))
+		assert.Equal(t, "top/top-submitters.csv", r.URL.Query().Get("name"))
+		assert.Equal(t, "text/csv; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer gcs-token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	store := newGCSStore(server.URL, "stats", "gcs-token")
+
+	assert.NoError(t, store.upload(context.Background(), "top/top-submitters.csv", "text/csv; charset=utf-8", []byte("a,b\n")))
+	err := store.upload(context.Background(), "denied.csv", "text/csv; charset=utf-8", []byte("a,b\n"))
+	assert.ErrorContains(t, err, "access denied")
+}
@@ -0,0 +1,263 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var overlapOutputFileName string
+
+// The activity of a contributor in both datasets
+type overlapEntry struct {
+	User   string
+	TotalA int
+	TotalB int
+}
+
+// The result of the overlap analysis
+type overlapResult struct {
+	NameA        string
+	NameB        string
+	PeriodA      string // "from to to"
+	PeriodB      string
+	Entries      []overlapEntry
+	Both         int
+	OnlyA        int
+	OnlyB        int
+	Correlation  float64 // of the totals of the contributors active in both datasets
+	IsCorrelated bool    // false if the correlation can't be computed (less than 2 common contributors)
+}
+
+// overlapCmd represents the overlap command
+var overlapCmd = &cobra.Command{
+	Use:   "overlap [pivot table A] [pivot table B]",
+	Short: "Analyzes the overlap of the contributors of two pivot tables",
+	Long: `The OVERLAP command compares the contributors of two pivot tables (for example the
+jenkinsci and the jenkins-infra organizations) over the same period (see "--month" and
+"--period"). It reports the contributors active in both, the ones only active in one of
+them, and the correlation (Pearson) of the activity of the contributors active in both.
+
+The usernames are compared without taking the case into account.
+
+The output lists the contributors with their total in each pivot table. Its format is
+selected by the extension of the output file (".md" for Markdown) or with "--format".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		for _, fileName := range args {
+			if !isFileValid(fileName) {
+				return fmt.Errorf("Invalid input file %s\n", fileName)
+			}
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		var datasets []*Dataset
+		for _, fileName := range args {
+			if !checkFile(fileName, isSilent) {
+				return fmt.Errorf("Invalid input file %s.", fileName)
+			}
+			dataset, err := loadDataset(cmd.Context(), fileName)
+			if err != nil {
+				return err
+			}
+			datasets = append(datasets, dataset)
+		}
+
+		result, err := computeOverlap(datasets[0], datasets[1], endMonth, period)
+		if err != nil {
+			return err
+		}
+
+		if dirErr := CheckDir(overlapOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		options := renderOptions{Title: "Contributor overlap", Introduction: "# Contributor overlap\n\n" + result.summary(), InputType: InputTypeSubmitters}
+		if err := writeResultTable(overlapOutputFileName, result.asTable(), options); err != nil {
+			return err
+		}
+		logInfo("%s", result.summary())
+		logInfo("Overlap written to \"%s\"\n", overlapOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(overlapCmd)
+
+	overlapCmd.Flags().StringVarP(&overlapOutputFileName, "out", "o", "overlap.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	overlapCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate (0 for all).")
+	overlapCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Last month of the period.")
+	addFormatFlag(overlapCmd)
+
+	overlapCmd.ValidArgsFunction = completeInputFile
+	_ = overlapCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Returns the name of a dataset (the base name of the file, without extension)
+func datasetName(fileName string) string {
+	return strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+}
+
+// Computes the overlap of the contributors active during the period in the two pivot tables
+func computeOverlap(datasetA *Dataset, datasetB *Dataset, endMonth string, period int) (overlapResult, error) {
+	result := overlapResult{NameA: datasetA.Name, NameB: datasetB.Name}
+
+	totalsA, periodA, err := totalsOverPeriod(datasetA, endMonth, period)
+	if err != nil {
+		return result, err
+	}
+	totalsB, periodB, err := totalsOverPeriod(datasetB, endMonth, period)
+	if err != nil {
+		return result, err
+	}
+	result.PeriodA, result.PeriodB = periodA, periodB
+
+	entries := make(map[string]*overlapEntry)
+	var users []string
+	for _, user := range totalsA.users {
+		entries[strings.ToLower(user)] = &overlapEntry{User: user, TotalA: totalsA.totals[user]}
+		users = append(users, strings.ToLower(user))
+	}
+	for _, user := range totalsB.users {
+		entry, isFound := entries[strings.ToLower(user)]
+		if !isFound {
+			entry = &overlapEntry{User: user}
+			entries[strings.ToLower(user)] = entry
+			users = append(users, strings.ToLower(user))
+		}
+		entry.TotalB = totalsB.totals[user]
+	}
+
+	var commonA, commonB []float64
+	for _, user := range users {
+		entry := entries[user]
+		switch {
+		case entry.TotalA > 0 && entry.TotalB > 0:
+			result.Both++
+			commonA = append(commonA, float64(entry.TotalA))
+			commonB = append(commonB, float64(entry.TotalB))
+		case entry.TotalA > 0:
+			result.OnlyA++
+		default:
+			result.OnlyB++
+		}
+		result.Entries = append(result.Entries, *entry)
+	}
+	result.Correlation, result.IsCorrelated = pearsonCorrelation(commonA, commonB)
+
+	// The most active contributors (in both datasets) first
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		return result.Entries[i].TotalA+result.Entries[i].TotalB > result.Entries[j].TotalA+result.Entries[j].TotalB
+	})
+	return result, nil
+}
+
+// The totals of the active users of a pivot table, in the order of the pivot table
+type userTotals struct {
+	users  []string
+	totals map[string]int
+}
+
+// Returns the totals of the users active during the period, and the description of the period
+func totalsOverPeriod(dataset *Dataset, endMonth string, period int) (userTotals, string, error) {
+	periodDataset, err := dataset.Period(endMonth, period)
+	if err != nil {
+		return userTotals{}, "", err
+	}
+	result := userTotals{totals: make(map[string]int)}
+	for i, total := range periodDataset.UserTotals() {
+		if total > 0 {
+			result.users = append(result.users, periodDataset.Users[i])
+			result.totals[periodDataset.Users[i]] = total
+		}
+	}
+	months := periodDataset.Months
+	return result, months[0] + " to " + months[len(months)-1], nil
+}
+
+// Returns the Pearson correlation coefficient of two series. It can't be computed
+// (false is returned) with less than two values or if a series is constant.
+func pearsonCorrelation(x []float64, y []float64) (float64, bool) {
+	if len(x) < 2 || len(x) != len(y) {
+		return 0, false
+	}
+	meanX, stdDevX := meanAndStdDev(x)
+	meanY, stdDevY := meanAndStdDev(y)
+	if stdDevX == 0 || stdDevY == 0 {
+		return 0, false
+	}
+	covariance := 0.0
+	for i := range x {
+		covariance += (x[i] - meanX) * (y[i] - meanY)
+	}
+	covariance /= float64(len(x))
+	return math.Max(-1, math.Min(1, covariance/(stdDevX*stdDevY))), true
+}
+
+// Returns the status of a contributor ("both", "only <dataset>")
+func (result overlapResult) statusOf(entry overlapEntry) string {
+	switch {
+	case entry.TotalA > 0 && entry.TotalB > 0:
+		return "both"
+	case entry.TotalA > 0:
+		return "only " + result.NameA
+	default:
+		return "only " + result.NameB
+	}
+}
+
+// Returns the contributors with their totals, as a table with a header line
+func (result overlapResult) asTable() [][]string {
+	table := [][]string{{"User", result.NameA, result.NameB, "Status"}}
+	for _, entry := range result.Entries {
+		table = append(table, []string{entry.User, strconv.Itoa(entry.TotalA), strconv.Itoa(entry.TotalB), result.statusOf(entry)})
+	}
+	return table
+}
+
+// Returns the summary of the overlap, as text
+func (result overlapResult) summary() string {
+	correlation := "can't be computed"
+	if result.IsCorrelated {
+		correlation = fmt.Sprintf("%.2f", result.Correlation)
+	}
+	return fmt.Sprintf("Contributors of %s (%s) and %s (%s):\n", result.NameA, result.PeriodA, result.NameB, result.PeriodB) +
+		fmt.Sprintf("  - active in both: %d\n  - only in %s: %d\n  - only in %s: %d\n", result.Both, result.NameA, result.OnlyA, result.NameB, result.OnlyB) +
+		fmt.Sprintf("Correlation of the activity of the contributors active in both: %s\n", correlation)
+}
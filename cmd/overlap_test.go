@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pearsonCorrelation(t *testing.T) {
+	tests := []struct {
+		name            string
+		x               []float64
+		y               []float64
+		want            float64
+		wantCorrelation bool
+	}{
+		{"positive", []float64{1, 2, 3}, []float64{2, 4, 6}, 1, true},
+		{"negative", []float64{1, 2, 3}, []float64{3, 2, 1}, -1, true},
+		{"none", []float64{1, 2, 3, 4}, []float64{1, 3, 3, 1}, 0, true},
+		{"constant", []float64{1, 2, 3}, []float64{5, 5, 5}, 0, false},
+		{"single value", []float64{1}, []float64{2}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isCorrelated := pearsonCorrelation(tt.x, tt.y)
+			assert.Equal(t, tt.wantCorrelation, isCorrelated)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func Test_computeOverlap(t *testing.T) {
+	recordsA := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "5", "0", "1"},
+		{"Bravo", "0", "2", "2"},
+		{"charlie", "1", "0", "0"},
+		{"delta", "0", "0", "3"},
+	}
+	recordsB := [][]string{
+		{"", "2023-02", "2023-03"},
+		{"bravo", "1", "0"},
+		{"delta", "2", "4"},
+		{"echo", "0", "7"},
+		{"foxtrot", "0", "0"},
+	}
+
+	datasetA, err := newDataset("ci", recordsA)
+	assert.NoError(t, err)
+	datasetB, err := newDataset("infra", recordsB)
+	assert.NoError(t, err)
+
+	result, err := computeOverlap(datasetA, datasetB, "latest", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-02 to 2023-03", result.PeriodA)
+	// "charlie" was not active during the period, "foxtrot" never was
+	assert.Equal(t, []overlapEntry{
+		{User: "delta", TotalA: 3, TotalB: 6},
+		{User: "echo", TotalA: 0, TotalB: 7},
+		{User: "Bravo", TotalA: 4, TotalB: 1},
+		{User: "alpha", TotalA: 1, TotalB: 0},
+	}, result.Entries)
+	assert.Equal(t, 2, result.Both)
+	assert.Equal(t, 1, result.OnlyA)
+	assert.Equal(t, 1, result.OnlyB)
+	assert.True(t, result.IsCorrelated)
+	assert.InDelta(t, -1, result.Correlation, 1e-9)
+
+	assert.Equal(t, []string{"User", "ci", "infra", "Status"}, result.asTable()[0])
+	assert.Equal(t, []string{"echo", "0", "7", "only infra"}, result.asTable()[2])
+	assert.Contains(t, result.summary(), "  - active in both: 2\n")
+}
+
+func Test_ExecuteOverlap(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "overlap.csv")
+
+	rootCmd.SetArgs([]string{"overlap", "../test_data/overview.csv", "../test_data/deleted_user_case.csv", "-m", "latest", "-p", "0", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "User,overview,deleted_user_case,Status\n"))
+	assert.Contains(t, string(content), ",both\n")
+}
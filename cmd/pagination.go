@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var maxTableRows int
+var tableSplitMode string
+
+// How the long tables are split
+const (
+	splitModeDetails = "details" // collapsible sections in the same file
+	splitModeFiles   = "files"   // one file per page
+)
+
+// A part of a long table
+type tableChunk struct {
+	From int // first row (starting at 1)
+	To   int // last row
+	Rows [][]string
+}
+
+// Splits the rows of a table (header excluded) in chunks of maxRows
+func splitTableRows(data [][]string, maxRows int) []tableChunk {
+	rows := data[1:]
+	if maxRows <= 0 || len(rows) <= maxRows {
+		return []tableChunk{{From: 1, To: len(rows), Rows: rows}}
+	}
+	var chunks []tableChunk
+	for from := 0; from < len(rows); from += maxRows {
+		to := from + maxRows
+		if to > len(rows) {
+			to = len(rows)
+		}
+		chunks = append(chunks, tableChunk{From: from + 1, To: to, Rows: rows[from:to]})
+	}
+	return chunks
+}
+
+// Returns the name of a page of a split output ("top.md", "top_2.md", ...)
+func pageFileName(outputFileName string, page int) string {
+	if page <= 1 {
+		return outputFileName
+	}
+	extension := filepath.Ext(outputFileName)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(outputFileName, extension), page, extension)
+}
+
+// Writes a long table as Markdown, split in chunks of maxRows. The first chunk is
+// always visible, the next ones are either collapsible sections or separate files.
+func writePaginatedMarkdown(outputFileName string, data [][]string, introductionText string, notesText string, isHistory bool, inputType InputType, maxRows int, splitMode string) error {
+	chunks := splitTableRows(data, maxRows)
+
+	if splitMode == splitModeFiles {
+		for i, chunk := range chunks {
+			navigation := getPageNavigation(outputFileName, i+1, len(chunks))
+			introduction := introductionText
+			if i > 0 {
+				introduction = fmt.Sprintf("Rows %d to %d.\n", chunk.From, chunk.To)
+			}
+			table := append([][]string{data[0]}, chunk.Rows...)
+			if err := writeDataAsMarkdownWithNotes(pageFileName(outputFileName, i+1), table, introduction, navigation+notesText, isHistory, inputType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	f, err := os.Create(outputFileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	out := bufio.NewWriter(f)
+
+	fmt.Fprintf(out, "%s\n\n", getProvenanceHeader())
+	if len(introductionText) > 0 {
+		fmt.Fprintf(out, "%s\n", introductionText)
+	}
+	plotted := getPlottedUsers(outputFileName, isHistory, inputType)
+	for i, chunk := range chunks {
+		table := append([][]string{data[0]}, chunk.Rows...)
+		if i == 0 {
+			if err := writeMarkdownTable(out, table, plotted, inputType); err != nil {
+				return err
+			}
+			continue
+		}
+		// The blank lines are needed for the table to be rendered inside the HTML block
+		fmt.Fprintf(out, "\n<details>\n<summary>Rows %d to %d</summary>\n\n", chunk.From, chunk.To)
+		if err := writeMarkdownTable(out, table, plotted, inputType); err != nil {
+			return err
+		}
+		fmt.Fprint(out, "\n</details>\n")
+	}
+	if len(notesText) > 0 {
+		fmt.Fprintf(out, "\n%s", notesText)
+	}
+	return out.Flush()
+}
+
+// Returns the links to the previous and next pages of a split output
+func getPageNavigation(outputFileName string, page int, nbrOfPages int) string {
+	var links []string
+	if page > 1 {
+		links = append(links, fmt.Sprintf("[← Previous](%s)", filepath.Base(pageFileName(outputFileName, page-1))))
+	}
+	links = append(links, fmt.Sprintf("Page %d of %d", page, nbrOfPages))
+	if page < nbrOfPages {
+		links = append(links, fmt.Sprintf("[Next →](%s)", filepath.Base(pageFileName(outputFileName, page+1))))
+	}
+	return strings.Join(links, " | ") + "\n"
+}
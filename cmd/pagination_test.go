@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var paginationTestData = [][]string{
+	{"Submitter", "Total_PRs"},
+	{"alpha", "12"},
+	{"bravo", "7"},
+	{"charlie", "5"},
+	{"delta", "3"},
+	{"echo", "1"},
+}
+
+func Test_splitTableRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxRows int
+		want    []tableChunk
+	}{
+		{
+			"no limit",
+			0,
+			[]tableChunk{{From: 1, To: 5, Rows: paginationTestData[1:]}},
+		},
+		{
+			"short table",
+			5,
+			[]tableChunk{{From: 1, To: 5, Rows: paginationTestData[1:]}},
+		},
+		{
+			"split",
+			2,
+			[]tableChunk{
+				{From: 1, To: 2, Rows: paginationTestData[1:3]},
+				{From: 3, To: 4, Rows: paginationTestData[3:5]},
+				{From: 5, To: 5, Rows: paginationTestData[5:]},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitTableRows(paginationTestData, tt.maxRows))
+		})
+	}
+}
+
+func Test_pageFileName(t *testing.T) {
+	assert.Equal(t, "out/top.md", pageFileName("out/top.md", 1))
+	assert.Equal(t, "out/top_3.md", pageFileName("out/top.md", 3))
+}
+
+func Test_writePaginatedMarkdown_details(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+
+	err := writePaginatedMarkdown(outputFile, paginationTestData, "Intro\n", "", false, InputTypeSubmitters, 2, splitModeDetails)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), "<details>"))
+	assert.Contains(t, string(content), "<summary>Rows 3 to 4</summary>\n\n| Submitter | Total_PRs |")
+	assert.Contains(t, string(content), "<summary>Rows 5 to 5</summary>")
+	// The first chunk is not collapsed
+	assert.Less(t, strings.Index(string(content), "| alpha"), strings.Index(string(content), "<details>"))
+}
+
+func Test_writePaginatedMarkdown_files(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.md")
+
+	err := writePaginatedMarkdown(outputFile, paginationTestData, "Intro\n", "", false, InputTypeSubmitters, 2, splitModeFiles)
+
+	assert.NoError(t, err)
+	first, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(first), "Intro")
+	assert.Contains(t, string(first), "Page 1 of 3 | [Next →](top_2.md)")
+	second, err := os.ReadFile(pageFileName(outputFile, 2))
+	assert.NoError(t, err)
+	assert.Contains(t, string(second), "| charlie")
+	assert.Contains(t, string(second), "[← Previous](top.md) | Page 2 of 3 | [Next →](top_3.md)")
+	assert.FileExists(t, pageFileName(outputFile, 3))
+}
+
+func Test_sitePage_Chunks(t *testing.T) {
+	entries := []leaderboardEntry{{Rank: 1, User: "alpha"}, {Rank: 2, User: "bravo"}, {Rank: 3, User: "charlie"}}
+
+	page := sitePage{Site: &siteData{}}
+	assert.Len(t, page.Chunks(entries), 1)
+
+	page = sitePage{Site: &siteData{MaxRows: 2}}
+	chunks := page.Chunks(entries)
+	assert.Len(t, chunks, 2)
+	assert.Equal(t, siteChunk{From: 3, To: 3, Entries: entries[2:]}, chunks[1])
+}
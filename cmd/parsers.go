@@ -0,0 +1,185 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Reads an input format as a (wide) pivot table. To support a new upstream format,
+// implement this interface and register it with registerParser (in an init function).
+type Parser interface {
+	// Tells whether the file is in this format (from its name and, if needed, its content)
+	Detect(fileName string) (bool, error)
+	// Reads the file as a pivot table: a header line ("", months...) then a line per user
+	Parse(fileName string) ([][]string, error)
+}
+
+// The parsers, by format name, in registration order
+var parserRegistry = map[string]Parser{}
+var parserFormats []string
+
+// The format assumed when no parser recognizes the file: the datamash pivot table
+const fallbackParserFormat = formatWide
+
+// Makes a format available to the commands reading the input files
+func registerParser(format string, parser Parser) {
+	if _, isRegistered := parserRegistry[format]; isRegistered {
+		panic(fmt.Sprintf("A parser is already registered for the \"%s\" format", format))
+	}
+	parserRegistry[format] = parser
+	parserFormats = append(parserFormats, format)
+}
+
+// Returns the parser of a format
+func getParser(format string) (Parser, error) {
+	parser, isRegistered := parserRegistry[format]
+	if !isRegistered {
+		return nil, fmt.Errorf("Unsupported input format \"%s\"", format)
+	}
+	return parser, nil
+}
+
+// Detects the format of an input file: the first registered parser recognizing
+// the file wins, the datamash pivot table is assumed otherwise.
+func detectInputFormat(fileName string) (string, error) {
+	for _, format := range parserFormats {
+		if format == fallbackParserFormat {
+			continue
+		}
+		isDetected, err := parserRegistry[format].Detect(fileName)
+		if err != nil {
+			return "", err
+		}
+		if isDetected {
+			return format, nil
+		}
+	}
+	return fallbackParserFormat, nil
+}
+
+// Returns the format of an input file read by a parser, or "" for a datamash pivot table
+// (read as a stream by the loaders). The files that can't be detected are read as pivot tables.
+func parsedInputFormat(fileName string) string {
+	format, err := detectInputFormat(fileName)
+	if err != nil || format == fallbackParserFormat {
+		return ""
+	}
+	return format
+}
+
+// Reads a file of the given format as a (wide) pivot table
+func readPivot(fileName string, format string) ([][]string, error) {
+	parser, err := getParser(format)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Parse(fileName)
+}
+
+// Tells whether the file has one of the extensions (case insensitive)
+func hasExtension(fileName string, extensions ...string) bool {
+	fileExtension := strings.ToLower(filepath.Ext(fileName))
+	for _, extension := range extensions {
+		if fileExtension == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// The pivot table generated by datamash
+type wideParser struct{}
+
+func (wideParser) Detect(fileName string) (bool, error) {
+	return hasExtension(fileName, ".csv"), nil
+}
+
+func (wideParser) Parse(fileName string) ([][]string, error) {
+	return loadInputPivotTable(fileName)
+}
+
+// CSV file with one "user,month,count" line per value (recognized by its header)
+type longCSVParser struct{}
+
+func (longCSVParser) Detect(fileName string) (bool, error) {
+	if !hasExtension(fileName, ".csv") {
+		return false, nil
+	}
+	f, err := os.Open(fileName)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header, err := csv.NewReader(f).Read()
+	if err != nil {
+		return false, fmt.Errorf("Unable to read the header of %s: %v", fileName, err)
+	}
+	return strings.EqualFold(strings.Join(header, ","), strings.Join(longFormatHeader, ",")), nil
+}
+
+func (longCSVParser) Parse(fileName string) ([][]string, error) {
+	records, err := readLongCSV(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return longToWide(records)
+}
+
+// One {"user","month","count"} JSON object per line
+type jsonlParser struct{}
+
+func (jsonlParser) Detect(fileName string) (bool, error) {
+	return hasExtension(fileName, ".jsonl", ".ndjson"), nil
+}
+
+func (jsonlParser) Parse(fileName string) ([][]string, error) {
+	records, err := readJSONL(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return longToWide(records)
+}
+
+// Excel workbook with the wide layout (first sheet)
+type xlsxParser struct{}
+
+func (xlsxParser) Detect(fileName string) (bool, error) {
+	return hasExtension(fileName, ".xlsx"), nil
+}
+
+func (xlsxParser) Parse(fileName string) ([][]string, error) {
+	return readXLSX(fileName)
+}
+
+// Registers the formats supported out of the box
+func init() {
+	registerParser(formatWide, wideParser{})
+	registerParser(formatLong, longCSVParser{})
+	registerParser(formatJSONL, jsonlParser{})
+	registerParser(formatXLSX, xlsxParser{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A parser of a made up format
+type fakeParser struct{}
+
+func (fakeParser) Detect(fileName string) (bool, error) {
+	return hasExtension(fileName, ".fake"), nil
+}
+
+func (fakeParser) Parse(fileName string) ([][]string, error) {
+	return [][]string{{"", "2023-01"}, {fileName, "1"}}, nil
+}
+
+func Test_registerParser(t *testing.T) {
+	defer func(formats []string) {
+		delete(parserRegistry, "fake")
+		parserFormats = formats
+	}(parserFormats)
+
+	registerParser("fake", fakeParser{})
+
+	format, err := detectInputFormat("data/export.FAKE")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake", format)
+	pivot, err := readPivot("export.fake", format)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "2023-01"}, {"export.fake", "1"}}, pivot)
+
+	assert.Panics(t, func() { registerParser("fake", fakeParser{}) })
+}
+
+func Test_getParser(t *testing.T) {
+	assert.Equal(t, []string{formatWide, formatLong, formatJSONL, formatXLSX}, parserFormats)
+
+	parser, err := getParser(formatJSONL)
+	assert.NoError(t, err)
+	assert.IsType(t, jsonlParser{}, parser)
+
+	_, err = getParser("yaml")
+	assert.ErrorContains(t, err, "Unsupported input format \"yaml\"")
+
+	// Unknown files are read as datamash pivot tables
+	format, err := detectInputFormat("export.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, formatWide, format)
+}
+
+func Test_loadersUseTheParsers(t *testing.T) {
+	jsonlFile := filepath.Join(t.TempDir(), "submissions.jsonl")
+	content := "{\"user\":\"alpha\",\"month\":\"2023-01\",\"count\":1}\n" +
+		"{\"user\":\"bravo\",\"month\":\"2023-02\",\"count\":4}\n" +
+		"{\"user\":\"alpha\",\"month\":\"2023-03\",\"count\":2}\n"
+	assert.NoError(t, os.WriteFile(jsonlFile, []byte(content), 0644))
+
+	assert.Equal(t, "", parsedInputFormat("../test_data/overview.csv"))
+	assert.Equal(t, formatJSONL, parsedInputFormat(jsonlFile))
+
+	records, err := loadInputPivotTable(jsonlFile)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "2023-01", "2023-02", "2023-03"}, {"alpha", "1", "", "2"}, {"bravo", "", "4", ""}}, records)
+
+	header, err := loadPivotTableHeader(jsonlFile)
+	assert.NoError(t, err)
+	assert.Equal(t, records[0], header)
+
+	projected, err := loadProjectedPivotTable(jsonlFile, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "2023-02", "2023-03"}, {"alpha", "", "2"}, {"bravo", "4", ""}}, projected)
+
+	assert.Empty(t, validatePivotTable(jsonlFile))
+}
@@ -0,0 +1,227 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var loadBudget time.Duration
+var rankBudget time.Duration
+var renderBudget time.Duration
+
+// Minimum time during which each phase is repeated, for its time per operation to be stable
+const perfMinDuration = time.Second
+
+// Result of a benchmark of the suite
+type perfResult struct {
+	Name    string
+	Budget  time.Duration // 0 when no budget is defined
+	Bytes   int64         // processed bytes per operation (for throughput)
+	Rows    int           // processed rows per operation (for throughput)
+	N       int           // number of operations run
+	Elapsed time.Duration // time taken by the N operations
+	Allocs  uint64        // memory allocations of the N operations
+}
+
+// Returns the average time of an operation
+func (r perfResult) perOp() time.Duration {
+	if r.N == 0 {
+		return 0
+	}
+	return r.Elapsed / time.Duration(r.N)
+}
+
+// Returns the average number of memory allocations of an operation
+func (r perfResult) allocsPerOp() uint64 {
+	if r.N == 0 {
+		return 0
+	}
+	return r.Allocs / uint64(r.N)
+}
+
+// perfCmd represents the perf command
+var perfCmd = &cobra.Command{
+	Use:   "perf [input file]",
+	Short: "Measures the processing performance on the supplied pivot table",
+	Long: `The PERF command runs the benchmark suite (loading, ranking and rendering)
+on the supplied pivot table and reports the throughput of each phase.
+
+A time budget (per operation) can be given for each phase. The command fails if
+one of the budgets is exceeded, which allows to detect performance regressions.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		results, err := runPerfSuite(args[0])
+		if err != nil {
+			return err
+		}
+		writePerfResults(cmd.OutOrStdout(), results)
+
+		return checkPerfBudgets(results)
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(perfCmd)
+
+	perfCmd.Flags().DurationVarP(&loadBudget, "load-budget", "", 0, "Maximum time to load the pivot table (ex: \"150ms\", 0 for no budget)")
+	perfCmd.Flags().DurationVarP(&rankBudget, "rank-budget", "", 0, "Maximum time to rank the submitters (0 for no budget)")
+	perfCmd.Flags().DurationVarP(&renderBudget, "render-budget", "", 0, "Maximum time to render the top submitters as markdown (0 for no budget)")
+	perfCmd.ValidArgsFunction = completeInputFile
+}
+
+// Runs the benchmarks of the loading, ranking and rendering phases on the given file
+func runPerfSuite(inputFilename string) ([]perfResult, error) {
+	info, err := os.Stat(inputFilename)
+	if err != nil {
+		return nil, err
+	}
+	records, err := loadInputPivotTable(inputFilename)
+	if err != nil {
+		return nil, err
+	}
+	nbrOfRows := len(records) - 1
+
+	tempDir, err := os.MkdirTemp("", "perf")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	renderFileName := filepath.Join(tempDir, "perf.md")
+	// The ranking works, as in EXTRACT, on the columns of the period (here all the months)
+	projected, err := loadProjectedPivotTable(inputFilename, 1, len(records[0])-1)
+	if err != nil {
+		return nil, err
+	}
+	topSlice := rankTopUsers(projected, 35, InputTypeSubmitters)
+
+	phases := []struct {
+		result    perfResult
+		operation func() error
+	}{
+		{perfResult{Name: "load", Budget: loadBudget, Bytes: info.Size(), Rows: nbrOfRows}, func() error {
+			_, err := loadInputPivotTable(inputFilename)
+			return err
+		}},
+		{perfResult{Name: "rank", Budget: rankBudget, Rows: nbrOfRows}, func() error {
+			rankTopUsers(projected, 35, InputTypeSubmitters)
+			return nil
+		}},
+		{perfResult{Name: "render", Budget: renderBudget, Rows: len(topSlice) - 1}, func() error {
+			return writeDataAsMarkdown(renderFileName, topSlice, "# Perf\n", false, InputTypeSubmitters)
+		}},
+	}
+
+	var results []perfResult
+	for _, phase := range phases {
+		result := phase.result
+		if err := measurePerf(&result, phase.operation); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Repeats the operation (at least once) during perfMinDuration and records the number of
+// operations, their time and their memory allocations in the result
+func measurePerf(result *perfResult, operation func() error) error {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for result.N == 0 || time.Since(start) < perfMinDuration {
+		if err := operation(); err != nil {
+			return err
+		}
+		result.N++
+	}
+	result.Elapsed = time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	result.Allocs = after.Mallocs - before.Mallocs
+	return nil
+}
+
+// Prints the benchmark results with their throughput
+func writePerfResults(out io.Writer, results []perfResult) {
+	fmt.Fprintf(out, "| Phase  |      Time/op |    Rows/s |     MB/s | Allocs/op |       Budget |\n")
+	fmt.Fprintf(out, "| ------ | -----------: | --------: | -------: | --------: | -----------: |\n")
+	for _, result := range results {
+		perOp := result.perOp()
+
+		rowsPerSecond := "-"
+		if result.Rows > 0 && perOp > 0 {
+			rowsPerSecond = fmt.Sprintf("%.0f", float64(result.Rows)/perOp.Seconds())
+		}
+		megaBytesPerSecond := "-"
+		if result.Bytes > 0 && perOp > 0 {
+			megaBytesPerSecond = fmt.Sprintf("%.2f", float64(result.Bytes)/1e6/perOp.Seconds())
+		}
+		budget := "-"
+		if result.Budget > 0 {
+			budget = result.Budget.String()
+		}
+
+		fmt.Fprintf(out, "| %-6s | %12s | %9s | %8s | %9d | %12s |\n", result.Name, perOp, rowsPerSecond, megaBytesPerSecond, result.allocsPerOp(), budget)
+	}
+}
+
+// Checks that no phase exceeded its budget
+func checkPerfBudgets(results []perfResult) error {
+	var exceeded []string
+	for _, result := range results {
+		perOp := result.perOp()
+		if result.Budget > 0 && perOp > result.Budget {
+			exceeded = append(exceeded, fmt.Sprintf("%s (%s > %s)", result.Name, perOp, result.Budget))
+		}
+	}
+	if len(exceeded) > 0 {
+		return fmt.Errorf("Performance budget exceeded: %s", strings.Join(exceeded, ", "))
+	}
+	return nil
+}
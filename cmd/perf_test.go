@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------------------------
+//
+// Benchmarks (go test -bench=. ./cmd/)
+//
+// ------------------------------
+
+func Benchmark_loadInputPivotTable(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := loadInputPivotTable("../test_data/overview.csv"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_loadProjectedPivotTable(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := loadProjectedPivotTable("../test_data/overview.csv", 29, 40); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_rankTopUsers(b *testing.B) {
+	records, err := loadProjectedPivotTable("../test_data/overview.csv", 29, 40)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rankTopUsers(records, 35, InputTypeSubmitters)
+	}
+}
+
+func Benchmark_writeDataAsMarkdown(b *testing.B) {
+	records, err := loadProjectedPivotTable("../test_data/overview.csv", 29, 40)
+	if err != nil {
+		b.Fatal(err)
+	}
+	topSlice := rankTopUsers(records, 35, InputTypeSubmitters)
+	outputFileName := filepath.Join(b.TempDir(), "output.md")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := writeDataAsMarkdown(outputFileName, topSlice, "# Benchmark\n", false, InputTypeSubmitters); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Test_checkPerfBudgets(t *testing.T) {
+	results := []perfResult{
+		{Name: "load", Budget: time.Millisecond, N: 1, Elapsed: 2 * time.Millisecond},
+		{Name: "rank", Budget: 0, N: 1, Elapsed: time.Second},
+		{Name: "render", Budget: time.Second, N: 10, Elapsed: time.Second},
+	}
+
+	err := checkPerfBudgets(results)
+	assert.EqualError(t, err, "Performance budget exceeded: load (2ms > 1ms)")
+
+	assert.NoError(t, checkPerfBudgets(results[1:]))
+}
+
+func Test_measurePerf(t *testing.T) {
+	calls := 0
+	result := perfResult{Name: "test"}
+	assert.NoError(t, measurePerf(&result, func() error {
+		calls++
+		return nil
+	}))
+	assert.Equal(t, calls, result.N)
+	assert.GreaterOrEqual(t, result.Elapsed, perfMinDuration)
+
+	// The first error stops the measure
+	assert.Error(t, measurePerf(&perfResult{}, func() error { return fmt.Errorf("failed") }))
+}
@@ -0,0 +1,311 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+var isPipelineDryRun bool
+
+// Definition of the pipeline (YAML file). The steps are executed in a fixed order:
+// fetch, check, extract, compare and publish.
+type pipelineDefinition struct {
+	Input   string            `yaml:"input"`
+	Fetch   *pipelineFetch    `yaml:"fetch"`
+	Check   bool              `yaml:"check"`
+	Extract []pipelineOptions `yaml:"extract"`
+	Compare []pipelineOptions `yaml:"compare"`
+	Publish *pipelinePublish  `yaml:"publish"`
+}
+
+// Downloads the input pivot table
+type pipelineFetch struct {
+	URL string `yaml:"url"`
+}
+
+// Uploads the generated files, to each destination
+type pipelinePublish struct {
+	Destination  string          `yaml:"destination"`
+	Destinations []string        `yaml:"destinations"`
+	Files        []string        `yaml:"files"`
+	Options      pipelineOptions `yaml:"options"`
+}
+
+// Returns all the destinations of the publish step
+func (publish *pipelinePublish) allDestinations() []string {
+	if publish.Destination == "" {
+		return publish.Destinations
+	}
+	return append([]string{publish.Destination}, publish.Destinations...)
+}
+
+// Flags of a command (name without the dashes and value)
+type pipelineOptions map[string]any
+
+// pipelineCmd represents the pipeline command
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline [definition file]",
+	Short: "Runs the whole monthly job described in a YAML file",
+	Long: `The PIPELINE command chains the fetch, check, extract, compare and publish steps
+described in a YAML definition file. The whole monthly job is then a single invocation
+(ex: of a container) with a single configuration file.
+
+The "extract" and "compare" steps are lists: each entry generates an output. Their
+entries, as the publish "options", are the flags of the corresponding command (ex: "topSize: 50").
+The files are published to each of the publish "destinations" (see the PUBLISH command).`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid pipeline definition file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		definition, err := loadPipelineDefinition(args[0])
+		if err != nil {
+			return err
+		}
+		steps, err := buildPipelineSteps(definition)
+		if err != nil {
+			return err
+		}
+
+		if isPipelineDryRun {
+			if definition.Fetch != nil {
+				logInfo("fetch %s -> %s\n", definition.Fetch.URL, definition.Input)
+			}
+			for _, step := range steps {
+				logInfo("%s\n", strings.Join(step, " "))
+			}
+			return nil
+		}
+
+		if definition.Fetch != nil {
+			logInfo("Fetching \"%s\"\n", definition.Fetch.URL)
+			if err := fetchFile(cmd.Context(), definition.Fetch.URL, definition.Input); err != nil {
+				return err
+			}
+		}
+		for _, step := range steps {
+			logVerbose("Running \"%s\"\n", strings.Join(step, " "))
+			if err := runPipelineStep(cmd.Context(), step); err != nil {
+				return fmt.Errorf("Step \"%s\" failed: %v", step[0], err)
+			}
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(pipelineCmd)
+
+	pipelineCmd.Flags().BoolVarP(&isPipelineDryRun, "dry-run", "", false, "Displays the commands of the pipeline without running them")
+}
+
+// Loads and validates the pipeline definition
+func loadPipelineDefinition(fileName string) (pipelineDefinition, error) {
+	var definition pipelineDefinition
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return definition, err
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&definition); err != nil {
+		return definition, fmt.Errorf("Invalid pipeline definition \"%s\": %v", fileName, err)
+	}
+
+	if definition.Input == "" {
+		return definition, fmt.Errorf("The pipeline definition has no \"input\"")
+	}
+	if definition.Fetch != nil && definition.Fetch.URL == "" {
+		return definition, fmt.Errorf("The fetch step has no \"url\"")
+	}
+	if definition.Publish != nil {
+		destinations := definition.Publish.allDestinations()
+		if len(destinations) == 0 || len(definition.Publish.Files) == 0 {
+			return definition, fmt.Errorf("The publish step needs a \"destination\" and \"files\"")
+		}
+		for _, destination := range destinations {
+			if _, err := newPublisher(destination); err != nil {
+				return definition, err
+			}
+		}
+	}
+	return definition, nil
+}
+
+// Converts the definition in the command lines of the steps (the fetch excepted)
+func buildPipelineSteps(definition pipelineDefinition) ([][]string, error) {
+	var steps [][]string
+	if definition.Check {
+		steps = append(steps, []string{"check", definition.Input})
+	}
+	for _, options := range definition.Extract {
+		flags, err := options.toFlags()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, append([]string{"extract", definition.Input}, flags...))
+	}
+	for _, options := range definition.Compare {
+		flags, err := options.toFlags()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, append([]string{"compare", definition.Input}, flags...))
+	}
+	if definition.Publish != nil {
+		flags, err := definition.Publish.Options.toFlags()
+		if err != nil {
+			return nil, err
+		}
+		// A single step so that a failing destination doesn't prevent publishing to the others
+		destinations := definition.Publish.allDestinations()
+		step := append([]string{"publish", destinations[0]}, definition.Publish.Files...)
+		for _, destination := range destinations[1:] {
+			step = append(step, "--to="+destination)
+		}
+		steps = append(steps, append(step, flags...))
+	}
+	return steps, nil
+}
+
+// Converts the options in command line flags (sorted by name)
+func (options pipelineOptions) toFlags() ([]string, error) {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var flags []string
+	for _, name := range names {
+		var value string
+		switch typedValue := options[name].(type) {
+		case string, int, bool, float64:
+			value = fmt.Sprint(typedValue)
+		case []any:
+			var values []string
+			for _, item := range typedValue {
+				values = append(values, fmt.Sprint(item))
+			}
+			value = strings.Join(values, ",")
+		default:
+			return nil, fmt.Errorf("Unsupported value for option \"%s\"", name)
+		}
+		flags = append(flags, "--"+name+"="+value)
+	}
+	return flags, nil
+}
+
+// Runs a step with the given command line. The flags of the command are first reset to their
+// default so that the steps don't influence each other. The step runs with the context of the
+// pipeline (its RunE is called directly, Cobra doesn't set it).
+func runPipelineStep(ctx context.Context, commandLine []string) error {
+	// The check is done in-process to get an error instead of exiting
+	if commandLine[0] == "check" {
+		if !checkFile(commandLine[1], false) {
+			return fmt.Errorf("Invalid input file")
+		}
+		return nil
+	}
+
+	cmd, commandArgs, err := rootCmd.Find(commandLine)
+	if err != nil {
+		return err
+	}
+	resetFlags(cmd)
+	if err := cmd.ParseFlags(commandArgs); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(ctx)
+	positionalArgs := cmd.Flags().Args()
+	if cmd.Args != nil {
+		if err := cmd.Args(cmd, positionalArgs); err != nil {
+			return err
+		}
+	}
+	if cmd.RunE != nil {
+		return cmd.RunE(cmd, positionalArgs)
+	}
+	cmd.Run(cmd, positionalArgs)
+	return nil
+}
+
+// Resets the flags of a command to their default value
+func resetFlags(cmd *cobra.Command) {
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			_ = sliceValue.Replace([]string{})
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+}
+
+// Downloads a file (the download is abandoned when the context is cancelled)
+func fetchFile(ctx context.Context, url string, fileName string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	httpClient := &http.Client{Timeout: 5 * time.Minute}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to fetch \"%s\": %v", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to fetch \"%s\" (%s)", url, response.Status)
+	}
+
+	if err := CheckDir(fileName); err != nil {
+		return err
+	}
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, response.Body)
+	return err
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadPipelineDefinition(t *testing.T) {
+	definition, err := loadPipelineDefinition("../test_data/pipeline.yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "data/overview.csv", definition.Input)
+	assert.Equal(t, "https://example.com/overview.csv", definition.Fetch.URL)
+	assert.True(t, definition.Check)
+	assert.Len(t, definition.Extract, 1)
+	assert.Equal(t, []string{"out"}, definition.Publish.Files)
+
+	steps, err := buildPipelineSteps(definition)
+
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"check", "data/overview.csv"},
+		{"extract", "data/overview.csv", "--history=true", "--out=out/top-submitters.md", "--topSize=50"},
+		{"compare", "data/overview.csv", "--compare=6", "--out=out/compare.md", "--user=alpha,bravo"},
+		{"publish", "s3://stats-bucket/jenkins", "out", "--region=eu-west-1"},
+	}
+	assert.Equal(t, expected, steps)
+}
+
+func Test_buildPipelineSteps_destinations(t *testing.T) {
+	definition := pipelineDefinition{
+		Input:   "data/overview.csv",
+		Publish: &pipelinePublish{Destination: "s3://stats-bucket/jenkins", Destinations: []string{"file:///var/www/stats"}, Files: []string{"out"}},
+	}
+
+	steps, err := buildPipelineSteps(definition)
+
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"publish", "s3://stats-bucket/jenkins", "out", "--to=file:///var/www/stats"},
+	}
+	assert.Equal(t, expected, steps)
+}
+
+func Test_loadPipelineDefinition_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no input", "check: true\n"},
+		{"unknown step", "input: a.csv\nsummarize: true\n"},
+		{"fetch without url", "input: a.csv\nfetch: {}\n"},
+		{"publish without files", "input: a.csv\npublish:\n  destination: s3://bucket\n"},
+		{"unsupported destination", "input: a.csv\npublish:\n  destinations: [ftp://bucket]\n  files: [out]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "pipeline.yaml")
+			assert.NoError(t, os.WriteFile(fileName, []byte(tt.content), 0644))
+			_, err := loadPipelineDefinition(fileName)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_pipelineOptions_toFlags(t *testing.T) {
+	flags, err := pipelineOptions{"period": 6, "month": "2023-03", "history": false, "user": []any{"a", "b"}}.toFlags()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--history=false", "--month=2023-03", "--period=6", "--user=a,b"}, flags)
+
+	_, err = pipelineOptions{"out": map[string]any{"a": 1}}.toFlags()
+	assert.Error(t, err)
+}
+
+func Test_fetchFile(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("../test_data")))
+	defer server.Close()
+	fileName := filepath.Join(t.TempDir(), "overview.csv")
+
+	assert.NoError(t, fetchFile(context.Background(), server.URL+"/overview.csv", fileName))
+	assert.NoError(t, isFileEquivalent(fileName, "../test_data/overview.csv"))
+
+	assert.Error(t, fetchFile(context.Background(), server.URL+"/unknown.csv", fileName))
+
+	// A cancelled run doesn't download anything
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := fetchFile(ctx, server.URL+"/overview.csv", filepath.Join(t.TempDir(), "cancelled.csv"))
+	assert.ErrorContains(t, err, "context canceled")
+}
+
+func Test_ExecutePipeline(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("../test_data")))
+	defer server.Close()
+	tempDir := t.TempDir()
+	definition := fmt.Sprintf(`input: %[1]s/overview.csv
+fetch:
+  url: %[2]s/overview.csv
+check: true
+extract:
+  - out: %[1]s/top.csv
+    topSize: 10
+  - out: %[1]s/top-quarter.csv
+    topSize: 5
+    period: 3
+compare:
+  - out: %[1]s/compare.csv
+`, tempDir, server.URL)
+	definitionFile := filepath.Join(tempDir, "pipeline.yaml")
+	assert.NoError(t, os.WriteFile(definitionFile, []byte(definition), 0644))
+
+	rootCmd.SetArgs([]string{"pipeline", definitionFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.NoError(t, isFileEquivalent(filepath.Join(tempDir, "overview.csv"), "../test_data/overview.csv"))
+	for _, fileName := range []string{"top.csv", "top-quarter.csv", "compare.csv"} {
+		assert.FileExists(t, filepath.Join(tempDir, fileName))
+	}
+	// The flags of a step don't leak into the next one (compare uses the default top size)
+	compare, err := loadInputPivotTable(filepath.Join(tempDir, "compare.csv"))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(compare), 36)
+}
+
+func Test_runPipelineStep_context(t *testing.T) {
+	// As when the step is the first command run by the process
+	extractCmd.SetContext(nil)
+	defer extractCmd.SetContext(nil)
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+
+	err := runPipelineStep(context.Background(), []string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile})
+
+	assert.NoError(t, err)
+	assert.FileExists(t, outputFile)
+
+	// The step is stopped when the pipeline is cancelled
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = runPipelineStep(cancelledCtx, []string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile})
+	assert.ErrorIs(t, err, context.Canceled)
+}
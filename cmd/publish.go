@@ -0,0 +1,251 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var publishRegion string
+var publishEndpoint string
+var publishGCSToken string
+var publishGSheetCredentials string
+var isPublishDryRun bool
+var publishOtherDestinations []string
+var publishPreviewDir string
+
+// A local file and the key it is uploaded to
+type publishFile struct {
+	Path string
+	Key  string
+}
+
+// Content types of the files we generate (the system MIME database is used for the others)
+var publishContentTypes = map[string]string{
+	".csv":   "text/csv; charset=utf-8",
+	".md":    "text/markdown; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".css":   "text/css; charset=utf-8",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".jsonl": "application/x-ndjson",
+	".ics":   "text/calendar; charset=utf-8",
+	".svg":   "image/svg+xml",
+	".png":   "image/png",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// publishCmd represents the publish command
+var publishCmd = &cobra.Command{
+	Use:   "publish [destination] [files or directories...]",
+	Short: "Uploads the generated files to S3, Google Cloud Storage, a Google Sheet or a directory",
+	Long: `The PUBLISH command uploads the generated files (CSV, Markdown, HTML, ...) to an
+object storage bucket, with the correct content type.
+
+The destination is either "s3://bucket/path" or "gs://bucket/path". The files are uploaded
+under that path with their name. The content of the directories is uploaded recursively,
+keeping the structure (ex: the output of the SITE command).
+
+S3 uses the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY (and AWS_SESSION_TOKEN) environment
+variables. Use "--endpoint" for S3 compatible storages.
+Google Cloud Storage uses an OAuth2 access token ("--gcs-token" or the
+GOOGLE_OAUTH_ACCESS_TOKEN environment variable, ex: "gcloud auth print-access-token").
+
+With "gsheet://spreadsheet-id/tab", a CSV file replaces the content of that tab of a
+Google Sheet (the tab is created if needed). Without a tab, each CSV file goes in the tab
+named after the month found in its name (ex: "2023-04"), so that the monthly runs add
+a tab per month. A service account key file is used ("--gsheet-credentials" or the
+GOOGLE_APPLICATION_CREDENTIALS environment variable); the spreadsheet must be shared
+with the service account.
+
+With "file:///path/to/directory", the files are copied in that local directory (ex: the
+document root of a web server).
+
+The files can be published to other destinations with "--to". A failing destination
+doesn't prevent the publication to the next ones: the failures are recorded in the
+state file and "publish --resume" retries only them, with the same (already generated) files.
+
+With "--preview", exactly what would be published (ex: the update of a Google Sheet tab, as sent
+to its API) is written in a local directory, for review before the real publication.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if isPublishResume {
+			return cobra.NoArgs(cmd, args)
+		}
+		if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		for _, destination := range append([]string{args[0]}, publishOtherDestinations...) {
+			if _, err := newPublisher(destination); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var jobs []publishJob
+		if isPublishResume {
+			state, err := loadPublishState(publishStateFileName)
+			if err != nil {
+				return err
+			}
+			if len(state.Failures) == 0 {
+				logInfo("No failed publication to resume\n")
+				return nil
+			}
+			for _, failure := range state.Failures {
+				jobs = append(jobs, publishJob{Destination: failure.Destination, Paths: failure.Paths})
+			}
+		} else {
+			for _, destination := range append([]string{args[0]}, publishOtherDestinations...) {
+				jobs = append(jobs, publishJob{Destination: destination, Paths: args[1:]})
+			}
+		}
+
+		if publishPreviewDir != "" {
+			for _, job := range jobs {
+				if err := previewPublication(job.Destination, job.Paths, publishPreviewDir); err != nil {
+					return err
+				}
+			}
+			logInfo("Preview of the publication written in \"%s\"\n", publishPreviewDir)
+			return nil
+		}
+
+		if isPublishDryRun {
+			for _, job := range jobs {
+				destination, err := newPublisher(job.Destination)
+				if err != nil {
+					return err
+				}
+				if err := destination.publish(cmd.Context(), job.Paths, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		failures := runPublishJobs(cmd.Context(), jobs, time.Now)
+		if err := savePublishState(publishStateFileName, publishState{Failures: failures}); err != nil {
+			return err
+		}
+		if len(failures) > 0 {
+			return fmt.Errorf("Failed to publish to %d of the %d destinations (retry them with \"publish --resume\")", len(failures), len(jobs))
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(publishCmd)
+
+	defaultRegion := os.Getenv("AWS_REGION")
+	if defaultRegion == "" {
+		defaultRegion = "us-east-1"
+	}
+	publishCmd.Flags().StringVarP(&publishRegion, "region", "", defaultRegion, "AWS region of the S3 bucket (default is the AWS_REGION environment variable)")
+	publishCmd.Flags().StringVarP(&publishEndpoint, "endpoint", "", "", "Endpoint of the object storage (for S3 compatible storages)")
+	publishCmd.Flags().StringVarP(&publishGCSToken, "gcs-token", "", "", "Google Cloud Storage access token (default is the GOOGLE_OAUTH_ACCESS_TOKEN environment variable)")
+	publishCmd.Flags().StringVarP(&publishGSheetCredentials, "gsheet-credentials", "", "", "Google service account key file (default is the GOOGLE_APPLICATION_CREDENTIALS environment variable)")
+	publishCmd.Flags().BoolVarP(&isPublishDryRun, "dry-run", "", false, "Lists the files that would be uploaded, without uploading them")
+	publishCmd.Flags().StringVarP(&publishPreviewDir, "preview", "", "", "Renders what would be published in this directory, for review, without publishing it")
+	publishCmd.Flags().StringArrayVarP(&publishOtherDestinations, "to", "", []string{}, "Other destination to publish the files to (can be repeated)")
+	publishCmd.Flags().BoolVarP(&isPublishResume, "resume", "", false, "Retries only the destinations that failed in the previous run, with the same files")
+	publishCmd.Flags().StringVarP(&publishStateFileName, "state-file", "", "publish-failures.json", "File recording the failed destinations, for \"--resume\"")
+}
+
+// Creates the client of the requested object storage
+func newPublishStore(scheme string, bucket string) (objectStore, error) {
+	if scheme == "gs" {
+		token := publishGCSToken
+		if token == "" {
+			token = os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("A Google Cloud Storage access token is required (\"--gcs-token\" or GOOGLE_OAUTH_ACCESS_TOKEN)")
+		}
+		return newGCSStore(publishEndpoint, bucket, token), nil
+	}
+
+	credentials, err := awsCredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return newS3Store(publishEndpoint, bucket, publishRegion, credentials), nil
+}
+
+// Lists the files to upload with their key. The files of a directory keep their
+// path relative to that directory.
+func collectPublishFiles(paths []string, prefix string) ([]publishFile, error) {
+	var files []publishFile
+	for _, localPath := range paths {
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, publishFile{Path: localPath, Key: path.Join(prefix, filepath.Base(localPath))})
+			continue
+		}
+
+		var directoryFiles []publishFile
+		err = filepath.WalkDir(localPath, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			relativePath, err := filepath.Rel(localPath, filePath)
+			if err != nil {
+				return err
+			}
+			directoryFiles = append(directoryFiles, publishFile{Path: filePath, Key: path.Join(prefix, filepath.ToSlash(relativePath))})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(directoryFiles, func(i, j int) bool { return directoryFiles[i].Key < directoryFiles[j].Key })
+		files = append(files, directoryFiles...)
+	}
+	return files, nil
+}
+
+// Returns the content type of a file, based on its extension
+func contentTypeOf(fileName string) string {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	if contentType, ok := publishContentTypes[extension]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_collectPublishFiles(t *testing.T) {
+	siteDir := filepath.Join(t.TempDir(), "site")
+	assert.NoError(t, os.MkdirAll(filepath.Join(siteDir, "users"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(siteDir, "index.html"), []byte("<html/>"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(siteDir, "users", "alpha.html"), []byte("<html/>"), 0644))
+
+	files, err := collectPublishFiles([]string{"../test_data/overview.csv", siteDir}, "stats")
+
+	assert.NoError(t, err)
+	expected := []publishFile{
+		{Path: "../test_data/overview.csv", Key: "stats/overview.csv"},
+		{Path: filepath.Join(siteDir, "index.html"), Key: "stats/index.html"},
+		{Path: filepath.Join(siteDir, "users", "alpha.html"), Key: "stats/users/alpha.html"},
+	}
+	assert.Equal(t, expected, files)
+
+	_, err = collectPublishFiles([]string{"../test_data/blaah.csv"}, "")
+	assert.Error(t, err)
+}
+
+func Test_contentTypeOf(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     string
+	}{
+		{"top-submitters_LATEST.csv", "text/csv; charset=utf-8"},
+		{"top-submitters_LATEST.md", "text/markdown; charset=utf-8"},
+		{"site/index.HTML", "text/html; charset=utf-8"},
+		{"site/search-index.json", "application/json"},
+		{"milestones.ics", "text/calendar; charset=utf-8"},
+		{"README", "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			assert.Equal(t, tt.want, contentTypeOf(tt.fileName))
+		})
+	}
+}
+
+func Test_ExecutePublish_gcs(t *testing.T) {
+	uploaded := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded[r.URL.Query().Get("name")] = r.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+	defer func() {
+		_ = publishCmd.Flags().Set("endpoint", "")
+		_ = publishCmd.Flags().Set("gcs-token", "")
+	}()
+
+	rootCmd.SetArgs([]string{"publish", "gs://stats/top", "../test_data/overview.csv", "--endpoint", server.URL, "--gcs-token", "token"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"top/overview.csv": "text/csv; charset=utf-8"}, uploaded)
+}
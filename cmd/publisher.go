@@ -0,0 +1,224 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A destination of the PUBLISH command, selected by the scheme of its URL
+type publisher interface {
+	// Publishes the files and directories (only lists what would be published in a dry run)
+	publish(ctx context.Context, paths []string, isDryRun bool) error
+}
+
+// A publisher able to render what it would publish in local files, for review before the real run
+type previewer interface {
+	preview(paths []string, directory string) error
+}
+
+// Creates the publisher of a destination URL (ex: "s3://bucket/path")
+type publisherFactory func(destination string) (publisher, error)
+
+// The publishers, by URL scheme. A new destination only needs to register itself here.
+var publishers = map[string]publisherFactory{}
+
+// Registers the publisher of a URL scheme
+func registerPublisher(scheme string, factory publisherFactory) {
+	publishers[scheme] = factory
+}
+
+// Returns the supported URL schemes, sorted
+func publisherSchemes() []string {
+	schemes := make([]string, 0, len(publishers))
+	for scheme := range publishers {
+		schemes = append(schemes, scheme+"://")
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Creates the publisher of a destination, based on its URL scheme
+func newPublisher(destination string) (publisher, error) {
+	scheme, _, isFound := strings.Cut(destination, "://")
+	factory, isKnown := publishers[scheme]
+	if !isFound || !isKnown {
+		return nil, fmt.Errorf("Unsupported destination \"%s\" (supported: %s)", destination, strings.Join(publisherSchemes(), ", "))
+	}
+	return factory(destination)
+}
+
+// Initialize the built-in publishers
+func init() {
+	registerPublisher("s3", newObjectStorePublisher)
+	registerPublisher("gs", newObjectStorePublisher)
+	registerPublisher("file", newDirectoryPublisher)
+}
+
+// Renders what a destination would publish in the preview directory
+func previewPublication(destination string, paths []string, directory string) error {
+	p, err := newPublisher(destination)
+	if err != nil {
+		return err
+	}
+	renderer, isPreviewable := p.(previewer)
+	if !isPreviewable {
+		return fmt.Errorf("No preview available for \"%s\"", destination)
+	}
+	return renderer.preview(paths, directory)
+}
+
+// Writes a file, creating its directory if needed
+func writeFileWithDirs(fileName string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0644)
+}
+
+// Copies the files in the preview directory, under the given path
+func previewFiles(files []publishFile, directory string) error {
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(directory, filepath.FromSlash(file.Key))
+		if err := writeFileWithDirs(target, content); err != nil {
+			return err
+		}
+		logInfo("%s -> %s (%s)\n", file.Path, target, contentTypeOf(file.Path))
+	}
+	return nil
+}
+
+// Uploads the files to an S3 or Google Cloud Storage bucket
+type objectStorePublisher struct {
+	destination string
+	scheme      string
+	bucket      string
+	prefix      string
+}
+
+// Creates the publisher of "s3://bucket/path" or "gs://bucket/path"
+func newObjectStorePublisher(destination string) (publisher, error) {
+	scheme, bucket, prefix, err := parseObjectStoreURL(destination)
+	if err != nil {
+		return nil, err
+	}
+	return &objectStorePublisher{destination: destination, scheme: scheme, bucket: bucket, prefix: prefix}, nil
+}
+
+func (p *objectStorePublisher) publish(ctx context.Context, paths []string, isDryRun bool) error {
+	files, err := collectPublishFiles(paths, p.prefix)
+	if err != nil {
+		return err
+	}
+
+	if isDryRun {
+		for _, file := range files {
+			logInfo("%s -> %s://%s/%s (%s)\n", file.Path, p.scheme, p.bucket, file.Key, contentTypeOf(file.Path))
+		}
+		return nil
+	}
+
+	store, err := newPublishStore(p.scheme, p.bucket)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return err
+		}
+		if err := store.upload(ctx, file.Key, contentTypeOf(file.Path), content); err != nil {
+			return err
+		}
+		logVerbose("Uploaded \"%s\" to %s://%s/%s\n", file.Path, p.scheme, p.bucket, file.Key)
+	}
+	logInfo("%d files uploaded to \"%s\"\n", len(files), p.destination)
+	return nil
+}
+
+// Copies the objects as they would be uploaded in "<scheme>/<bucket>/<key>"
+func (p *objectStorePublisher) preview(paths []string, directory string) error {
+	files, err := collectPublishFiles(paths, p.prefix)
+	if err != nil {
+		return err
+	}
+	return previewFiles(files, filepath.Join(directory, p.scheme, p.bucket))
+}
+
+// Copies the files in a local directory (ex: the document root of a web server or a shared drive)
+type directoryPublisher struct {
+	directory string
+}
+
+// Creates the publisher of "file:///path/to/directory"
+func newDirectoryPublisher(destination string) (publisher, error) {
+	u, err := url.Parse(destination)
+	if err != nil || u.Path == "" || (u.Host != "" && u.Host != "localhost") {
+		return nil, fmt.Errorf("Invalid destination \"%s\" (expecting \"file:///path/to/directory\")", destination)
+	}
+	return &directoryPublisher{directory: filepath.FromSlash(u.Path)}, nil
+}
+
+func (p *directoryPublisher) publish(ctx context.Context, paths []string, isDryRun bool) error {
+	files, err := collectPublishFiles(paths, "")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		target := filepath.Join(p.directory, filepath.FromSlash(file.Key))
+		if isDryRun {
+			logInfo("%s -> %s\n", file.Path, target)
+			continue
+		}
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return err
+		}
+		if err := writeFileWithDirs(target, content); err != nil {
+			return err
+		}
+		logVerbose("Copied \"%s\" to \"%s\"\n", file.Path, target)
+	}
+	if !isDryRun {
+		logInfo("%d files copied to \"%s\"\n", len(files), p.directory)
+	}
+	return nil
+}
+
+// Copies the files as they would be copied in "file/<directory>"
+func (p *directoryPublisher) preview(paths []string, directory string) error {
+	files, err := collectPublishFiles(paths, "")
+	if err != nil {
+		return err
+	}
+	return previewFiles(files, filepath.Join(directory, "file", strings.TrimPrefix(filepath.ToSlash(filepath.Clean(p.directory)), "/")))
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Records what is published
+type fakePublisher struct {
+	destination string
+	published   *[]string
+}
+
+func (p *fakePublisher) publish(ctx context.Context, paths []string, isDryRun bool) error {
+	*p.published = append(*p.published, paths...)
+	return nil
+}
+
+func Test_newPublisher(t *testing.T) {
+	var published []string
+	registerPublisher("fake", func(destination string) (publisher, error) {
+		return &fakePublisher{destination: destination, published: &published}, nil
+	})
+	defer delete(publishers, "fake")
+
+	destination, err := newPublisher("fake://somewhere")
+	assert.NoError(t, err)
+	assert.NoError(t, destination.publish(context.Background(), []string{"top.csv"}, false))
+	assert.Equal(t, []string{"top.csv"}, published)
+
+	destination, err = newPublisher("s3://stats-bucket/jenkins")
+	assert.NoError(t, err)
+	assert.Equal(t, &objectStorePublisher{destination: "s3://stats-bucket/jenkins", scheme: "s3", bucket: "stats-bucket", prefix: "jenkins"}, destination)
+
+	_, err = newPublisher("ftp://stats-bucket")
+	assert.ErrorContains(t, err, "file://, gs://, gsheet://, s3://")
+	_, err = newPublisher("stats-bucket")
+	assert.Error(t, err)
+	_, err = newPublisher("s3://")
+	assert.Error(t, err)
+}
+
+func Test_ExecutePublish_directory(t *testing.T) {
+	siteDir := filepath.Join(t.TempDir(), "site")
+	assert.NoError(t, os.MkdirAll(filepath.Join(siteDir, "users"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(siteDir, "users", "alpha.html"), []byte("<html/>"), 0644))
+	targetDir := t.TempDir()
+
+	rootCmd.SetArgs([]string{"publish", "file://" + filepath.ToSlash(targetDir), "../test_data/overview.csv", siteDir})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.NoError(t, isFileEquivalent(filepath.Join(targetDir, "overview.csv"), "../test_data/overview.csv"))
+	assert.FileExists(t, filepath.Join(targetDir, "users", "alpha.html"))
+}
+
+func Test_ExecutePublish_preview(t *testing.T) {
+	previewDir := t.TempDir()
+	defer resetFlags(publishCmd)
+
+	rootCmd.SetArgs([]string{"publish", "s3://stats-bucket/jenkins", "../test_data/overview.csv",
+		"--to", "gsheet://1AbCdEf/latest", "--to", "file:///var/www/stats", "--preview", previewDir})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.NoError(t, isFileEquivalent(filepath.Join(previewDir, "s3", "stats-bucket", "jenkins", "overview.csv"), "../test_data/overview.csv"))
+	assert.NoError(t, isFileEquivalent(filepath.Join(previewDir, "file", "var", "www", "stats", "overview.csv"), "../test_data/overview.csv"))
+	update, err := os.ReadFile(filepath.Join(previewDir, "gsheet", "1AbCdEf", "latest.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(update), `"majorDimension": "ROWS"`)
+	assert.NoFileExists(t, "/var/www/stats/overview.csv")
+}
+
+func Test_previewPublication_unsupported(t *testing.T) {
+	var published []string
+	registerPublisher("fake", func(destination string) (publisher, error) {
+		return &fakePublisher{destination: destination, published: &published}, nil
+	})
+	defer delete(publishers, "fake")
+
+	err := previewPublication("fake://somewhere", []string{"../test_data/overview.csv"}, t.TempDir())
+
+	assert.ErrorContains(t, err, "No preview")
+	assert.Empty(t, published)
+}
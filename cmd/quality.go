@@ -0,0 +1,177 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+var qualityFileName string
+
+// Data quality of a pivot table, to track the quality of the input over time
+type dataQuality struct {
+	File                string   `json:"file"`
+	Score               float64  `json:"score"` // percentage of clean rows
+	Rows                int      `json:"rows"`
+	CleanRows           int      `json:"clean_rows"`
+	InvalidRows         int      `json:"invalid_rows"`         // rows with problems reported by the check
+	SuspiciousUsernames int      `json:"suspicious_usernames"` // valid for the check, but not for GitHub
+	ZeroOnlyRows        int      `json:"zero_only_rows"`
+	MissingMonths       []string `json:"missing_months"` // months absent from the header
+	EmptyMonths         []string `json:"empty_months"`   // months without any activity
+	NoDataMonths        []string `json:"no_data_months"` // months in the header, but without data (empty cells)
+}
+
+// Reads all the lines of the input (converted by its parser if it isn't a datamash pivot table)
+func readQualityRecords(fileName string) ([][]string, error) {
+	if format := parsedInputFormat(fileName); format != "" {
+		return readPivot(fileName, format)
+	}
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// Computes the data quality of a pivot table, from the problems found by the check.
+// A clean row has no problem, a strictly valid username and some activity.
+func computeDataQuality(fileName string, problems []dataProblem) (dataQuality, error) {
+	quality := dataQuality{File: fileName, MissingMonths: []string{}, EmptyMonths: []string{}, NoDataMonths: []string{}}
+
+	records, err := readQualityRecords(fileName)
+	if err != nil {
+		return quality, err
+	}
+	if len(records) == 0 {
+		return quality, fmt.Errorf("No data in %s", fileName)
+	}
+
+	normalizePivotTableHeader(records[0])
+
+	invalidLines := make(map[int]bool)
+	for _, problem := range problems {
+		if problem.Line < 2 {
+			return quality, fmt.Errorf("The quality can't be computed: %s", problem.Message)
+		}
+		invalidLines[problem.Line] = true
+	}
+
+	header := records[0]
+	monthTotals := make([]int, len(header))
+	monthHasData := make([]bool, len(header))
+	for i, dataLine := range records[1:] {
+		quality.Rows++
+		if invalidLines[i+2] {
+			quality.InvalidRows++
+			continue
+		}
+
+		isClean := true
+		if dataLine[0] != "deleted_user" && !strictGitHubUserRegexp.MatchString(dataLine[0]) {
+			quality.SuspiciousUsernames++
+			isClean = false
+		}
+		total, hasData := 0, false
+		for column := 1; column < len(dataLine); column++ {
+			if dataLine[column] == "" {
+				continue
+			}
+			value, _ := strconv.Atoi(dataLine[column])
+			monthTotals[column] += value
+			monthHasData[column] = true
+			total += value
+			hasData = true
+		}
+		if total == 0 && hasData {
+			quality.ZeroOnlyRows++
+			isClean = false
+		}
+		if isClean {
+			quality.CleanRows++
+		}
+	}
+
+	for column := 1; column < len(header); column++ {
+		if !monthHasData[column] {
+			quality.NoDataMonths = append(quality.NoDataMonths, header[column])
+		} else if monthTotals[column] == 0 {
+			quality.EmptyMonths = append(quality.EmptyMonths, header[column])
+		}
+		if column == 1 {
+			continue
+		}
+		previous, errPrevious := time.Parse("2006-01", header[column-1])
+		current, errCurrent := time.Parse("2006-01", header[column])
+		if errPrevious != nil || errCurrent != nil {
+			continue
+		}
+		for month := previous.AddDate(0, 1, 0); month.Before(current); month = month.AddDate(0, 1, 0) {
+			quality.MissingMonths = append(quality.MissingMonths, month.Format("2006-01"))
+		}
+	}
+
+	if quality.Rows > 0 {
+		quality.Score = math.Round(float64(quality.CleanRows)*1000/float64(quality.Rows)) / 10
+	}
+	return quality, nil
+}
+
+// Prints the score and its breakdown
+func printDataQuality(out io.Writer, quality dataQuality) {
+	fmt.Fprintf(out, "Data quality score: %.1f%% (%d clean rows out of %d)\n", quality.Score, quality.CleanRows, quality.Rows)
+	fmt.Fprintf(out, "  - Rows with problems: %d\n", quality.InvalidRows)
+	fmt.Fprintf(out, "  - Suspicious usernames: %d\n", quality.SuspiciousUsernames)
+	fmt.Fprintf(out, "  - Users without activity: %d\n", quality.ZeroOnlyRows)
+	fmt.Fprintf(out, "  - Missing months: %d\n", len(quality.MissingMonths))
+	fmt.Fprintf(out, "  - Months without activity: %d\n", len(quality.EmptyMonths))
+	if len(quality.NoDataMonths) > 0 {
+		fmt.Fprintf(out, "  - Months without data: %d\n", len(quality.NoDataMonths))
+	}
+}
+
+// Writes the quality breakdown as JSON ("-" for the standard output)
+func writeDataQuality(fileName string, quality dataQuality, stdout io.Writer) error {
+	content, err := json.MarshalIndent(quality, "", "  ")
+	if err != nil {
+		return err
+	}
+	content = append(content, '\n')
+	if fileName == "-" {
+		_, err = stdout.Write(content)
+		return err
+	}
+	if err := CheckDir(fileName); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0644)
+}
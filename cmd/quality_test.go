@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeDataQuality(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     dataQuality
+	}{
+		{
+			"../test_data/suspicious_data.csv",
+			dataQuality{Score: 20, Rows: 5, CleanRows: 1, SuspiciousUsernames: 2, ZeroOnlyRows: 2, MissingMonths: []string{}, EmptyMonths: []string{}, NoDataMonths: []string{}},
+		},
+		{
+			"../test_data/multiple_errors.csv",
+			dataQuality{Score: 25, Rows: 4, CleanRows: 1, InvalidRows: 3, MissingMonths: []string{}, EmptyMonths: []string{}, NoDataMonths: []string{}},
+		},
+		{
+			"../test_data/quality_gaps.csv",
+			dataQuality{Score: 100, Rows: 2, CleanRows: 2, MissingMonths: []string{"2023-03", "2023-05", "2023-06"}, EmptyMonths: []string{"2023-02"}, NoDataMonths: []string{}},
+		},
+		{
+			// The months without data are not months without activity
+			"../test_data/no_data_months.csv",
+			dataQuality{Score: 66.7, Rows: 3, CleanRows: 2, ZeroOnlyRows: 1, MissingMonths: []string{}, EmptyMonths: []string{}, NoDataMonths: []string{"2023-02"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			got, err := computeDataQuality(tt.fileName, validatePivotTable(tt.fileName))
+			assert.NoError(t, err)
+			tt.want.File = tt.fileName
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// No quality without a valid header
+	_, err := computeDataQuality("../test_data/bad_first_column.csv", validatePivotTable("../test_data/bad_first_column.csv"))
+	assert.Error(t, err)
+}
+
+func Test_printDataQuality(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	printDataQuality(out, dataQuality{Score: 66.7, Rows: 3, CleanRows: 2, ZeroOnlyRows: 1, EmptyMonths: []string{"2023-02"}})
+
+	expected := "Data quality score: 66.7% (2 clean rows out of 3)\n" +
+		"  - Rows with problems: 0\n" +
+		"  - Suspicious usernames: 0\n" +
+		"  - Users without activity: 1\n" +
+		"  - Missing months: 0\n" +
+		"  - Months without activity: 1\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_ExecuteCheck_qualityJSON(t *testing.T) {
+	qualityFile := filepath.Join(t.TempDir(), "quality.json")
+	defer func() { _ = checkCmd.PersistentFlags().Set("quality-json", "") }()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/quality_gaps.csv", "--quality-json", qualityFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(qualityFile)
+	assert.NoError(t, err)
+	var quality dataQuality
+	assert.NoError(t, json.Unmarshal(content, &quality))
+	assert.Equal(t, 100.0, quality.Score)
+	assert.Equal(t, []string{"2023-03", "2023-05", "2023-06"}, quality.MissingMonths)
+}
@@ -0,0 +1,255 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+var regionsOutputFileName string
+
+const regionUnknown = "Unknown"
+
+// The regions, in the order they are tested (North America is last as "america" is ambiguous)
+var regionNames = []string{"Latin America", "Europe", "Asia", "Africa", "Oceania", "North America"}
+
+// Keywords (countries, states and main cities) found in the self-declared GitHub locations.
+// The keywords are lower case and matched on whole words.
+var regionKeywords = map[string][]string{
+	"Europe": {"europe", "france", "paris", "lyon", "toulouse", "germany", "deutschland", "berlin", "munich", "münchen", "hamburg",
+		"frankfurt", "cologne", "stuttgart", "uk", "united kingdom", "england", "london", "manchester", "cambridge", "scotland",
+		"edinburgh", "wales", "ireland", "dublin", "spain", "españa", "madrid", "barcelona", "valencia", "italy", "italia", "rome",
+		"milan", "turin", "netherlands", "amsterdam", "rotterdam", "belgium", "belgique", "brussels", "switzerland", "zurich",
+		"zürich", "geneva", "austria", "vienna", "wien", "poland", "polska", "warsaw", "krakow", "kraków", "wroclaw", "czech",
+		"czechia", "prague", "brno", "sweden", "stockholm", "gothenburg", "norway", "oslo", "denmark", "copenhagen", "finland",
+		"helsinki", "portugal", "lisbon", "porto", "greece", "athens", "hungary", "budapest", "romania", "bucharest", "ukraine",
+		"kyiv", "kiev", "kharkiv", "lviv", "russia", "moscow", "saint petersburg", "belarus", "minsk", "bulgaria", "sofia",
+		"serbia", "belgrade", "croatia", "zagreb", "slovakia", "bratislava", "slovenia", "ljubljana", "estonia", "tallinn",
+		"latvia", "riga", "lithuania", "vilnius", "luxembourg", "turkey", "türkiye", "istanbul", "ankara"},
+	"North America": {"usa", "us", "united states", "america", "canada", "toronto", "vancouver", "montreal", "montréal", "ottawa",
+		"calgary", "new york", "nyc", "brooklyn", "san francisco", "sf", "bay area", "silicon valley", "san jose", "seattle",
+		"boston", "chicago", "austin", "dallas", "houston", "texas", "california", "colorado", "denver", "boulder", "raleigh",
+		"north carolina", "los angeles", "portland", "oregon", "atlanta", "washington", "virginia", "florida", "miami",
+		"minnesota", "ohio", "pennsylvania", "philadelphia", "pittsburgh", "massachusetts", "new jersey", "utah", "arizona",
+		"phoenix", "michigan", "illinois", "georgia"},
+	"Latin America": {"latin america", "south america", "mexico", "méxico", "brazil", "brasil", "são paulo", "sao paulo", "rio de janeiro", "argentina",
+		"buenos aires", "chile", "santiago", "colombia", "bogota", "bogotá", "medellin", "medellín", "peru", "perú", "lima",
+		"uruguay", "montevideo", "venezuela", "caracas", "ecuador", "quito", "costa rica", "cuba", "guatemala", "bolivia", "paraguay"},
+	"Asia": {"asia", "india", "bangalore", "bengaluru", "pune", "mumbai", "delhi", "new delhi", "hyderabad", "chennai", "kolkata",
+		"noida", "gurgaon", "gurugram", "ahmedabad", "jaipur", "kerala", "china", "beijing", "shanghai", "shenzhen", "hangzhou",
+		"guangzhou", "chengdu", "japan", "tokyo", "osaka", "kyoto", "korea", "seoul", "taiwan", "taipei", "singapore",
+		"hong kong", "vietnam", "viet nam", "hanoi", "ho chi minh", "indonesia", "jakarta", "philippines", "manila", "thailand",
+		"bangkok", "malaysia", "kuala lumpur", "pakistan", "karachi", "lahore", "bangladesh", "dhaka", "sri lanka", "colombo",
+		"nepal", "kathmandu", "israel", "tel aviv", "jerusalem", "uae", "dubai", "iran", "tehran", "saudi arabia", "jordan"},
+	"Africa": {"africa", "nigeria", "lagos", "abuja", "kenya", "nairobi", "egypt", "cairo", "south africa", "cape town",
+		"johannesburg", "ghana", "accra", "morocco", "casablanca", "rabat", "tunisia", "tunis", "algeria", "algiers", "ethiopia",
+		"addis ababa", "uganda", "kampala", "cameroon", "senegal", "dakar", "rwanda", "kigali", "tanzania", "zimbabwe"},
+	"Oceania": {"australia", "sydney", "melbourne", "brisbane", "perth", "adelaide", "canberra", "new zealand", "auckland",
+		"wellington", "christchurch"},
+}
+
+// regionsCmd represents the regions command
+var regionsCmd = &cobra.Command{
+	Use:   "regions [input file]",
+	Short: "Aggregates the top submitters by world region (uses the GitHub API)",
+	Long: `The REGIONS command extracts the top submitters (as the EXTRACT command does) and
+aggregates them by world region, based on the location declared in their GitHub profile.
+It supports the "global community" section of the yearly report.
+
+This command is opt-in as it queries the GitHub API for each top submitter. The
+lookups are cached (see "--cache-file" and "--cache-ttl") so that the monthly runs
+stay within the API rate limits. Profiles without a recognized location are counted
+as "Unknown".
+
+The format is selected by the extension of the output file (".md" for Markdown) or with "--format".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		result, realEndDate, topSubmitters := extractData(cmd.Context(), args[0], topSize, endMonth, period, 0, InputTypeSubmitters)
+		if !result {
+			return extractionError(cmd.Context(), "Failed to extract data")
+		}
+
+		client, err := newAuthenticatedGitHubClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		cache, err := openGitHubCache()
+		if err != nil {
+			return err
+		}
+
+		regionsOfUsers, err := lookupRegions(cmd.Context(), client, cache, topSubmitters)
+		cache.close()
+		if err != nil {
+			return err
+		}
+
+		regions := aggregateByRegion(topSubmitters, regionsOfUsers)
+
+		if dirErr := CheckDir(regionsOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		introduction := fmt.Sprintf("# Top Submitters by Region\n\nRegions (from the GitHub profile location) of the %d top submitters \nover the %d months before \"%s\".\n\n", topSize, period, realEndDate)
+		options := renderOptions{Title: "Top Submitters by Region", Introduction: introduction, InputType: InputTypeSubmitters}
+		if err := writeResultTable(regionsOutputFileName, regions, options); err != nil {
+			return err
+		}
+		logInfo("Regions of the top submitters written to \"%s\"\n", regionsOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(regionsCmd)
+
+	regionsCmd.Flags().StringVarP(&regionsOutputFileName, "out", "o", "top-submitters-regions.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	regionsCmd.Flags().IntVarP(&topSize, "topSize", "t", 35, "Number of top submitters to extract.")
+	regionsCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months to accumulate.")
+	regionsCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Month to extract top submitters.")
+	addGitHubTokenFlag(regionsCmd)
+	addGitHubCacheFlags(regionsCmd)
+	addFormatFlag(regionsCmd)
+
+	regionsCmd.ValidArgsFunction = completeInputFile
+	_ = regionsCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Looks up the region of each top submitter (first column, after the header)
+func lookupRegions(ctx context.Context, client *githubClient, cache *githubCache, topSubmitters [][]string) (map[string]string, error) {
+	regions := make(map[string]string)
+	now := time.Now()
+	for i, dataLine := range topSubmitters {
+		//Skip header line
+		if i == 0 {
+			continue
+		}
+		if dataLine[0] == "deleted_user" {
+			regions[dataLine[0]] = regionUnknown
+			continue
+		}
+		user, err := cache.getUser(ctx, client, dataLine[0], now)
+		if errors.Is(err, errGitHubNotFound) {
+			regions[dataLine[0]] = regionUnknown
+			continue
+		}
+		if err != nil {
+			return regions, err
+		}
+		regions[dataLine[0]] = locationToRegion(user.Location)
+		logVerbose("  %s: \"%s\" -> %s\n", dataLine[0], user.Location, regions[dataLine[0]])
+	}
+	return regions, nil
+}
+
+// Guesses the world region of a free text location. The parts of the location
+// are tested from the last one, as it usually is the country.
+func locationToRegion(location string) string {
+	parts := strings.Split(strings.ToLower(location), ",")
+	for i := len(parts) - 1; i >= 0; i-- {
+		words := " " + strings.Join(strings.FieldsFunc(parts[i], func(r rune) bool { return !unicode.IsLetter(r) }), " ") + " "
+		for _, region := range regionNames {
+			for _, keyword := range regionKeywords[region] {
+				if strings.Contains(words, " "+keyword+" ") {
+					return region
+				}
+			}
+		}
+	}
+	return regionUnknown
+}
+
+// Aggregates the top submitters (name and total) by region, the most active regions first
+func aggregateByRegion(topSubmitters [][]string, regionsOfUsers map[string]string) [][]string {
+	type regionTotal struct {
+		Region     string
+		Submitters int
+		Total      int
+	}
+	totals := make(map[string]*regionTotal)
+	grandTotal := 0
+	for i, dataLine := range topSubmitters {
+		//Skip header line
+		if i == 0 {
+			continue
+		}
+		region := regionsOfUsers[dataLine[0]]
+		if region == "" {
+			region = regionUnknown
+		}
+		if totals[region] == nil {
+			totals[region] = &regionTotal{Region: region}
+		}
+		value, _ := strconv.Atoi(dataLine[1])
+		totals[region].Submitters++
+		totals[region].Total += value
+		grandTotal += value
+	}
+
+	sorted := make([]*regionTotal, 0, len(totals))
+	for _, total := range totals {
+		sorted = append(sorted, total)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Total != sorted[j].Total {
+			return sorted[i].Total > sorted[j].Total
+		}
+		return sorted[i].Region < sorted[j].Region
+	})
+
+	output := [][]string{{"Region", "Submitters", "Total_PRs", "Share"}}
+	for _, total := range sorted {
+		share := 0.0
+		if grandTotal > 0 {
+			share = float64(total.Total) * 100 / float64(grandTotal)
+		}
+		output = append(output, []string{total.Region, strconv.Itoa(total.Submitters), strconv.Itoa(total.Total), formatMetric(share)})
+	}
+	return output
+}
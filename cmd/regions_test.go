@@ -0,0 +1,127 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_locationToRegion(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{"Paris, France", "Europe"},
+		{"Bengaluru", "Asia"},
+		{"Raleigh, NC, USA", "North America"},
+		{"São Paulo, Brazil", "Latin America"},
+		{"South America", "Latin America"},
+		{"Sydney NSW", "Oceania"},
+		{"Lagos, Nigeria", "Africa"},
+		{"Indianapolis", regionUnknown},
+		{"Planet Earth", regionUnknown},
+		{"", regionUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			assert.Equal(t, tt.want, locationToRegion(tt.location))
+		})
+	}
+}
+
+func Test_aggregateByRegion(t *testing.T) {
+	topSubmitters := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "50"},
+		{"bravo", "30"},
+		{"charly", "15"},
+		{"delta", "5"},
+	}
+	regionsOfUsers := map[string]string{"alpha": "Europe", "bravo": "Asia", "charly": "Europe"}
+
+	expected := [][]string{
+		{"Region", "Submitters", "Total_PRs", "Share"},
+		{"Europe", "2", "65", "65"},
+		{"Asia", "1", "30", "30"},
+		{"Unknown", "1", "5", "5"},
+	}
+	assert.Equal(t, expected, aggregateByRegion(topSubmitters, regionsOfUsers))
+}
+
+func Test_githubUserCache(t *testing.T) {
+	server := startFakeGitHub(t, map[string]githubUser{
+		"alpha": {Login: "alpha", Location: "Paris"},
+	})
+	cacheFile := filepath.Join(t.TempDir(), "cache", "github-users.json")
+	now := time.Now()
+
+	cache, err := loadGitHubCache(cacheFile, time.Hour)
+	assert.NoError(t, err)
+	client := newGitHubClient("")
+	user, err := cache.getUser(context.Background(), client, "alpha", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "Paris", user.Location)
+	_, err = cache.getUser(context.Background(), client, "ghost", now)
+	assert.ErrorIs(t, err, errGitHubNotFound)
+	assert.NoError(t, cache.save())
+
+	// GitHub is not called anymore while the entries are fresh
+	server.Close()
+	cache, err = loadGitHubCache(cacheFile, time.Hour)
+	assert.NoError(t, err)
+	user, err = cache.getUser(context.Background(), client, "alpha", now.Add(30*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "Paris", user.Location)
+	_, err = cache.getUser(context.Background(), client, "ghost", now.Add(30*time.Minute))
+	assert.ErrorIs(t, err, errGitHubNotFound)
+
+	// Expired entries are fetched again
+	_, err = cache.getUser(context.Background(), client, "alpha", now.Add(2*time.Hour))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errGitHubNotFound)
+}
+
+func Test_ExecuteRegions(t *testing.T) {
+	startFakeGitHub(t, map[string]githubUser{
+		"adi10hero":      {Login: "ADI10HERO", Location: "Mumbai, India"},
+		"95-jonpet":      {Login: "95-jonpet", Location: "Oslo"},
+		"adityadas1999":  {Login: "adityadas1999", Location: "Kolkata"},
+		"apedevelopment": {Login: "APEdevelopment"},
+	})
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "regions.csv")
+	cacheFile := filepath.Join(tempDir, "cache.json")
+
+	rootCmd.SetArgs([]string{"regions", "../test_data/deleted_user_case.csv", "-m", "latest", "-p", "40", "-t", "35", "--cache-file", cacheFile, "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.FileExists(t, cacheFile)
+	records, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Asia", "2", "27", "72.97297297297297"}, records[1])
+}
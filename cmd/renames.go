@@ -0,0 +1,187 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var renamesOutputFileName string
+
+// Header of the alias files
+var aliasFileHeader = []string{"alias", "login"}
+
+// detectRenamesCmd represents the detect-renames command
+var detectRenamesCmd = &cobra.Command{
+	Use:   "detect-renames [input file]",
+	Short: "Detects the usernames that were renamed or no longer exist on GitHub",
+	Long: `The DETECT-RENAMES command checks each username of the pivot table with the GitHub
+API and flags the ones that no longer exist or that redirect to a different login
+(renamed accounts). Simple casing differences are ignored.
+
+The renames are written as a suggested alias file ("alias,login" CSV) that can be
+reviewed and used with "normalize --aliases" to merge the lines of the renamed
+accounts. The usernames that no longer exist are listed as comments.
+
+A GitHub token (or a GitHub App, see "--github-app-id") is strongly advised, as anonymous
+calls are limited to 60 per hour.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		client, err := newAuthenticatedGitHubClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		cache, err := openGitHubCache()
+		if err != nil {
+			return err
+		}
+		canonicalNames, unknownUsers, err := resolveCanonicalNames(cmd.Context(), client, cache, dataset.Users)
+		cache.close()
+		if err != nil {
+			return err
+		}
+		renames := filterRenames(canonicalNames)
+
+		if dirErr := CheckDir(renamesOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		out, err := os.Create(renamesOutputFileName)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		if err := writeAliases(out, renames, unknownUsers); err != nil {
+			return err
+		}
+
+		logInfo("%d renamed and %d missing accounts, aliases written to \"%s\"\n", len(renames), len(unknownUsers), renamesOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(detectRenamesCmd)
+
+	detectRenamesCmd.Flags().StringVarP(&renamesOutputFileName, "out", "o", "aliases.csv", "Output file name of the suggested aliases")
+	addGitHubTokenFlag(detectRenamesCmd)
+	addGitHubCacheFlags(detectRenamesCmd)
+
+	detectRenamesCmd.ValidArgsFunction = completeInputFile
+}
+
+// Keeps only the real renames (not the casing differences)
+func filterRenames(canonicalNames map[string]string) map[string]string {
+	renames := make(map[string]string)
+	for alias, login := range canonicalNames {
+		if !strings.EqualFold(alias, login) {
+			renames[alias] = login
+		}
+	}
+	return renames
+}
+
+// Writes the aliases (sorted) as CSV, followed by the unknown users as comments
+func writeAliases(out io.Writer, aliases map[string]string, unknownUsers []string) error {
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+
+	csvOut := csv.NewWriter(out)
+	if err := csvOut.Write(aliasFileHeader); err != nil {
+		return err
+	}
+	for _, alias := range names {
+		if err := csvOut.Write([]string{alias, aliases[alias]}); err != nil {
+			return err
+		}
+	}
+	csvOut.Flush()
+	if err := csvOut.Error(); err != nil {
+		return err
+	}
+
+	for _, user := range unknownUsers {
+		if _, err := fmt.Fprintf(out, "# %s: not found on GitHub\n", user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Loads an alias file ("alias,login" CSV, lines starting with "#" are ignored)
+func loadAliases(fileName string) (map[string]string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = len(aliasFileHeader)
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid alias file %s: %v", fileName, err)
+	}
+
+	aliases := make(map[string]string)
+	for i, line := range lines {
+		if i == 0 && strings.EqualFold(line[0], aliasFileHeader[0]) {
+			continue
+		}
+		if line[0] == "" || line[1] == "" {
+			return nil, fmt.Errorf("Invalid alias \"%s\" in %s", strings.Join(line, ","), fileName)
+		}
+		aliases[line[0]] = line[1]
+	}
+	return aliases, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterRenames(t *testing.T) {
+	canonicalNames := map[string]string{"markewaite": "MarkEWaite", "old-name": "new-name"}
+
+	assert.Equal(t, map[string]string{"old-name": "new-name"}, filterRenames(canonicalNames))
+}
+
+func Test_aliasesRoundTrip(t *testing.T) {
+	out := new(bytes.Buffer)
+	aliases := map[string]string{"old-name": "new-name", "another": "alpha"}
+
+	err := writeAliases(out, aliases, []string{"ghost"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alias,login\nanother,alpha\nold-name,new-name\n# ghost: not found on GitHub\n", out.String())
+
+	fileName := filepath.Join(t.TempDir(), "aliases.csv")
+	assert.NoError(t, os.WriteFile(fileName, out.Bytes(), 0644))
+	loaded, err := loadAliases(fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, aliases, loaded)
+}
+
+func Test_ExecuteDetectRenames(t *testing.T) {
+	startFakeGitHub(t, map[string]githubUser{
+		"0x41head":       {Login: "0x41head"},
+		"95-jonpet":      {Login: "jonpet"},
+		"adi10hero":      {Login: "ADI10HERO"},
+		"adityadas1999":  {Login: "adityadas1999"},
+		"apedevelopment": {Login: "APEdevelopment"},
+	})
+	tempDir := t.TempDir()
+	aliasFile := filepath.Join(tempDir, "aliases.csv")
+	normalizedFile := filepath.Join(tempDir, "normalized.csv")
+	defer func() {
+		_ = normalizeCmd.Flags().Set("aliases", "")
+		_ = normalizeCmd.Flags().Set("out", "")
+		_ = detectRenamesCmd.Flags().Set("no-cache", "false")
+	}()
+
+	rootCmd.SetArgs([]string{"detect-renames", "../test_data/deleted_user_case.csv", "-o", aliasFile, "--no-cache"})
+	assert.NoError(t, rootCmd.Execute())
+
+	content, err := os.ReadFile(aliasFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "alias,login\n95-jonpet,jonpet\n", string(content))
+
+	// The alias file is used to merge the lines
+	rootCmd.SetArgs([]string{"normalize", "../test_data/deleted_user_case.csv", "--aliases", aliasFile, "-o", normalizedFile})
+	assert.NoError(t, rootCmd.Execute())
+
+	records, err := loadInputPivotTable(normalizedFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "jonpet", records[3][0])
+}
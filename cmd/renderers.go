@@ -0,0 +1,368 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var resultFormat string
+var metricPrecision int
+
+// Output formats of the result tables
+const (
+	renderCSV      = "csv"
+	renderMarkdown = "md"
+	renderHTML     = "html"
+	renderJSON     = "json"
+	renderXLSX     = "xlsx"
+)
+
+// Writes a result table (header line first) in an output format. To support a new format,
+// implement this interface and register it with registerRenderer (in an init function).
+type Renderer interface {
+	// Extensions (lower case, with the dot) of the files written in this format
+	Extensions() []string
+	Render(fileName string, table [][]string, options renderOptions) error
+}
+
+// Optionally implemented by the renderers of the formats read by humans: the number of decimals
+// the computed metrics (percentages, averages, ratios) are rounded to. They are written as computed otherwise.
+type metricRounder interface {
+	MetricDecimals() int
+}
+
+// What a command knows about its output. The renderers use what makes sense for their format.
+type renderOptions struct {
+	Title        string               // title of the document (HTML)
+	Introduction string               // text before the table (Markdown)
+	Decorations  *markdownDecorations // nil if the command doesn't support them (Markdown)
+	IsHistory    bool                 // links the users to their plot (Markdown)
+	InputType    InputType
+}
+
+// The renderers, by format name, in registration order
+var rendererRegistry = map[string]Renderer{}
+var rendererFormats []string
+
+// Makes an output format available to all the commands writing result tables
+func registerRenderer(format string, renderer Renderer) {
+	if _, isRegistered := rendererRegistry[format]; isRegistered {
+		panic(fmt.Sprintf("A renderer is already registered for the \"%s\" format", format))
+	}
+	rendererRegistry[format] = renderer
+	rendererFormats = append(rendererFormats, format)
+}
+
+// Adds the flag forcing the output format (deduced from the file extension otherwise) and
+// the one humanizing the large counts
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&resultFormat, "format", "", "", "Output format (csv, md, html, json or xlsx), deduced from the output file extension by default")
+	cmd.Flags().BoolVarP(&isHumanized, "humanize", "", false, "Renders the large counts as 1.2k or 3.4M in Markdown and HTML, the exact values being in tooltips")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{renderCSV, renderMarkdown, renderHTML, renderJSON, renderXLSX}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Returns the format to use for the output file: the requested one or the one of
+// its extension (CSV if unknown)
+func selectRenderFormat(fileName string, format string) (string, error) {
+	if format != "" {
+		if _, isRegistered := rendererRegistry[format]; !isRegistered {
+			return "", fmt.Errorf("\"%s\" is an invalid output format (should be one of %s)", format, strings.Join(rendererFormats, ", "))
+		}
+		return format, nil
+	}
+	for _, registered := range rendererFormats {
+		if hasExtension(fileName, rendererRegistry[registered].Extensions()...) {
+			return registered, nil
+		}
+	}
+	return renderCSV, nil
+}
+
+// Writes a result table in the format requested with "--format" or else deduced from the file extension
+func writeResultTable(fileName string, table [][]string, options renderOptions) error {
+	format, err := selectRenderFormat(fileName, resultFormat)
+	if err != nil {
+		return err
+	}
+	return renderTable(fileName, format, table, options)
+}
+
+// Writes the result table in the given format
+func renderTable(fileName string, format string, table [][]string, options renderOptions) error {
+	renderer, isRegistered := rendererRegistry[format]
+	if !isRegistered {
+		return fmt.Errorf("Unsupported output format \"%s\"", format)
+	}
+	decimals := -1
+	if rounder, isRounder := renderer.(metricRounder); isRounder {
+		decimals = rounder.MetricDecimals()
+	}
+	if metricPrecision >= 0 {
+		decimals = metricPrecision
+	}
+	if decimals >= 0 {
+		table = roundMetrics(table, decimals)
+	}
+	logVerbose("Writing \"%s\" (%s format)\n", fileName, format)
+	span := startSpan("render")
+	span.setAttribute("file", fileName)
+	span.setAttribute("format", format)
+	err := renderer.Render(fileName, table, options)
+	span.finish(err)
+	return err
+}
+
+type csvRenderer struct{}
+
+func (csvRenderer) Extensions() []string { return []string{".csv"} }
+
+func (csvRenderer) Render(fileName string, table [][]string, options renderOptions) error {
+	return writeResultCSV(fileName, table)
+}
+
+type markdownRenderer struct{}
+
+func (markdownRenderer) Extensions() []string { return []string{".md", ".markdown"} }
+
+func (markdownRenderer) MetricDecimals() int { return 1 }
+
+func (markdownRenderer) Render(fileName string, table [][]string, options renderOptions) error {
+	if options.Decorations != nil {
+		return writeDecoratedMarkdown(fileName, table, options.Introduction, *options.Decorations, options.IsHistory, options.InputType)
+	}
+	return writeDataAsMarkdown(fileName, table, options.Introduction, options.IsHistory, options.InputType)
+}
+
+// A standalone page with the table (the numbers are right aligned)
+type htmlRenderer struct{}
+
+func (htmlRenderer) Extensions() []string { return []string{".html", ".htm"} }
+
+func (htmlRenderer) MetricDecimals() int { return 1 }
+
+func (htmlRenderer) Render(fileName string, table [][]string, options renderOptions) error {
+	title := options.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	out := bufio.NewWriter(f)
+
+	fmt.Fprintf(out, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", html.EscapeString(title))
+	// The provenance header is an HTML comment, written as is (like in the Markdown output)
+	fmt.Fprintf(out, "%s\n<h1>%s</h1>\n<table>\n", getProvenanceHeader(), html.EscapeString(title))
+	for i, dataLine := range table {
+		if i == 0 {
+			out.WriteString("<thead>\n<tr>")
+		} else {
+			out.WriteString("<tr>")
+		}
+		for column, value := range dataLine {
+			_, isNumeric := numericText(value)
+			switch {
+			case i == 0:
+				fmt.Fprintf(out, "<th>%s</th>", html.EscapeString(value))
+			case isNumeric && column > 0:
+				// The exact value of a humanized count is in the tooltip
+				if short, isShortened := humanizeCount(value); isHumanized && isShortened {
+					fmt.Fprintf(out, "<td style=\"text-align: right\" title=\"%s\">%s</td>", html.EscapeString(value), html.EscapeString(short))
+				} else {
+					fmt.Fprintf(out, "<td style=\"text-align: right\">%s</td>", html.EscapeString(value))
+				}
+			default:
+				fmt.Fprintf(out, "<td>%s</td>", html.EscapeString(value))
+			}
+		}
+		if i == 0 {
+			out.WriteString("</tr>\n</thead>\n<tbody>\n")
+		} else {
+			out.WriteString("</tr>\n")
+		}
+	}
+	out.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+	return out.Flush()
+}
+
+// An array with an object per line, with the header as keys (in the column order).
+// The counts are numbers, so that the consumers don't have to convert them.
+type jsonRenderer struct{}
+
+func (jsonRenderer) Extensions() []string { return []string{".json"} }
+
+func (jsonRenderer) Render(fileName string, table [][]string, options renderOptions) error {
+	isNumericColumn := numericColumns(table)
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, dataLine := range table {
+		if i == 0 {
+			continue
+		}
+		if i > 1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n  {")
+		for column, value := range dataLine {
+			if column > 0 {
+				sb.WriteString(", ")
+			}
+			key, _ := json.Marshal(table[0][column])
+			sb.Write(key)
+			sb.WriteString(": ")
+			// The first column (the user) is always a string, even if it looks like a number
+			if number, isNumeric := numericText(value); isNumeric && column > 0 {
+				sb.WriteString(number)
+			} else if value == "" && isNumericColumn[column] {
+				// A month without data in a column of counts
+				sb.WriteString("null")
+			} else {
+				encoded, _ := json.Marshal(value)
+				sb.Write(encoded)
+			}
+		}
+		sb.WriteString("}")
+	}
+	if len(table) > 1 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+	return os.WriteFile(fileName, []byte(sb.String()), 0644)
+}
+
+// An Excel workbook (the numbers are stored as numbers)
+type xlsxRenderer struct{}
+
+func (xlsxRenderer) Extensions() []string { return []string{".xlsx"} }
+
+func (xlsxRenderer) Render(fileName string, table [][]string, options renderOptions) error {
+	return writeXLSXSheet(fileName, "results", table)
+}
+
+// Matches the numbers (in the JSON syntax)
+var numberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// Tells whether the value is a number
+func isNumber(value string) bool {
+	return numberRegexp.MatchString(value)
+}
+
+// Returns the value in the JSON number syntax if it is a number. The explicit "+" of the
+// positive evolutions (ex: "+12") is removed.
+func numericText(value string) (string, bool) {
+	if strings.HasPrefix(value, "+") && !strings.HasPrefix(value, "+-") {
+		value = value[1:]
+	}
+	return value, isNumber(value)
+}
+
+// Formats a computed metric as is (ex: "0.3333333333333333"). It is rounded when rendered.
+func formatMetric(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Checks the "--precision" flag
+func checkMetricPrecision() error {
+	if metricPrecision < -1 {
+		return fmt.Errorf("Invalid precision %d (should be a number of decimals, or -1 for the default of the format)", metricPrecision)
+	}
+	return nil
+}
+
+// Returns a copy of the table with the metric columns rounded to the given number of decimals. The metric
+// columns are the ones with only numbers (or empty cells), at least one of them not being an integer.
+func roundMetrics(table [][]string, decimals int) [][]string {
+	if len(table) < 2 {
+		return table
+	}
+	isMetric := make([]bool, len(table[0]))
+	for column := 1; column < len(isMetric); column++ {
+		for _, dataLine := range table[1:] {
+			if column >= len(dataLine) || dataLine[column] == "" {
+				continue
+			}
+			if !isNumber(dataLine[column]) {
+				isMetric[column] = false
+				break
+			}
+			if strings.ContainsAny(dataLine[column], ".eE") {
+				isMetric[column] = true
+			}
+		}
+	}
+
+	rounded := make([][]string, len(table))
+	rounded[0] = table[0]
+	for i, dataLine := range table[1:] {
+		roundedLine := make([]string, len(dataLine))
+		for column, value := range dataLine {
+			roundedLine[column] = value
+			if column < len(isMetric) && isMetric[column] && value != "" {
+				number, _ := strconv.ParseFloat(value, 64)
+				roundedLine[column] = strconv.FormatFloat(number, 'f', decimals, 64)
+			}
+		}
+		rounded[i+1] = roundedLine
+	}
+	return rounded
+}
+
+// Tells, for each column, whether all its non-empty cells are numbers (the first column, the user, never is)
+func numericColumns(table [][]string) []bool {
+	isNumeric := make([]bool, len(table[0]))
+	for column := 1; column < len(isNumeric); column++ {
+		for _, dataLine := range table[1:] {
+			if column >= len(dataLine) || dataLine[column] == "" {
+				continue
+			}
+			if _, ok := numericText(dataLine[column]); !ok {
+				isNumeric[column] = false
+				break
+			}
+			isNumeric[column] = true
+		}
+	}
+	return isNumeric
+}
+
+// Registers the formats supported out of the box
+func init() {
+	registerRenderer(renderCSV, csvRenderer{})
+	registerRenderer(renderMarkdown, markdownRenderer{})
+	registerRenderer(renderHTML, htmlRenderer{})
+	registerRenderer(renderJSON, jsonRenderer{})
+	registerRenderer(renderXLSX, xlsxRenderer{})
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_selectRenderFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		format   string
+		want     string
+		wantErr  bool
+	}{
+		{"csv", "out/top.csv", "", renderCSV, false},
+		{"markdown", "out/top.MD", "", renderMarkdown, false},
+		{"html", "top.htm", "", renderHTML, false},
+		{"json", "top.json", "", renderJSON, false},
+		{"xlsx", "top.xlsx", "", renderXLSX, false},
+		{"unknown extension", "top.txt", "", renderCSV, false},
+		{"forced format", "top.csv", "json", renderJSON, false},
+		{"invalid format", "top.csv", "pdf", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectRenderFormat(tt.fileName, tt.format)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "\"pdf\" is an invalid output format (should be one of csv, md, html, json, xlsx)")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+var renderedTable = [][]string{
+	{"User", "Total", "Ratio"},
+	{"alice<bob>", "12", "0.5"},
+	{"007", "3", "-1.25e2"},
+}
+
+func Test_jsonRenderer(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "top.json")
+
+	err := jsonRenderer{}.Render(fileName, renderedTable, renderOptions{})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(fileName)
+	assert.Equal(t, "[\n"+
+		"  {\"User\": \"alice\\u003cbob\\u003e\", \"Total\": 12, \"Ratio\": 0.5},\n"+
+		"  {\"User\": \"007\", \"Total\": 3, \"Ratio\": -1.25e2}\n"+
+		"]\n", string(content))
+
+	err = jsonRenderer{}.Render(fileName, renderedTable[:1], renderOptions{})
+	assert.NoError(t, err)
+	content, _ = os.ReadFile(fileName)
+	assert.Equal(t, "[]\n", string(content))
+}
+
+func Test_jsonRenderer_keepsTypes(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "compare.json")
+	table := [][]string{
+		{"Submitter", "Total_PRs", "Evolution"},
+		{"42", "7", "+5"},
+		{"bob", "2", "-3"},
+	}
+
+	err := jsonRenderer{}.Render(fileName, table, renderOptions{})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(fileName)
+	assert.Equal(t, "[\n"+
+		"  {\"Submitter\": \"42\", \"Total_PRs\": 7, \"Evolution\": 5},\n"+
+		"  {\"Submitter\": \"bob\", \"Total_PRs\": 2, \"Evolution\": -3}\n"+
+		"]\n", string(content))
+}
+
+func Test_jsonRenderer_missingCounts(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "history.json")
+	table := [][]string{
+		{"Submitter", "2023-01", "2023-02", "Status"},
+		{"alice", "1", "", ""},
+		{"bob", "", "2", "New"},
+	}
+
+	err := jsonRenderer{}.Render(fileName, table, renderOptions{})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(fileName)
+	// The months without data are null, the other empty cells stay strings
+	assert.Equal(t, "[\n"+
+		"  {\"Submitter\": \"alice\", \"2023-01\": 1, \"2023-02\": null, \"Status\": \"\"},\n"+
+		"  {\"Submitter\": \"bob\", \"2023-01\": null, \"2023-02\": 2, \"Status\": \"New\"}\n"+
+		"]\n", string(content))
+}
+
+func Test_numericText(t *testing.T) {
+	tests := []struct {
+		value     string
+		want      string
+		isNumeric bool
+	}{
+		{"12", "12", true},
+		{"+5", "5", true},
+		{"-3", "-3", true},
+		{"0.5", "0.5", true},
+		{"+-5", "+-5", false},
+		{"007", "007", false},
+		{"NEW", "NEW", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, isNumeric := numericText(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.isNumeric, isNumeric)
+		})
+	}
+}
+
+func Test_roundMetrics(t *testing.T) {
+	table := [][]string{
+		{"Region", "Total_PRs", "Share", "Note"},
+		{"Europe", "65", "65", "1.5"},
+		{"Asia", "27", "72.97297297297297", "n/a"},
+		{"Unknown", "3", "", ""},
+	}
+
+	assert.Equal(t, [][]string{
+		{"Region", "Total_PRs", "Share", "Note"},
+		{"Europe", "65", "65.0", "1.5"},
+		{"Asia", "27", "73.0", "n/a"},
+		{"Unknown", "3", "", ""},
+	}, roundMetrics(table, 1))
+	assert.Equal(t, "72.97297297297297", table[2][2], "the table is not modified")
+	assert.Equal(t, "73", roundMetrics(table, 0)[2][2])
+}
+
+func Test_renderTable_precision(t *testing.T) {
+	tempDir := t.TempDir()
+	table := [][]string{{"Month", "Index"}, {"January", "0.9279650332016475"}}
+	defer func() { metricPrecision = -1 }()
+
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "raw.csv"), renderCSV, table, renderOptions{}))
+	content, _ := os.ReadFile(filepath.Join(tempDir, "raw.csv"))
+	assert.Equal(t, "Month,Index\nJanuary,0.9279650332016475\n", string(content))
+
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "rounded.md"), renderMarkdown, table, renderOptions{}))
+	content, _ = os.ReadFile(filepath.Join(tempDir, "rounded.md"))
+	assert.Contains(t, string(content), "| January | 0.9   |")
+
+	metricPrecision = 3
+	assert.NoError(t, renderTable(filepath.Join(tempDir, "precise.csv"), renderCSV, table, renderOptions{}))
+	content, _ = os.ReadFile(filepath.Join(tempDir, "precise.csv"))
+	assert.Equal(t, "Month,Index\nJanuary,0.928\n", string(content))
+}
+
+func Test_htmlRenderer(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "top.html")
+
+	err := htmlRenderer{}.Render(fileName, renderedTable, renderOptions{Title: "Top & co"})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(fileName)
+	assert.Contains(t, string(content), "<title>Top &amp; co</title>")
+	assert.Contains(t, string(content), "<body>\n"+getProvenanceHeader()+"\n<h1>")
+	assert.NotContains(t, string(content), "&lt;!--")
+	assert.Contains(t, string(content), "<thead>\n<tr><th>User</th><th>Total</th><th>Ratio</th></tr>\n</thead>")
+	assert.Contains(t, string(content), "<tr><td>alice&lt;bob&gt;</td><td style=\"text-align: right\">12</td><td style=\"text-align: right\">0.5</td></tr>")
+}
+
+func Test_xlsxRenderer(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "top.xlsx")
+
+	err := xlsxRenderer{}.Render(fileName, renderedTable, renderOptions{})
+
+	assert.NoError(t, err)
+	f, err := excelize.OpenFile(fileName)
+	assert.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows("results")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice<bob>", "12", "0.5"}, rows[1])
+	cellType, _ := f.GetCellType("results", "C2")
+	assert.NotEqual(t, excelize.CellTypeSharedString, cellType)
+
+	err = xlsxRenderer{}.Render(fileName, [][]string{{"Submitter", "Evolution"}, {"42", "+5"}}, renderOptions{})
+	assert.NoError(t, err)
+	f, err = excelize.OpenFile(fileName)
+	assert.NoError(t, err)
+	defer f.Close()
+	userType, _ := f.GetCellType("results", "A2")
+	evolutionType, _ := f.GetCellType("results", "B2")
+	assert.NotEqual(t, userType, evolutionType)
+	evolution, _ := f.GetCellValue("results", "B2")
+	assert.Equal(t, "5", evolution)
+}
+
+func Test_ExecuteExtractWithFormat(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.txt")
+	defer func() {
+		_ = extractCmd.Flags().Set("format", "")
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "--type=submitters", "--topSize=3", "--format=json", "--out=" + outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"Total_PRs\": ")
+}
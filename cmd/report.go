@@ -0,0 +1,321 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Report specification file
+type reportSpecFile struct {
+	Reports []reportSpec `yaml:"reports"`
+}
+
+// An output to generate. The keys are named after the flags of the EXTRACT and COMPARE commands
+// (and have the same default values).
+type reportSpec struct {
+	Name        string   `yaml:"name"`
+	Out         string   `yaml:"out"`         // the extension selects the format (CSV if unknown)
+	Format      string   `yaml:"format"`      // forces the format (csv, md, html, json or xlsx)
+	Type        string   `yaml:"type"`        // "submitters" or "commenters"
+	Month       string   `yaml:"month"`       // last month of the period
+	Period      *int     `yaml:"period"`      // number of months (0 for all)
+	TopSize     int      `yaml:"topSize"`     // number of top users
+	Compare     int      `yaml:"compare"`     // if not 0, compares with the situation X months before
+	Exclude     []string `yaml:"exclude"`     // users excluded from the ranking
+	MinTotal    int      `yaml:"minTotal"`    // minimum total to be listed
+	IntroFile   string   `yaml:"introFile"`   // template of the Markdown introduction (optional)
+	Annotations string   `yaml:"annotations"` // notes rendered as footnotes of the Markdown output (optional)
+	Highlight   []string `yaml:"highlight"`   // users highlighted in the Markdown output (optional)
+	Marker      string   `yaml:"marker"`      // appended to the highlighted users (bold if empty)
+	Medals      bool     `yaml:"medals"`      // decorates the top three of the Markdown output with medals
+	Bars        bool     `yaml:"bars"`        // adds a column with a bar proportional to the total
+	MaxRows     int      `yaml:"maxRows"`     // number of rows after which the Markdown table is split
+	SplitMode   string   `yaml:"splitMode"`   // "details" (default) or "files"
+	Destination string   `yaml:"destination"` // where the output is published, as with the PUBLISH command (optional)
+}
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report [input file] [report specification file]",
+	Short: "Generates all the outputs described in a report specification file",
+	Long: `The REPORT command generates, in one run over the same input, all the outputs
+described in a YAML report specification file (format, period, filters and destination
+of each output). The input file is only checked and loaded once.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isFileValid(args[1]) {
+			return fmt.Errorf("Invalid report specification file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		specs, err := loadReportSpecs(args[1])
+		if err != nil {
+			return err
+		}
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+		records, err := loadInputPivotTableContext(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range specs {
+			// Stops between two reports when interrupted
+			if err := cmd.Context().Err(); err != nil {
+				return err
+			}
+			if err := generateReport(cmd.Context(), records, spec); err != nil {
+				return fmt.Errorf("Report \"%s\" failed: %v", spec.Name, err)
+			}
+			logInfo("Report \"%s\" written to \"%s\"\n", spec.Name, spec.Out)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.ValidArgsFunction = completeInputFile
+}
+
+// Loads and validates the report specifications
+func loadReportSpecs(fileName string) ([]reportSpec, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var specFile reportSpecFile
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&specFile); err != nil {
+		return nil, fmt.Errorf("Invalid report specification \"%s\": %v", fileName, err)
+	}
+	if len(specFile.Reports) == 0 {
+		return nil, fmt.Errorf("No report defined in \"%s\"", fileName)
+	}
+
+	for i := range specFile.Reports {
+		spec := &specFile.Reports[i]
+		if spec.Out == "" {
+			return nil, fmt.Errorf("Report %d has no \"out\"", i+1)
+		}
+		if spec.Name == "" {
+			spec.Name = filepath.Base(spec.Out)
+		}
+		// Same default values as the flags
+		if spec.Type == "" {
+			spec.Type = "submitters"
+		}
+		if spec.Month == "" {
+			spec.Month = "latest"
+		}
+		if spec.Period == nil {
+			defaultPeriod := 12
+			spec.Period = &defaultPeriod
+		}
+		if spec.TopSize == 0 {
+			spec.TopSize = 35
+		}
+		if spec.SplitMode == "" {
+			spec.SplitMode = splitModeDetails
+		}
+		if _, err := spec.inputType(); err != nil {
+			return nil, err
+		}
+		if !isValidMonth(spec.Month, isVerbose()) {
+			return nil, fmt.Errorf("\"%s\" is an invalid month (report \"%s\")", spec.Month, spec.Name)
+		}
+		if _, err := selectRenderFormat(spec.Out, spec.Format); err != nil {
+			return nil, fmt.Errorf("%v (report \"%s\")", err, spec.Name)
+		}
+		if spec.Destination != "" {
+			if _, err := newPublisher(spec.Destination); err != nil {
+				return nil, fmt.Errorf("%v (report \"%s\")", err, spec.Name)
+			}
+		}
+	}
+	return specFile.Reports, nil
+}
+
+// Returns the type of data of the report
+func (spec reportSpec) inputType() (InputType, error) {
+	switch strings.ToLower(spec.Type) {
+	case "submitters":
+		return InputTypeSubmitters, nil
+	case "commenters":
+		return InputTypeCommenters, nil
+	}
+	return InputTypeUnknown, fmt.Errorf("%s is an invalid input type (report \"%s\")", spec.Type, spec.Name)
+}
+
+// Generates (and uploads if requested) a report from the loaded pivot table
+func generateReport(ctx context.Context, records [][]string, spec reportSpec) error {
+	inputType, err := spec.inputType()
+	if err != nil {
+		return err
+	}
+	records = excludeUsers(records, spec.Exclude)
+
+	span := startSpan("compute")
+	span.setAttribute("report", spec.Name)
+	topUsers, realEndDate, err := rankReportPeriod(records, spec, 0, inputType)
+	if err != nil {
+		span.finish(err)
+		return err
+	}
+	data := topUsers
+	if spec.Compare > 0 {
+		previousTopUsers, _, err := rankReportPeriod(records, spec, spec.Compare, inputType)
+		if err != nil {
+			span.finish(err)
+			return err
+		}
+		data = compareExtractedData(topUsers, previousTopUsers, inputType)
+	}
+	span.finish(nil)
+
+	if err := CheckDir(spec.Out); err != nil {
+		return err
+	}
+	outputFormat, err := selectRenderFormat(spec.Out, spec.Format)
+	if err != nil {
+		return err
+	}
+	options := renderOptions{Title: getTopTitle(inputType), InputType: inputType}
+	if outputFormat == renderMarkdown {
+		options.Introduction = getExtractIntroduction(inputType, spec.TopSize, *spec.Period, realEndDate)
+		if spec.Compare > 0 {
+			options.Introduction = getCompareIntroduction(inputType, spec.TopSize, *spec.Period, spec.Compare, realEndDate, nil)
+		}
+		if spec.IntroFile != "" {
+			introductionData := newIntroductionData(records[0], spec.Month, *spec.Period, spec.TopSize, spec.Compare, inputType, data)
+			options.Introduction, err = renderIntroductionFile(spec.IntroFile, introductionData)
+			if err != nil {
+				return err
+			}
+		}
+		options.Decorations = &markdownDecorations{AnnotationsFile: spec.Annotations, Highlighted: spec.Highlight, HighlightMarker: spec.Marker, IsWithMedals: spec.Medals, IsWithBars: spec.Bars, MaxRows: spec.MaxRows, SplitMode: spec.SplitMode}
+	}
+	if err := renderTable(spec.Out, outputFormat, data, options); err != nil {
+		return err
+	}
+
+	if spec.Destination != "" {
+		return uploadReport(ctx, spec)
+	}
+	return nil
+}
+
+// Ranks the users over the period of the report (moved back by offset months)
+func rankReportPeriod(records [][]string, spec reportSpec, offset int, inputType InputType) ([][]string, string, error) {
+	firstColumn, lastColumn, _, realEndDate := getBoundaries(records, spec.Month, *spec.Period, offset)
+	if lastColumn == 0 {
+		return nil, "", fmt.Errorf("The requested period is not available")
+	}
+
+	projected := make([][]string, 0, len(records))
+	for _, dataLine := range records {
+		line := make([]string, 0, lastColumn-firstColumn+2)
+		line = append(line, dataLine[0])
+		projected = append(projected, append(line, dataLine[firstColumn:lastColumn+1]...))
+	}
+
+	topUsers := rankTopUsers(projected, spec.TopSize, inputType)
+	if spec.MinTotal > 0 {
+		filtered := [][]string{topUsers[0]}
+		var removed []string
+		for _, line := range topUsers[1:] {
+			if total, _ := strconv.Atoi(line[1]); total >= spec.MinTotal {
+				filtered = append(filtered, line)
+			} else {
+				removed = append(removed, line[0])
+			}
+		}
+		if len(removed) > 0 {
+			recordTransformation(auditFilter, removed, "%d users below the minimum total of %d", len(removed), spec.MinTotal)
+		}
+		topUsers = filtered
+	}
+	return topUsers, realEndDate, nil
+}
+
+// Removes the given users (case insensitive) from the pivot table
+func excludeUsers(records [][]string, users []string) [][]string {
+	if len(users) == 0 {
+		return records
+	}
+	excluded := make(map[string]bool)
+	for _, user := range users {
+		excluded[strings.ToLower(user)] = true
+	}
+
+	filtered := [][]string{records[0]}
+	var removed []string
+	for _, dataLine := range records[1:] {
+		if excluded[strings.ToLower(dataLine[0])] {
+			removed = append(removed, dataLine[0])
+			continue
+		}
+		filtered = append(filtered, dataLine)
+	}
+	if len(removed) > 0 {
+		recordTransformation(auditExclusion, removed, "%d users excluded", len(removed))
+	}
+	return filtered
+}
+
+// Publishes the output of a report to its destination
+func uploadReport(ctx context.Context, spec reportSpec) (err error) {
+	span := startSpan("publish")
+	span.setAttribute("destination", spec.Destination)
+	defer func() { span.finish(err) }()
+
+	destination, err := newPublisher(spec.Destination)
+	if err != nil {
+		return err
+	}
+	return destination.publish(ctx, []string{spec.Out}, false)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadReportSpecs(t *testing.T) {
+	specs, err := loadReportSpecs("../test_data/reports.yaml")
+
+	assert.NoError(t, err)
+	assert.Len(t, specs, 3)
+	// Default values
+	assert.Equal(t, "yearly", specs[0].Name)
+	assert.Equal(t, "submitters", specs[0].Type)
+	assert.Equal(t, "latest", specs[0].Month)
+	assert.Equal(t, 12, *specs[0].Period)
+	assert.Equal(t, 10, specs[0].TopSize)
+	// The name defaults to the output file name
+	assert.Equal(t, "top-quarter.csv", specs[1].Name)
+	assert.Equal(t, []string{"basil", "DEPENDABOT"}, specs[1].Exclude)
+	assert.Equal(t, 0, *specs[2].Period)
+	assert.Equal(t, 35, specs[2].TopSize)
+	assert.Equal(t, "gs://stats-bucket/jenkins", specs[2].Destination)
+}
+
+func Test_loadReportSpecs_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no report", "reports: []\n"},
+		{"no output", "reports:\n  - name: a\n"},
+		{"unknown key", "reports:\n  - out: a.csv\n    size: 3\n"},
+		{"invalid type", "reports:\n  - out: a.csv\n    type: reviewers\n"},
+		{"invalid month", "reports:\n  - out: a.csv\n    month: 2023-13\n"},
+		{"invalid destination", "reports:\n  - out: a.csv\n    destination: ftp://server/path\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "reports.yaml")
+			assert.NoError(t, os.WriteFile(fileName, []byte(tt.content), 0644))
+			_, err := loadReportSpecs(fileName)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_generateReport(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "10", "0", "5"},
+		{"bravo", "1", "1", "1"},
+		{"charlie", "0", "7", "9"},
+		{"delta", "0", "0", "2"},
+	}
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+	period := 2
+	spec := reportSpec{Name: "test", Out: outputFile, Type: "submitters", Month: "latest", Period: &period, TopSize: 3, Exclude: []string{"CHARLIE"}, MinTotal: 2}
+
+	assert.NoError(t, generateReport(context.Background(), records, spec))
+
+	result, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Submitter", "Total_PRs"}, {"alpha", "5"}, {"bravo", "2"}, {"delta", "2"}}, result)
+
+	// Compared with the situation one month before
+	spec.Compare = 1
+	spec.Exclude = nil
+	spec.MinTotal = 0
+	spec.TopSize = 1
+	assert.NoError(t, generateReport(context.Background(), records, spec))
+
+	result, err = loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Submitter", "Total_PRs", "Status"}, {"charlie", "16", "new"}, {"alpha", "", "churned"}}, result)
+}
+
+func Test_generateReport_destination(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02"},
+		{"alpha", "10", "5"},
+	}
+	tempDir := t.TempDir()
+	publishDir := filepath.Join(tempDir, "published")
+	period := 2
+	// The destination is any destination of the PUBLISH command
+	spec := reportSpec{Name: "test", Out: filepath.Join(tempDir, "top.csv"), Type: "submitters", Month: "latest", Period: &period, TopSize: 3,
+		Destination: "file://" + filepath.ToSlash(publishDir)}
+
+	assert.NoError(t, generateReport(context.Background(), records, spec))
+
+	assert.FileExists(t, filepath.Join(publishDir, "top.csv"))
+}
+
+func Test_ExecuteReport(t *testing.T) {
+	tempDir := t.TempDir()
+	specFile := filepath.Join(tempDir, "reports.yaml")
+	spec := "reports:\n  - out: " + filepath.Join(tempDir, "top.md") + "\n    topSize: 10\n  - out: " + filepath.Join(tempDir, "top.csv") + "\n"
+	assert.NoError(t, os.WriteFile(specFile, []byte(spec), 0644))
+
+	rootCmd.SetArgs([]string{"report", "../test_data/overview.csv", specFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(tempDir, "top.md"))
+	top, err := loadInputPivotTable(filepath.Join(tempDir, "top.csv"))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(top), 36)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var publishStateFileName string
+var isPublishResume bool
+
+// A publication to a destination, recorded when it fails so that "--resume" retries it
+// with the already generated files
+type publishJob struct {
+	Destination string    `json:"destination"`
+	Paths       []string  `json:"paths"`
+	Error       string    `json:"error,omitempty"`
+	FailedAt    time.Time `json:"failed_at,omitempty"`
+}
+
+// Content of the state file: the publications that failed
+type publishState struct {
+	Failures []publishJob `json:"failures"`
+}
+
+// Loads the failed publications (none if the state file doesn't exist)
+func loadPublishState(fileName string) (publishState, error) {
+	var state publishState
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return state, fmt.Errorf("Invalid publish state file \"%s\": %v", fileName, err)
+	}
+	return state, nil
+}
+
+// Saves the failed publications. The state file is removed when nothing failed.
+func savePublishState(fileName string, state publishState) error {
+	if len(state.Failures) == 0 {
+		if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(content, '\n'), 0644)
+}
+
+// Publishes to each destination, even if a previous one failed. Returns the failed publications.
+func runPublishJobs(ctx context.Context, jobs []publishJob, now func() time.Time) []publishJob {
+	var failures []publishJob
+	for _, job := range jobs {
+		destination, err := newPublisher(job.Destination)
+		if err == nil {
+			span := startSpan("publish")
+			span.setAttribute("destination", job.Destination)
+			err = destination.publish(ctx, job.Paths, false)
+			span.finish(err)
+		}
+		if err != nil {
+			warn("Failed to publish to \"%s\": %v", job.Destination, err)
+			failures = append(failures, publishJob{Destination: job.Destination, Paths: job.Paths, Error: err.Error(), FailedAt: now().UTC()})
+		}
+	}
+	return failures
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fails the given number of times, then records what is published
+type flakyPublisher struct {
+	failures  *int
+	published *[]string
+}
+
+func (p *flakyPublisher) publish(ctx context.Context, paths []string, isDryRun bool) error {
+	if *p.failures > 0 {
+		*p.failures--
+		return fmt.Errorf("Service unavailable")
+	}
+	*p.published = append(*p.published, paths...)
+	return nil
+}
+
+func Test_publishState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "publish-failures.json")
+
+	state, err := loadPublishState(stateFile)
+	assert.NoError(t, err)
+	assert.Empty(t, state.Failures, "No state file")
+
+	failedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved := publishState{Failures: []publishJob{{Destination: "s3://stats", Paths: []string{"out"}, Error: "boom", FailedAt: failedAt}}}
+	assert.NoError(t, savePublishState(stateFile, saved))
+	state, err = loadPublishState(stateFile)
+	assert.NoError(t, err)
+	assert.Equal(t, saved, state)
+
+	assert.NoError(t, savePublishState(stateFile, publishState{}))
+	assert.NoFileExists(t, stateFile)
+
+	assert.NoError(t, os.WriteFile(stateFile, []byte("{"), 0644))
+	_, err = loadPublishState(stateFile)
+	assert.Error(t, err)
+}
+
+func Test_ExecutePublish_resume(t *testing.T) {
+	failures := 1
+	var published []string
+	registerPublisher("flaky", func(destination string) (publisher, error) {
+		return &flakyPublisher{failures: &failures, published: &published}, nil
+	})
+	defer delete(publishers, "flaky")
+	stateFile := filepath.Join(t.TempDir(), "publish-failures.json")
+	targetDir := t.TempDir()
+	defer resetFlags(publishCmd)
+
+	// The flaky destination fails, but the files are still published to the next one
+	rootCmd.SetArgs([]string{"publish", "flaky://stats", "../test_data/overview.csv", "--to", "file://" + filepath.ToSlash(targetDir), "--state-file", stateFile})
+	err := rootCmd.Execute()
+
+	assert.ErrorContains(t, err, "1 of the 2 destinations")
+	assert.FileExists(t, filepath.Join(targetDir, "overview.csv"))
+	state, err := loadPublishState(stateFile)
+	assert.NoError(t, err)
+	assert.Len(t, state.Failures, 1)
+	assert.Equal(t, "flaky://stats", state.Failures[0].Destination)
+	assert.Equal(t, "Service unavailable", state.Failures[0].Error)
+	assert.Empty(t, published)
+
+	// Only the failed destination is retried
+	assert.NoError(t, os.Remove(filepath.Join(targetDir, "overview.csv")))
+	resetFlags(publishCmd)
+	rootCmd.SetArgs([]string{"publish", "--resume", "--state-file", stateFile})
+	err = rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"../test_data/overview.csv"}, published)
+	assert.NoFileExists(t, filepath.Join(targetDir, "overview.csv"))
+	assert.NoFileExists(t, stateFile)
+}
@@ -22,11 +22,19 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var isInteractive bool
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "jenkins-contribution-aggregator",
@@ -37,27 +45,116 @@ number of GitHub PRs in the jenkinsci and jenkins-infra org per month for each
 of the submitters. 
 
 The CHECK command can be used to validate that the file is of the expected format.
-The EXTRACT command will list the 35 most active submitters for the given period.`,
-	// Uncomment the following line if your bare application
-	// has an action associated with it:
-	// Run: func(cmd *cobra.Command, args []string) { },
+The EXTRACT command will list the 35 most active submitters for the given period.
+
+Occasional users can use the "--interactive" flag to be prompted for the parameters.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !isInteractive {
+			return cmd.Help()
+		}
+		return runInteractive(cmd)
+	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputSchema(); err != nil {
+			return err
+		}
+		if err := checkMetricPrecision(); err != nil {
+			return err
+		}
+		resetAudit()
+		startRunStats(cmd.Name(), time.Now())
+		startTracing(cmd.CommandPath(), time.Now())
+		// The warnings file of the wizard is left to the command it runs (opening it twice would
+		// truncate it)
+		if cmd != cmd.Root() || !isInteractive {
+			if err := openWarningsFile(); err != nil {
+				return err
+			}
+		}
+		return lockRun(cmd.Context())
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return finishRun(cmd.Context())
+	},
+}
+
+// Releases the lock and emits the audit, the trace and the statistics of a successful run
+func finishRun(ctx context.Context) error {
+	unlockRun()
+	closeWarningsFile()
+	if err := writeAuditFile(os.Args, time.Now()); err != nil {
+		return err
+	}
+	if err := finishTracing(ctx, nil); err != nil {
+		return err
+	}
+	return finishRunStats(ctx, true)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	// Ctrl-C (or SIGTERM) cancels the context, so that the loading and the network calls stop cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := executeRoot(ctx)
+	stop()
+	if exitCode != 0 {
+		os.Exit(exitCode)
 	}
 }
 
+// Runs the command and finishes the run (even when it failed). Returns the exit code.
+func executeRoot(ctx context.Context) int {
+	err := rootCmd.ExecuteContext(ctx)
+	// Differing top lists are a successful run (the post run hooks were skipped by Cobra)
+	isDiffering := errors.Is(err, errLeaderboardsDiffer)
+	if isDiffering {
+		if err = finishRun(ctx); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+	}
+	// The lock is also released, and the trace and the statistics emitted, when the command failed
+	unlockRun()
+	if traceErr := finishTracing(ctx, err); traceErr != nil {
+		warn("%v", traceErr)
+	}
+	if statsErr := finishRunStats(ctx, err == nil); statsErr != nil {
+		warn("%v", statsErr)
+	}
+	switch {
+	case err != nil && isCompareExitCode:
+		// As "diff", 1 is reserved to the differing top lists
+		return 2
+	case err != nil || isDiffering:
+		return 1
+	}
+	return 0
+}
+
 func init() {
 	// Here you will define your flags and configuration settings.
 
 	//Disable the Cobra completion options
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
+	rootCmd.Flags().BoolVarP(&isInteractive, "interactive", "i", false, "Prompts for the operation and its parameters")
+	rootCmd.PersistentFlags().CountVarP(&verbosityCount, "verbose", "v", "Displays more details (\"-vv\" for even more)")
+	rootCmd.PersistentFlags().BoolVarP(&isQuiet, "quiet", "q", false, "Only displays the results and the errors")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
+	rootCmd.PersistentFlags().StringVarP(&warningsFileName, "warnings-file", "", "", "Writes the warnings to this file instead of the standard error")
+	rootCmd.PersistentFlags().BoolVarP(&isCanonicalCSV, "canonical-csv", "", false, "Writes the CSV files in a stable form (sorted pivot tables, fixed quoting, LF endings)")
+	rootCmd.PersistentFlags().StringVarP(&outputSchema, "output-schema", "", outputSchemaV1, "Version of the header of the CSV result tables (v1 or v2, see the documentation)")
+	rootCmd.PersistentFlags().IntVarP(&metricPrecision, "precision", "", -1, "Decimals of the computed metrics (percentages, averages, ratios), see the documentation")
+	rootCmd.PersistentFlags().BoolVarP(&isCRLFOutput, "crlf", "", false, "Ends the lines of the CSV files with CRLF (Windows, Excel)")
+	rootCmd.PersistentFlags().StringVarP(&lockFileName, "lock-file", "", "", "Lock file preventing concurrent runs (ex: writing in the same output directory)")
+	rootCmd.PersistentFlags().DurationVarP(&lockWait, "lock-wait", "", 0, "How long to wait for the lock held by another run (fails immediately by default)")
+	rootCmd.PersistentFlags().DurationVarP(&lockStaleAfter, "lock-stale", "", 24*time.Hour, "Age after which a lock is considered left over by a crashed run and is taken over")
+	rootCmd.PersistentFlags().BoolVarP(&isRunStats, "stats", "", false, "Displays statistics about the run at the end (duration, rows processed, memory)")
+	rootCmd.PersistentFlags().StringVarP(&statsPushgatewayURL, "stats-pushgateway", "", "", "Also pushes the statistics of the run to this Prometheus Pushgateway (requires \"--stats\")")
+	rootCmd.PersistentFlags().StringVarP(&statsJobName, "stats-job", "", "jenkins_contribution_aggregator", "Job name of the statistics pushed to the Pushgateway")
+	rootCmd.PersistentFlags().StringVarP(&auditFileName, "audit-file", "", "", "Writes the transformations applied to the data to this JSON file")
+
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.jenkins-contribution-aggregator.yaml)")
 
 }
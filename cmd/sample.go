@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var sampleSize int
+var sampleSeed int64
+
+// Adds the flags drawing a random sample of the contributors outside the top
+func addSampleFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVarP(&sampleSize, "sample", "", 0, "Also writes a random sample of this number of contributors outside the top (ex: for spotlight interviews)")
+	cmd.PersistentFlags().Int64VarP(&sampleSeed, "seed", "", 0, "Seed of the random sample (default is derived from the last month of the period)")
+}
+
+// Returns the name of the sample file written next to the output file
+func generateSampleFilename(outputFilename string) string {
+	return strings.TrimSuffix(outputFilename, filepath.Ext(outputFilename)) + "_sample.csv"
+}
+
+// Returns the default seed of a period: its last month as a number (ex: 202304), so that
+// re-running the same extraction draws the same sample
+func defaultSampleSeed(endMonth string) int64 {
+	seed, _ := strconv.ParseInt(strings.ReplaceAll(endMonth, "-", ""), 10, 64)
+	return seed
+}
+
+// Draws size contributors, active during the period but not in the top. The same seed
+// always gives the same sample.
+func drawSample(leaderboard []leaderboardEntry, topUsers [][]string, size int, seed int64) []leaderboardEntry {
+	isInTop := make(map[string]bool)
+	for _, dataLine := range topUsers[1:] {
+		isInTop[strings.ToLower(dataLine[0])] = true
+	}
+	var candidates []leaderboardEntry
+	for _, entry := range leaderboard {
+		if entry.Total > 0 && !isInTop[strings.ToLower(entry.User)] && entry.User != "deleted_user" {
+			candidates = append(candidates, entry)
+		}
+	}
+	// The draw must not depend on the order of the input file
+	sort.Slice(candidates, func(i, j int) bool {
+		return strings.ToLower(candidates[i].User) < strings.ToLower(candidates[j].User)
+	})
+
+	random := rand.New(rand.NewSource(seed))
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if size < len(candidates) {
+		candidates = candidates[:size]
+	}
+	return candidates
+}
+
+// Writes a random sample of the contributors outside the top (same columns as the top)
+func writeSample(ctx context.Context, sampleFilename string, inputFilename string, topUsers [][]string, endMonth string, period int, size int, seed int64) error {
+	dataset, err := loadDataset(ctx, inputFilename)
+	if err != nil {
+		return err
+	}
+	periodDataset, err := dataset.Period(endMonth, period)
+	if err != nil {
+		return err
+	}
+	if seed == 0 {
+		seed = defaultSampleSeed(periodDataset.Months[len(periodDataset.Months)-1])
+	}
+
+	sample := [][]string{topUsers[0][:2]}
+	for _, entry := range drawSample(periodDataset.Leaderboard(), topUsers, size, seed) {
+		sample = append(sample, []string{entry.User, strconv.Itoa(entry.Total)})
+	}
+	if err := writeCSVtoFile(sampleFilename, sample); err != nil {
+		return err
+	}
+	logInfo("Random sample of %d contributors (seed %d) written to \"%s\"\n", len(sample)-1, seed, sampleFilename)
+	if len(sample)-1 < size {
+		warn("Only %d contributors outside the top were active, the sample is smaller than requested", len(sample)-1)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_drawSample(t *testing.T) {
+	leaderboard := []leaderboardEntry{
+		{Rank: 1, User: "alpha", Total: 20},
+		{Rank: 2, User: "bravo", Total: 10},
+		{Rank: 3, User: "charlie", Total: 5},
+		{Rank: 4, User: "delta", Total: 3},
+		{Rank: 5, User: "echo", Total: 2},
+		{Rank: 6, User: "deleted_user", Total: 2},
+		{Rank: 7, User: "foxtrot", Total: 1},
+		{Rank: 8, User: "golf", Total: 0},
+	}
+	topUsers := [][]string{{"Submitter", "Total_PRs"}, {"alpha", "20"}, {"Bravo", "10"}}
+
+	sample := drawSample(leaderboard, topUsers, 2, 42)
+
+	assert.Len(t, sample, 2)
+	for _, entry := range sample {
+		assert.Contains(t, []string{"charlie", "delta", "echo", "foxtrot"}, entry.User)
+	}
+	// The same seed draws the same sample, whatever the order of the input
+	reversed := append([]leaderboardEntry(nil), leaderboard...)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	assert.Equal(t, sample, drawSample(reversed, topUsers, 2, 42))
+
+	// All the candidates if the sample is larger
+	assert.Len(t, drawSample(leaderboard, topUsers, 10, 42), 4)
+}
+
+func Test_defaultSampleSeed(t *testing.T) {
+	assert.Equal(t, int64(202304), defaultSampleSeed("2023-04"))
+}
+
+func Test_ExecuteExtractWithSample(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+	defer func() {
+		_ = extractCmd.PersistentFlags().Set("sample", "0")
+		_ = extractCmd.PersistentFlags().Set("seed", "0")
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "--type=submitters", "--topSize=5", "--sample=3", "--seed=7", "--out=" + outputFile})
+	err := rootCmd.Execute()
+	assert.NoError(t, err)
+	first, err := os.ReadFile(filepath.Join(filepath.Dir(outputFile), "top_sample.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, strings.Count(string(first), "\n"))
+
+	// Same pick when re-run
+	err = rootCmd.Execute()
+	assert.NoError(t, err)
+	second, _ := os.ReadFile(filepath.Join(filepath.Dir(outputFile), "top_sample.csv"))
+	assert.Equal(t, string(first), string(second))
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// Short description of the SARIF rules (indexed by the problem rule id)
+var sarifRuleDescriptions = map[string]string{
+	ruleReadError:       "The file can't be read as a CSV file",
+	ruleHeaderFormat:    "The header is not the one of a datamash pivot table",
+	ruleMissingData:     "The file doesn't contain enough data",
+	ruleColumnCount:     "The line doesn't have the same number of columns as the header",
+	ruleInvalidUser:     "The username doesn't follow the GitHub rules",
+	ruleInvalidValue:    "The value is not an integer",
+	ruleNegativeValue:   "The value is negative",
+	ruleDatasetMismatch: "The pivot table is not consistent with the one it is checked against",
+}
+
+// Subset of the SARIF 2.1.0 format we need
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// Writes the problems found in a file as a SARIF log
+func writeProblemsAsSarif(out io.Writer, fileName string, problems []dataProblem) error {
+	// Only the rules that were triggered are listed, in order of appearance
+	rules := []sarifRule{}
+	knownRules := make(map[string]bool)
+	results := []sarifResult{}
+	for _, problem := range problems {
+		if !knownRules[problem.Rule] {
+			knownRules[problem.Rule] = true
+			rules = append(rules, sarifRule{ID: problem.Rule, ShortDescription: sarifMessage{Text: sarifRuleDescriptions[problem.Rule]}})
+		}
+
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(fileName)}}
+		if problem.Line > 0 {
+			location.Region = &sarifRegion{StartLine: problem.Line, StartColumn: problem.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    problem.Rule,
+			Level:     "error",
+			Message:   sarifMessage{Text: problem.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	sarif := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "jenkins-contribution-aggregator",
+				Version:        version,
+				InformationURI: "https://github.com/jenkins-infra/jenkins-contribution-aggregator",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarif)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeProblemsAsSarif(t *testing.T) {
+	problems := validatePivotTable("../test_data/multiple_errors.csv")
+	out := new(bytes.Buffer)
+
+	err := writeProblemsAsSarif(out, "../test_data/multiple_errors.csv", problems)
+	assert.NoError(t, err)
+
+	var sarif sarifLog
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	assert.Len(t, sarif.Runs, 1)
+
+	run := sarif.Runs[0]
+	assert.Equal(t, "jenkins-contribution-aggregator", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, 4)
+	assert.Len(t, run.Results, 4)
+
+	result := run.Results[1]
+	assert.Equal(t, ruleInvalidValue, result.RuleID)
+	assert.Equal(t, "error", result.Level)
+	location := result.Locations[0].PhysicalLocation
+	assert.Equal(t, "../test_data/multiple_errors.csv", location.ArtifactLocation.URI)
+	assert.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 2}, location.Region)
+
+	// Whole line problem has no column
+	assert.Equal(t, &sarifRegion{StartLine: 4}, run.Results[3].Locations[0].PhysicalLocation.Region)
+}
+
+func Test_writeProblemsAsSarif_noProblem(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	err := writeProblemsAsSarif(out, "data.csv", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"results": []`)
+	assert.Contains(t, out.String(), `"rules": []`)
+}
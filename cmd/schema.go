@@ -0,0 +1,207 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaSampleSize int
+var schemaSampleMonths int
+var schemaSampleSeed int64
+var schemaOutputFileName string
+var schemaJSONName string
+
+// The JSON Schemas of the JSON outputs ("<name>.schema.json")
+//
+//go:embed schemas
+var jsonSchemas embed.FS
+
+const jsonSchemaSuffix = ".schema.json"
+
+const inputSchemaDescription = `The input file is a CSV pivot table as generated by the GNU "datamash" pivot function:
+
+  - the first line is the header. Its first column is empty, the other columns are the
+    months in the "YYYY-MM" format, from the oldest to the most recent one. At least two
+    months are required.
+  - each following line contains the data of one submitter (or commenter):
+      - the first column is the GitHub username (letters, digits and "-", less than 40
+        characters). "deleted_user" is accepted for the deleted GitHub accounts.
+      - the other columns are the number of PRs (or comments) of that month. They are
+        positive integers.
+  - all the lines have the same number of columns as the header.
+
+Example:
+
+,"2023-01","2023-02","2023-03"
+"alice",3,0,1
+"bob-the-builder",0,12,4
+"deleted_user",1,0,0
+`
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Describes the expected input file and generates sample files",
+	Long: `The SCHEMA command prints the layout of the input pivot table expected by the
+other commands.
+
+With "--sample", it generates instead a realistic synthetic pivot table of the given
+number of submitters (and "--months" months, ending last month). It can be used to test
+downstream pipelines or for demos. The same "--seed" generates the same table.
+
+With "--json", it prints the JSON Schema of one of the JSON outputs of the tool:
+  - "audit": the file written with "--audit-file",
+  - "calendar": the files of the CALENDAR command,
+  - "check-quality": the data quality written by "check --quality-json",
+  - "compare-diff": the changes written by "compare --diff-file",
+  - "dashboard": the dashboard data written with "--history-json",
+  - "convert-jsonl": a line of the "jsonl" format of the CONVERT command,
+  - "result-table": the result tables written in JSON (".json" or "--format json"),
+  - "search-index": the search index of the SITE command,
+  - "show": the output of "show --format json",
+  - "webhook": the payload POSTed with "--notify-webhook".
+With "--json all", all the schemas are written in the "--out" directory.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.NoArgs(cmd, args); err != nil {
+			return err
+		}
+		if schemaJSONName == "" || schemaJSONName == "all" {
+			return nil
+		}
+		if _, err := getJSONSchema(schemaJSONName); err != nil {
+			return fmt.Errorf("Unknown JSON output \"%s\" (should be one of %s or all)", schemaJSONName, strings.Join(getJSONSchemaNames(), ", "))
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if schemaJSONName != "" {
+			return writeJSONSchemas(cmd.OutOrStdout(), schemaJSONName, schemaOutputFileName)
+		}
+
+		if schemaSampleSize == 0 {
+			fmt.Fprint(cmd.OutOrStdout(), inputSchemaDescription)
+			return nil
+		}
+
+		spec := syntheticPivotSpec{
+			Submitters: schemaSampleSize,
+			Months:     schemaSampleMonths,
+			EndMonth:   time.Now().AddDate(0, -1, 0),
+			Skew:       1.0,
+			MaxMean:    20,
+			Seed:       schemaSampleSeed,
+		}
+		sample, err := generateSyntheticPivot(spec)
+		if err != nil {
+			return err
+		}
+
+		if schemaOutputFileName == "" {
+			return writeCSV(cmd.OutOrStdout(), sample)
+		}
+		if dirErr := CheckDir(schemaOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		if err := writeCSVtoFile(schemaOutputFileName, sample); err != nil {
+			return err
+		}
+		logInfo("Sample written to \"%s\"\n", schemaOutputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().IntVarP(&schemaSampleSize, "sample", "s", 0, "Generates a sample pivot table with that number of submitters")
+	schemaCmd.Flags().IntVarP(&schemaSampleMonths, "months", "", 24, "Number of months of the sample")
+	schemaCmd.Flags().Int64VarP(&schemaSampleSeed, "seed", "", 1, "Seed of the random generator")
+	schemaCmd.Flags().StringVarP(&schemaOutputFileName, "out", "o", "", "Output file name of the sample or the schema, directory with \"--json all\" (default is the standard output)")
+	schemaCmd.Flags().StringVarP(&schemaJSONName, "json", "", "", "Prints the JSON Schema of a JSON output (or \"all\")")
+
+	_ = schemaCmd.RegisterFlagCompletionFunc("json", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(getJSONSchemaNames(), "all"), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// Returns the names of the JSON outputs having a schema
+func getJSONSchemaNames() []string {
+	entries, _ := fs.ReadDir(jsonSchemas, "schemas")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), jsonSchemaSuffix))
+	}
+	return names
+}
+
+// Returns the JSON Schema of a JSON output
+func getJSONSchema(name string) ([]byte, error) {
+	return jsonSchemas.ReadFile("schemas/" + name + jsonSchemaSuffix)
+}
+
+// Writes the requested schema to the output file (or out if not specified).
+// All the schemas are written in the output directory when "all" is requested.
+func writeJSONSchemas(out io.Writer, name string, outputName string) error {
+	if name != "all" {
+		schema, err := getJSONSchema(name)
+		if err != nil {
+			return err
+		}
+		if outputName == "" {
+			_, err = out.Write(schema)
+			return err
+		}
+		if dirErr := CheckDir(outputName); dirErr != nil {
+			return dirErr
+		}
+		return os.WriteFile(outputName, schema, 0644)
+	}
+
+	if outputName == "" {
+		return fmt.Errorf("An output directory (\"--out\") is required with \"--json all\"")
+	}
+	if err := os.MkdirAll(outputName, 0755); err != nil {
+		return err
+	}
+	for _, schemaName := range getJSONSchemaNames() {
+		schema, err := getJSONSchema(schemaName)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outputName, schemaName+jsonSchemaSuffix), schema, 0644); err != nil {
+			return err
+		}
+	}
+	logInfo("Schemas written to \"%s\"\n", outputName)
+	return nil
+}
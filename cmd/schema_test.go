@@ -0,0 +1,252 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteSchema(t *testing.T) {
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{"schema"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "YYYY-MM")
+
+	out.Reset()
+	defer func() { _ = schemaCmd.Flags().Set("sample", "0") }()
+	rootCmd.SetArgs([]string{"schema", "--sample", "3", "--months", "4"})
+	assert.NoError(t, rootCmd.Execute())
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 4)
+	assert.Equal(t, 5, len(bytes.Split(lines[0], []byte(","))))
+}
+
+func Test_ExecuteSchema_json(t *testing.T) {
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+	defer func() { _ = schemaCmd.Flags().Set("json", "") }()
+
+	rootCmd.SetArgs([]string{"schema", "--json", "show", "-o", ""})
+	assert.NoError(t, rootCmd.Execute())
+	assert.True(t, json.Valid(out.Bytes()))
+	assert.Contains(t, out.String(), `"title": "Submitter profile"`)
+
+	outputDir := filepath.Join(t.TempDir(), "schemas")
+	rootCmd.SetArgs([]string{"schema", "--json", "all", "-o", outputDir})
+	assert.NoError(t, rootCmd.Execute())
+	for _, name := range getJSONSchemaNames() {
+		assert.FileExists(t, filepath.Join(outputDir, name+".schema.json"))
+	}
+
+	rootCmd.SetArgs([]string{"schema", "--json", "unknown"})
+	assert.Error(t, rootCmd.Execute())
+}
+
+// Checks that the JSON outputs of the tool match their published schema
+func Test_jsonOutputsMatchSchemas(t *testing.T) {
+	records, err := loadInputPivotTable("../test_data/overview.csv")
+	assert.NoError(t, err)
+
+	dataset, err := newDataset("overview", records)
+	assert.NoError(t, err)
+	profile, err := buildSubmitterProfile(dataset, "basil", "latest", 12)
+	assert.NoError(t, err)
+	calendar, err := buildContributionCalendar(dataset, "basil")
+	assert.NoError(t, err)
+	longRecords, err := wideToLong(records[:3])
+	assert.NoError(t, err)
+	header, err := loadPivotTableHeader("../test_data/overview.csv")
+	assert.NoError(t, err)
+	diff, err := buildCompareDiff(header, [][]string{{"Submitter", "Total_PRs"}, {"basil", "1476"}, {"jglick", "445"}},
+		[][]string{{"Submitter", "Total_PRs"}, {"basil", "1482"}, {"timja", "500"}}, "latest", 12, 3, InputTypeSubmitters)
+	assert.NoError(t, err)
+	quality, err := computeDataQuality("../test_data/quality_gaps.csv", nil)
+	assert.NoError(t, err)
+	dashboard, err := buildDashboardData(records, records[:3], InputTypeSubmitters)
+	assert.NoError(t, err)
+	summary, err := buildGenerationSummary("extract", "../test_data/overview.csv", [][]string{{"Submitter", "Total_PRs"}, {"basil", "1476"}}, "latest", 12, []string{"top.md"})
+	assert.NoError(t, err)
+
+	siteDir := t.TempDir()
+	rootCmd.SetArgs([]string{"site", "../test_data/deleted_user_case.csv", "-m", "latest", "-p", "40", "-o", siteDir})
+	assert.NoError(t, rootCmd.Execute())
+	searchIndex, err := os.ReadFile(filepath.Join(siteDir, "search-index.json"))
+	assert.NoError(t, err)
+
+	resultFile := filepath.Join(t.TempDir(), "top.json")
+	assert.NoError(t, jsonRenderer{}.Render(resultFile, [][]string{{"Submitter", "2023-01", "Total", "Status"}, {"42", "", "+5", "NEW"}}, renderOptions{}))
+	resultTable, err := os.ReadFile(resultFile)
+	assert.NoError(t, err)
+	audit := auditLog{Version: "1.0.0", Command: "normalize pivot.csv", GeneratedAt: "2024-01-31T10:00:00Z", Transformations: []auditEntry{
+		{Operation: auditMerge, Description: "Line 4: \"a\" merged into \"A\" (sum)", Users: []string{"a", "A"}},
+		{Operation: auditFilter, Description: "2 months outside of 2023-01..2023-12 removed"},
+	}}
+
+	tests := []struct {
+		schema string
+		output any
+	}{
+		{"show", profile},
+		{"calendar", calendar},
+		{"check-quality", quality},
+		{"compare-diff", diff},
+		{"dashboard", dashboard},
+		{"convert-jsonl", longRecords[0]},
+		{"search-index", json.RawMessage(searchIndex)},
+		{"webhook", summary},
+		{"audit", audit},
+		{"result-table", json.RawMessage(resultTable)},
+	}
+	assert.Len(t, tests, len(getJSONSchemaNames()), "all the schemas should be tested")
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			content, err := getJSONSchema(tt.schema)
+			assert.NoError(t, err)
+			var schema map[string]any
+			assert.NoError(t, json.Unmarshal(content, &schema))
+
+			output, err := json.Marshal(tt.output)
+			assert.NoError(t, err)
+			var value any
+			assert.NoError(t, json.Unmarshal(output, &value))
+
+			assert.NoError(t, validateJSONSchema(schema, schema, value, "$"))
+		})
+	}
+}
+
+// Validates a decoded JSON value with the subset of JSON Schema used by our schemas
+func validateJSONSchema(root map[string]any, schema map[string]any, value any, path string) error {
+	if ref, isRef := schema["$ref"].(string); isRef {
+		definition, isFound := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if !isFound {
+			return fmt.Errorf("%s: unknown reference %s", path, ref)
+		}
+		return validateJSONSchema(root, definition, value, path)
+	}
+
+	schemaType := schema["type"]
+	if types, isList := schemaType.([]any); isList {
+		// The value must match one of the types
+		for _, alternative := range types {
+			if alternative == "null" {
+				if value == nil {
+					return nil
+				}
+				continue
+			}
+			typedSchema := make(map[string]any)
+			for key, property := range schema {
+				typedSchema[key] = property
+			}
+			typedSchema["type"] = alternative
+			if validateJSONSchema(root, typedSchema, value, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: expecting one of %v", path, types)
+	}
+
+	switch schemaType {
+	case "object":
+		object, isObject := value.(map[string]any)
+		if !isObject {
+			return fmt.Errorf("%s: expecting an object", path)
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, required := range required {
+			if _, isPresent := object[required.(string)]; !isPresent {
+				return fmt.Errorf("%s: missing property %s", path, required)
+			}
+		}
+		for name, propertyValue := range object {
+			property, isDefined := properties[name].(map[string]any)
+			if !isDefined {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unexpected property %s", path, name)
+				}
+				// The properties not listed can be constrained by a schema
+				if property, isDefined = schema["additionalProperties"].(map[string]any); !isDefined {
+					continue
+				}
+			}
+			if err := validateJSONSchema(root, property, propertyValue, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, isArray := value.([]any)
+		if !isArray {
+			return fmt.Errorf("%s: expecting an array", path)
+		}
+		for i, item := range array {
+			if err := validateJSONSchema(root, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		text, isString := value.(string)
+		if !isString {
+			return fmt.Errorf("%s: expecting a string", path)
+		}
+		if pattern, hasPattern := schema["pattern"].(string); hasPattern && !regexp.MustCompile(pattern).MatchString(text) {
+			return fmt.Errorf("%s: \"%s\" doesn't match %s", path, text, pattern)
+		}
+	case "integer", "number":
+		number, isNumber := value.(float64)
+		if !isNumber || (schemaType == "integer" && number != float64(int64(number))) {
+			return fmt.Errorf("%s: expecting an %s", path, schemaType)
+		}
+		if minimum, hasMinimum := schema["minimum"].(float64); hasMinimum && number < minimum {
+			return fmt.Errorf("%s: %v is lower than %v", path, number, minimum)
+		}
+		if maximum, hasMaximum := schema["maximum"].(float64); hasMaximum && number > maximum {
+			return fmt.Errorf("%s: %v is greater than %v", path, number, maximum)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported type %v", path, schemaType)
+	}
+
+	if enum, hasEnum := schema["enum"].([]any); hasEnum {
+		for _, allowed := range enum {
+			if allowed == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var seasonalityOutputFileName string
+var seasonalityChartFileName string
+
+// The activity of a calendar month across the years
+type seasonalMonth struct {
+	Month   time.Month
+	Years   int // number of years with data for this month
+	Average float64
+	Min     int
+	Max     int
+	Index   float64 // average of the month divided by the average of all the months
+}
+
+// seasonalityCmd represents the seasonality command
+var seasonalityCmd = &cobra.Command{
+	Use:   "seasonality [input file]",
+	Short: "Summarizes the average activity per calendar month across the years",
+	Long: `The SEASONALITY command computes, for each calendar month (January to December),
+the average of the monthly totals over the years available in the pivot table, with
+the lowest and highest values. The "Index" column compares the average of the month
+with the average of all the months (ex: 0.80 for a month 20% less active than usual).
+
+It helps to put the month-over-month comparisons in context (are Decembers always slow?).
+
+The output is a CSV or Markdown (".md" extension) table, or any format selected with "--format".
+With "--chart", the averages
+are also plotted as a bar chart (".png" or ".svg").`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		seasonality, err := computeSeasonality(dataset)
+		if err != nil {
+			return err
+		}
+
+		if dirErr := CheckDir(seasonalityOutputFileName); dirErr != nil {
+			return dirErr
+		}
+		table := seasonalityAsTable(seasonality)
+		introduction := fmt.Sprintf("# Seasonality\n\nAverage activity per calendar month between %s and %s.\n", dataset.Months[0], dataset.Months[len(dataset.Months)-1])
+		options := renderOptions{Title: "Seasonality", Introduction: introduction, InputType: InputTypeSubmitters}
+		if err := writeResultTable(seasonalityOutputFileName, table, options); err != nil {
+			return err
+		}
+		logInfo("Seasonality written to \"%s\"\n", seasonalityOutputFileName)
+
+		if seasonalityChartFileName != "" {
+			if dirErr := CheckDir(seasonalityChartFileName); dirErr != nil {
+				return dirErr
+			}
+			if err := plotSeasonality(seasonalityChartFileName, seasonality); err != nil {
+				return err
+			}
+			logInfo("Chart written to \"%s\"\n", seasonalityChartFileName)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(seasonalityCmd)
+
+	seasonalityCmd.Flags().StringVarP(&seasonalityOutputFileName, "out", "o", "seasonality.csv", "Output file name. The extension selects the format (\".md\" for markdown, see \"--format\")")
+	seasonalityCmd.Flags().StringVarP(&seasonalityChartFileName, "chart", "", "", "Also plots the averages in this file (.png or .svg)")
+	addFormatFlag(seasonalityCmd)
+
+	seasonalityCmd.ValidArgsFunction = completeInputFile
+}
+
+// Computes the statistics of each calendar month. The months without data (empty columns)
+// are ignored, rather than lowering the averages, and the calendar months without data are not returned.
+func computeSeasonality(dataset *Dataset) ([]seasonalMonth, error) {
+	var totalsPerMonth [12][]int
+	overallTotal, nbrOfMonths := 0, 0
+	for i, total := range dataset.MonthTotals() {
+		if !dataset.hasMonthData(i) {
+			continue
+		}
+		month, err := time.Parse("2006-01", dataset.Months[i])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid month \"%s\" in the header", dataset.Months[i])
+		}
+		totalsPerMonth[month.Month()-1] = append(totalsPerMonth[month.Month()-1], total)
+		overallTotal += total
+		nbrOfMonths++
+	}
+	overallAverage := float64(overallTotal) / float64(nbrOfMonths)
+
+	var seasonality []seasonalMonth
+	for i, totals := range totalsPerMonth {
+		if len(totals) == 0 {
+			continue
+		}
+		stats := seasonalMonth{Month: time.Month(i + 1), Years: len(totals), Min: totals[0], Max: totals[0]}
+		sum := 0
+		for _, total := range totals {
+			sum += total
+			if total < stats.Min {
+				stats.Min = total
+			}
+			if total > stats.Max {
+				stats.Max = total
+			}
+		}
+		stats.Average = float64(sum) / float64(len(totals))
+		if overallAverage > 0 {
+			stats.Index = stats.Average / overallAverage
+		}
+		seasonality = append(seasonality, stats)
+	}
+	return seasonality, nil
+}
+
+// Returns the statistics as a table (with a header line)
+func seasonalityAsTable(seasonality []seasonalMonth) [][]string {
+	table := [][]string{{"Month", "Years", "Average", "Min", "Max", "Index"}}
+	for _, stats := range seasonality {
+		table = append(table, []string{stats.Month.String(), strconv.Itoa(stats.Years), formatMetric(stats.Average),
+			strconv.Itoa(stats.Min), strconv.Itoa(stats.Max), formatMetric(stats.Index)})
+	}
+	return table
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeSeasonality(t *testing.T) {
+	// There is no data for 2022-10: it is ignored
+	records := [][]string{
+		{"", "2021-11", "2021-12", "2022-01", "2022-10", "2022-11", "2022-12"},
+		{"alpha", "4", "1", "6", "", "8", "1"},
+		{"bravo", "2", "0", "2", "", "2", "2"},
+	}
+
+	dataset, err := newDataset("test", records)
+	assert.NoError(t, err)
+
+	seasonality, err := computeSeasonality(dataset)
+
+	assert.NoError(t, err)
+	// The average of all the months is 28 / 5 = 5.6
+	assert.Equal(t, []seasonalMonth{
+		{Month: time.January, Years: 1, Average: 8, Min: 8, Max: 8, Index: 8 / 5.6},
+		{Month: time.November, Years: 2, Average: 8, Min: 6, Max: 10, Index: 8 / 5.6},
+		{Month: time.December, Years: 2, Average: 2, Min: 1, Max: 3, Index: 2 / 5.6},
+	}, seasonality)
+
+	assert.Equal(t, [][]string{
+		{"Month", "Years", "Average", "Min", "Max", "Index"},
+		{"January", "1", "8", "8", "8", "1.4285714285714286"},
+		{"November", "2", "8", "6", "10", "1.4285714285714286"},
+		{"December", "2", "2", "1", "3", "0.35714285714285715"},
+	}, seasonalityAsTable(seasonality))
+}
+
+func Test_ExecuteSeasonality(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "seasonality.csv")
+	chartFile := filepath.Join(tempDir, "seasonality.svg")
+
+	rootCmd.SetArgs([]string{"seasonality", "../test_data/overview.csv", "-o", outputFile, "--chart", chartFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 13)
+	assert.Equal(t, "January,4,828,648,892,0.9279650332016475", lines[1])
+	assert.FileExists(t, chartFile)
+}
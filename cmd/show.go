@@ -0,0 +1,278 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var showFormat string
+var showChartFileName string
+
+// Activity of a submitter for a given month
+type monthActivity struct {
+	Month string `json:"month"`
+	Count int    `json:"count"`
+	Rank  int    `json:"rank,omitempty"` // not set when there is no activity
+}
+
+// A line of the leaderboard
+type leaderboardEntry struct {
+	User  string `json:"user"`
+	Total int    `json:"total"`
+	Rank  int    `json:"rank"`
+}
+
+// Position of a submitter in the leaderboard of the selected period, with its neighbors
+type leaderboardPosition struct {
+	From  string            `json:"from"`
+	To    string            `json:"to"`
+	Rank  int               `json:"rank"`
+	Total int               `json:"total"`
+	Above *leaderboardEntry `json:"above,omitempty"`
+	Below *leaderboardEntry `json:"below,omitempty"`
+}
+
+// All the information about a submitter
+type submitterProfile struct {
+	User        string              `json:"user"`
+	Total       int                 `json:"total"`
+	Months      []monthActivity     `json:"months"`
+	Leaderboard leaderboardPosition `json:"leaderboard"`
+}
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show [input file] [username]",
+	Short: "Shows the activity and ranking of a submitter",
+	Long: `The SHOW command prints the details of a submitter: the count and the rank
+for each month, the overall total and the position in the leaderboard of the 
+selected period (see "--month" and "--period") with the submitters just above and below.
+
+The username is not case sensitive. The output is either a table (default) or JSON ("--format json").
+
+The "--chart" flag additionally draws the monthly rank of the submitter. The chart format depends
+on the file extension: ".png", ".svg" or ".mmd" (Mermaid).`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if !isValidMonth(endMonth, false) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		switch strings.ToLower(showFormat) {
+		case "table", "json":
+		default:
+			return fmt.Errorf("%s is an invalid output format\n", showFormat)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		profile, err := buildSubmitterProfile(dataset, args[1], endMonth, period)
+		if err != nil {
+			return err
+		}
+
+		if showChartFileName != "" {
+			if err := writeRankChart(showChartFileName, profile); err != nil {
+				return err
+			}
+		}
+
+		if strings.ToLower(showFormat) == "json" {
+			return writeProfileAsJSON(cmd.OutOrStdout(), profile)
+		}
+		writeProfileAsTable(cmd.OutOrStdout(), profile)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(showCmd)
+
+	showCmd.Flags().StringVarP(&showFormat, "format", "f", "table", "Output format: \"table\" or \"json\"")
+	showCmd.Flags().StringVarP(&showChartFileName, "chart", "c", "", "Draws the monthly rank in the given file (\".png\", \".svg\" or \".mmd\" for Mermaid)")
+	showCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months used for the leaderboard.")
+	showCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Last month of the leaderboard period.")
+
+	showCmd.ValidArgsFunction = completeInputFile
+	_ = showCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Collects the monthly activity, ranks and leaderboard position of a submitter
+func buildSubmitterProfile(dataset *Dataset, username string, endMonth string, period int) (submitterProfile, error) {
+	var profile submitterProfile
+
+	userIndex := dataset.findUser(username)
+	if userIndex == -1 {
+		return profile, fmt.Errorf("Submitter \"%s\" not found (the \"find\" command can help)", username)
+	}
+	profile.User = dataset.Users[userIndex]
+
+	// Monthly activity and rank
+	for month, count := range dataset.Counts[userIndex] {
+		activity := monthActivity{Month: dataset.Months[month], Count: count}
+		if count > 0 {
+			activity.Rank = dataset.MonthRanks(month)[userIndex]
+		}
+		profile.Months = append(profile.Months, activity)
+		profile.Total = profile.Total + count
+	}
+
+	// Position in the leaderboard
+	periodDataset, err := dataset.Period(endMonth, period)
+	if err != nil {
+		return profile, err
+	}
+	leaderboard := periodDataset.Leaderboard()
+	for i, entry := range leaderboard {
+		if entry.User != profile.User {
+			continue
+		}
+		profile.Leaderboard = leaderboardPosition{From: periodDataset.Months[0], To: periodDataset.Months[len(periodDataset.Months)-1], Rank: entry.Rank, Total: entry.Total}
+		if i > 0 {
+			above := leaderboard[i-1]
+			profile.Leaderboard.Above = &above
+		}
+		if i < len(leaderboard)-1 {
+			below := leaderboard[i+1]
+			profile.Leaderboard.Below = &below
+		}
+		break
+	}
+
+	return profile, nil
+}
+
+// Computes the leaderboard (sorted by descending total) for the given column range
+func computeLeaderboard(records [][]string, firstColumn int, lastColumn int) []leaderboardEntry {
+	var leaderboard []leaderboardEntry
+	for i, dataLine := range records {
+		//Skip header line
+		if i == 0 {
+			continue
+		}
+		total := 0
+		for column := firstColumn; column <= lastColumn; column++ {
+			value, _ := strconv.Atoi(dataLine[column])
+			total = total + value
+		}
+		leaderboard = append(leaderboard, leaderboardEntry{User: dataLine[0], Total: total})
+	}
+	return rankLeaderboard(leaderboard)
+}
+
+// Sorts the leaderboard by descending total and sets the ranks
+func rankLeaderboard(leaderboard []leaderboardEntry) []leaderboardEntry {
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Total != leaderboard[j].Total {
+			return leaderboard[i].Total > leaderboard[j].Total
+		}
+		return strings.ToLower(leaderboard[i].User) < strings.ToLower(leaderboard[j].User)
+	})
+
+	for i := range leaderboard {
+		if i > 0 && leaderboard[i].Total == leaderboard[i-1].Total {
+			leaderboard[i].Rank = leaderboard[i-1].Rank
+		} else {
+			leaderboard[i].Rank = i + 1
+		}
+	}
+	return leaderboard
+}
+
+// Draws the rank history chart in the format matching the file extension
+func writeRankChart(chartFileName string, profile submitterProfile) error {
+	if err := CheckDir(chartFileName); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(chartFileName)) {
+	case ".png", ".svg":
+		return plotRankHistory(chartFileName, profile)
+	case ".mmd", ".mermaid":
+		f, err := os.Create(chartFileName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writeRankHistoryMermaid(f, profile)
+	default:
+		return fmt.Errorf("Unsupported chart format \"%s\" (expecting .png, .svg or .mmd)", filepath.Ext(chartFileName))
+	}
+}
+
+// Writes the profile as indented JSON
+func writeProfileAsJSON(out io.Writer, profile submitterProfile) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(profile)
+}
+
+// Writes the profile as a human readable table
+func writeProfileAsTable(out io.Writer, profile submitterProfile) {
+	fmt.Fprintf(out, "Submitter: %s\n\n", profile.User)
+
+	leaderboard := profile.Leaderboard
+	fmt.Fprintf(out, "Leaderboard from %s to %s: rank %d (%d)\n", leaderboard.From, leaderboard.To, leaderboard.Rank, leaderboard.Total)
+	if leaderboard.Above != nil {
+		fmt.Fprintf(out, "  above: %s, rank %d (%d)\n", leaderboard.Above.User, leaderboard.Above.Rank, leaderboard.Above.Total)
+	}
+	if leaderboard.Below != nil {
+		fmt.Fprintf(out, "  below: %s, rank %d (%d)\n", leaderboard.Below.User, leaderboard.Below.Rank, leaderboard.Below.Total)
+	}
+
+	fmt.Fprintf(out, "\n| Month   | Count | Rank |\n| ------- | ----: | ---: |\n")
+	for _, activity := range profile.Months {
+		rank := "-"
+		if activity.Rank != 0 {
+			rank = strconv.Itoa(activity.Rank)
+		}
+		fmt.Fprintf(out, "| %s | %5d | %4s |\n", activity.Month, activity.Count, rank)
+	}
+	fmt.Fprintf(out, "| Total   | %5d |      |\n", profile.Total)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var show_records = [][]string{
+	{"", "2023-01", "2023-02", "2023-03"},
+	{"alpha", "1", "5", "2"},
+	{"bravo", "4", "0", "2"},
+	{"charly", "0", "1", "7"},
+	{"delta", "3", "3", "0"},
+}
+
+func Test_computeLeaderboard(t *testing.T) {
+	got := computeLeaderboard(show_records, 2, 3)
+	want := []leaderboardEntry{
+		{User: "charly", Total: 8, Rank: 1},
+		{User: "alpha", Total: 7, Rank: 2},
+		{User: "delta", Total: 3, Rank: 3},
+		{User: "bravo", Total: 2, Rank: 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeLeaderboard() = %v, want %v", got, want)
+	}
+}
+
+func Test_buildSubmitterProfile(t *testing.T) {
+	dataset, err := newDataset("show", show_records)
+	assert.NoError(t, err)
+
+	profile, err := buildSubmitterProfile(dataset, "ALPHA", "latest", 2)
+
+	assert.NoError(t, err)
+	expected := submitterProfile{
+		User:  "alpha",
+		Total: 8,
+		Months: []monthActivity{
+			{Month: "2023-01", Count: 1, Rank: 3},
+			{Month: "2023-02", Count: 5, Rank: 1},
+			{Month: "2023-03", Count: 2, Rank: 2},
+		},
+		Leaderboard: leaderboardPosition{
+			From:  "2023-02",
+			To:    "2023-03",
+			Rank:  2,
+			Total: 7,
+			Above: &leaderboardEntry{User: "charly", Total: 8, Rank: 1},
+			Below: &leaderboardEntry{User: "delta", Total: 3, Rank: 3},
+		},
+	}
+	assert.Equal(t, expected, profile)
+
+	_, err = buildSubmitterProfile(dataset, "zulu", "latest", 2)
+	assert.EqualError(t, err, "Submitter \"zulu\" not found (the \"find\" command can help)")
+}
+
+func Test_writeProfileAsTable(t *testing.T) {
+	dataset, _ := newDataset("show", show_records)
+	profile, _ := buildSubmitterProfile(dataset, "charly", "latest", 2)
+	out := new(bytes.Buffer)
+
+	writeProfileAsTable(out, profile)
+
+	expected := `Submitter: charly
+
+Leaderboard from 2023-02 to 2023-03: rank 1 (8)
+  below: alpha, rank 2 (7)
+
+| Month   | Count | Rank |
+| ------- | ----: | ---: |
+| 2023-01 |     0 |    - |
+| 2023-02 |     1 |    3 |
+| 2023-03 |     7 |    1 |
+| Total   |     8 |      |
+`
+	assert.Equal(t, expected, out.String())
+}
@@ -0,0 +1,230 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var isShowingDiff bool
+
+// Where the diff of the regenerated output is written
+var diffOutput io.Writer = os.Stdout
+
+// Number of unchanged lines shown around the changes
+const diffContextLines = 3
+
+// Adds the "--show-diff" flag to a command generating an output file
+func addShowDiffFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isShowingDiff, "show-diff", "", false, "Shows a unified diff of the output file being overwritten and its new content")
+}
+
+// The content of an output file before its regeneration (nil if it doesn't exist or no diff is requested)
+type previousOutput struct {
+	fileName string
+	content  []byte
+}
+
+// Keeps the content of the output file, if it exists, to show what changed once regenerated
+func keepPreviousOutput(fileName string) (*previousOutput, error) {
+	if !isShowingDiff {
+		return nil, nil
+	}
+	content, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &previousOutput{fileName: fileName, content: content}, nil
+}
+
+// Shows the diff between the previous and the regenerated content of the output file
+func (previous *previousOutput) showDiff(newFileName string) error {
+	if previous == nil {
+		return nil
+	}
+	content, err := os.ReadFile(newFileName)
+	if err != nil {
+		return err
+	}
+	switch {
+	case bytes.Equal(previous.content, content):
+		fmt.Fprintf(diffOutput, "No change in \"%s\"\n", previous.fileName)
+	case !isTextContent(previous.content) || !isTextContent(content):
+		fmt.Fprintf(diffOutput, "Binary file \"%s\" changed\n", previous.fileName)
+	default:
+		fmt.Fprint(diffOutput, unifiedDiff(previous.fileName, previous.fileName, string(previous.content), string(content)))
+	}
+	return nil
+}
+
+// Whether the content is text (ex: not an Excel file)
+func isTextContent(content []byte) bool {
+	return utf8.Valid(content) && !bytes.ContainsRune(content, 0)
+}
+
+// Splits a text in lines (without their end)
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// A line of the diff: ' ' unchanged, '-' removed or '+' added. oldIndex and newIndex
+// are the positions in the old and new texts when the line is reached.
+type diffLine struct {
+	kind     byte
+	text     string
+	oldIndex int
+	newIndex int
+}
+
+// Returns the unified diff of two texts (empty if they are identical)
+func unifiedDiff(oldName string, newName string, oldText string, newText string) string {
+	lines := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	for start := 0; start < len(lines); {
+		// Next hunk: the changes separated by less than twice the context, with their context
+		first := start
+		for first < len(lines) && lines[first].kind == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for i := first; i < len(lines) && i-last <= 2*diffContextLines+1; i++ {
+			if lines[i].kind != ' ' {
+				last = i
+			}
+		}
+		hunkStart := first - diffContextLines
+		if hunkStart < start {
+			hunkStart = start
+		}
+		hunkEnd := last + diffContextLines + 1
+		if hunkEnd > len(lines) {
+			hunkEnd = len(lines)
+		}
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		oldCount, newCount := 0, 0
+		for _, line := range lines[hunkStart:hunkEnd] {
+			if line.kind != '+' {
+				oldCount++
+			}
+			if line.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(lines[hunkStart].oldIndex, oldCount), hunkRange(lines[hunkStart].newIndex, newCount))
+		for _, line := range lines[hunkStart:hunkEnd] {
+			fmt.Fprintf(&sb, "%c%s\n", line.kind, line.text)
+		}
+		start = hunkEnd
+	}
+	return sb.String()
+}
+
+// Formats the range of a hunk (an empty range starts at the line before)
+func hunkRange(index int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", index+1)
+	}
+	return fmt.Sprintf("%d,%d", index+1, count)
+}
+
+// Computes the lines of the diff, based on the longest common subsequence of the lines.
+// The common prefix and suffix are skipped to keep the computation small.
+func diffLines(oldLines []string, newLines []string) []diffLine {
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	a := oldLines[prefix : len(oldLines)-suffix]
+	b := newLines[prefix : len(newLines)-suffix]
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	for k := 0; k < prefix; k++ {
+		lines = append(lines, diffLine{kind: ' ', text: oldLines[k], oldIndex: k, newIndex: k})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		line := diffLine{oldIndex: prefix + i, newIndex: prefix + j}
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			line.kind, line.text = ' ', a[i]
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			line.kind, line.text = '-', a[i]
+			i++
+		default:
+			line.kind, line.text = '+', b[j]
+			j++
+		}
+		lines = append(lines, line)
+	}
+	for k := 0; k < suffix; k++ {
+		lines = append(lines, diffLine{kind: ' ', text: oldLines[len(oldLines)-suffix+k], oldIndex: len(oldLines) - suffix + k, newIndex: len(newLines) - suffix + k})
+	}
+	return lines
+}
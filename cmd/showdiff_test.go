@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    string
+	}{
+		{"identical", old, old, ""},
+		{"two hunks", old, "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n",
+			"--- out.csv\n+++ out.csv\n@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n@@ -11,3 +11,4 @@\n k\n l\n m\n+n\n"},
+		{"replaced end", old, "a\nb\nc\nd\ne\nf\ng\nh\nI\n",
+			"--- out.csv\n+++ out.csv\n@@ -6,8 +6,4 @@\n f\n g\n h\n-i\n-j\n-k\n-l\n-m\n+I\n"},
+		{"new file", "", "a\nb\n", "--- out.csv\n+++ out.csv\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"CRLF ignored", "a\r\nb\r\n", "a\nb\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unifiedDiff("out.csv", "out.csv", tt.oldText, tt.newText))
+		})
+	}
+}
+
+func Test_ExecuteExtract_showDiff(t *testing.T) {
+	var output bytes.Buffer
+	diffOutput = &output
+	defer func() { diffOutput = os.Stdout }()
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+	defer func() {
+		extractCmd.Flags().Set("show-diff", "false")
+		extractCmd.PersistentFlags().Set("topSize", "35")
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile, "--show-diff", "--topSize", "5"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Empty(t, output.String(), "No diff for a new file")
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile, "--show-diff", "--topSize", "5"})
+	assert.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "No change in \""+outputFile+"\"\n", output.String())
+
+	output.Reset()
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-o", outputFile, "--show-diff", "--topSize", "6"})
+	assert.NoError(t, rootCmd.Execute())
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Equal(t, "--- "+outputFile, lines[0])
+	assert.Equal(t, "@@ -4,3 +4,4 @@", lines[2])
+	assert.Len(t, lines, 7)
+	assert.True(t, strings.HasPrefix(lines[6], "+"))
+}
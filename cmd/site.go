@@ -0,0 +1,437 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed site_templates
+var siteTemplates embed.FS
+
+var siteOutputDir string
+var siteTitle string
+var siteTopSize int
+var siteStyle siteStyleOptions
+
+// All the data of the generated site
+type siteData struct {
+	Title        string
+	Version      string
+	GeneratedOn  string
+	IsWithMedals bool
+	MaxRows      int // number of rows after which the rankings are split in collapsible sections
+	Style        siteStyleOptions
+	Datasets     []*siteDataset
+}
+
+// The optional styling of the pages
+type siteStyleOptions struct {
+	IsStickyHeader bool
+	IsZebra        bool
+	IsDarkMode     bool
+	CustomCSS      string // file included after the default style sheet
+}
+
+// The pages generated from one pivot table
+type siteDataset struct {
+	Name        string // used as directory name
+	Title       string
+	From        string
+	To          string
+	TopSize     int
+	Leaderboard []leaderboardEntry
+	TopFive     []siteSearchEntry
+	Months      []string
+	MonthPages  []*siteMonthPage
+	Users       []*siteUserPage
+	Footnotes   []siteFootnote // notes of the annotated users of the leaderboard
+
+	footnoteNumbers map[string]int
+}
+
+// The notes of a user of the leaderboard
+type siteFootnote struct {
+	Number int
+	User   string
+	Notes  []string
+}
+
+// The ranking of a month
+type siteMonthPage struct {
+	Month    string
+	Total    int
+	Entries  []leaderboardEntry
+	Previous string
+	Next     string
+}
+
+// The history of a contributor
+type siteUserPage struct {
+	Name   string
+	Total  int
+	Rank   int // in the leaderboard (0 if not part of it)
+	Months []monthActivity
+	Chart  template.HTML
+	Notes  []string
+}
+
+// An entry of the search index
+type siteSearchEntry struct {
+	User    string `json:"user"`
+	Dataset string `json:"dataset"`
+	Total   int    `json:"total"`
+	URL     string `json:"url"`
+}
+
+// The data passed to the page templates
+type sitePage struct {
+	Site      *siteData
+	Root      string // relative path to the root of the site
+	PageTitle string
+	Dataset   *siteDataset
+	Month     *siteMonthPage
+	User      *siteUserPage
+}
+
+// siteCmd represents the site command
+var siteCmd = &cobra.Command{
+	Use:   "site [input file...]",
+	Short: "Generates a static website with the full statistics",
+	Long: `The SITE command generates a complete static website from one or more pivot
+tables (for example the submitters and the commenters ones), ready to be published
+on GitHub Pages.
+
+For each pivot table, the site contains:
+  - the leaderboard of the period (see "--month", "--period" and "--top"),
+  - a page per month with the ranking of the month,
+  - a page per contributor with its history (chart and table).
+The home page links the pivot tables and allows to search the contributors
+(using the generated "search-index.json").`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		for _, fileName := range args {
+			if !isFileValid(fileName) {
+				return fmt.Errorf("Invalid input file %s\n", fileName)
+			}
+		}
+		if !isValidMonth(endMonth, isVerbose()) {
+			return fmt.Errorf("\"%s\" is an invalid month\n", endMonth)
+		}
+		if siteStyle.CustomCSS != "" && !isFileValid(siteStyle.CustomCSS) {
+			return fmt.Errorf("Invalid CSS file %s\n", siteStyle.CustomCSS)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		site := &siteData{Title: siteTitle, Version: version, GeneratedOn: time.Now().Format("2006-01-02"), IsWithMedals: isWithMedals, MaxRows: maxTableRows, Style: siteStyle}
+		annotations := make(userAnnotations)
+		if annotationsFileName != "" {
+			var err error
+			if annotations, err = loadAnnotations(annotationsFileName); err != nil {
+				return err
+			}
+		}
+		for _, fileName := range args {
+			if !checkFile(fileName, isSilent) {
+				return fmt.Errorf("Invalid input file %s.", fileName)
+			}
+			source, err := loadDataset(cmd.Context(), fileName)
+			if err != nil {
+				return err
+			}
+			dataset, err := buildSiteDataset(source, endMonth, period, siteTopSize, annotations)
+			if err != nil {
+				return err
+			}
+			site.Datasets = append(site.Datasets, dataset)
+		}
+
+		if err := writeSite(siteOutputDir, site); err != nil {
+			return err
+		}
+		logInfo("Site generated in \"%s\"\n", siteOutputDir)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(siteCmd)
+
+	siteCmd.Flags().StringVarP(&siteOutputDir, "out-dir", "o", "site", "Directory where the site is generated")
+	siteCmd.Flags().StringVarP(&siteTitle, "title", "", "Jenkins Contributors", "Title of the site")
+	siteCmd.Flags().IntVarP(&siteTopSize, "top", "t", 100, "Number of contributors in the leaderboard (0 for all)")
+	siteCmd.Flags().IntVarP(&period, "period", "p", 12, "Number of months of the leaderboard.")
+	siteCmd.Flags().StringVarP(&endMonth, "month", "m", "latest", "Last month of the leaderboard.")
+	siteCmd.Flags().BoolVarP(&isWithMedals, "medals", "", false, "Decorates the top three of the rankings with medals")
+	siteCmd.Flags().IntVarP(&maxTableRows, "max-rows", "", 0, "Number of rows after which the rankings are split in collapsible sections (0 for no limit)")
+	siteCmd.Flags().BoolVarP(&siteStyle.IsStickyHeader, "sticky-header", "", false, "Keeps the header of the tables visible when scrolling")
+	siteCmd.Flags().BoolVarP(&siteStyle.IsZebra, "zebra", "", false, "Alternates the background of the table rows")
+	siteCmd.Flags().BoolVarP(&siteStyle.IsDarkMode, "dark-mode", "", false, "Uses dark colors when the browser prefers a dark theme")
+	siteCmd.Flags().StringVarP(&siteStyle.CustomCSS, "custom-css", "", "", "CSS file included after the default style sheet")
+	siteCmd.Flags().StringVarP(&annotationsFileName, "annotations", "", "", "File (\"user,note\" CSV) with notes rendered as footnotes of the leaderboards")
+
+	siteCmd.ValidArgsFunction = completeInputFile
+	_ = siteCmd.RegisterFlagCompletionFunc("month", completeMonth)
+}
+
+// Computes the content of the pages of a pivot table. The months without data are
+// handled as months without activity.
+func buildSiteDataset(source *Dataset, endMonth string, period int, topSize int, annotations userAnnotations) (*siteDataset, error) {
+	name := source.Name
+	dataset := &siteDataset{Name: name, Title: name, Months: source.Months, footnoteNumbers: make(map[string]int)}
+
+	periodDataset, err := source.Period(endMonth, period)
+	if err != nil {
+		return nil, err
+	}
+	dataset.From, dataset.To = periodDataset.Months[0], periodDataset.Months[len(periodDataset.Months)-1]
+	leaderboardRanks := make(map[string]int)
+	for _, entry := range periodDataset.Leaderboard() {
+		if entry.Total == 0 || (topSize > 0 && len(dataset.Leaderboard) >= topSize) {
+			break
+		}
+		dataset.Leaderboard = append(dataset.Leaderboard, entry)
+		leaderboardRanks[entry.User] = entry.Rank
+		if notes := annotations.notesOf(entry.User); len(notes) > 0 {
+			footnote := siteFootnote{Number: len(dataset.Footnotes) + 1, User: entry.User, Notes: notes}
+			dataset.Footnotes = append(dataset.Footnotes, footnote)
+			dataset.footnoteNumbers[entry.User] = footnote.Number
+		}
+		if len(dataset.TopFive) < 5 {
+			dataset.TopFive = append(dataset.TopFive, siteSearchEntry{User: entry.User, Dataset: name, Total: entry.Total, URL: name + "/users/" + entry.User + ".html"})
+		}
+	}
+	dataset.TopSize = len(dataset.Leaderboard)
+
+	// Ranking of each month (only the contributors with some activity)
+	monthRanks := make([]map[string]int, len(source.Months))
+	for month, monthName := range source.Months {
+		page := &siteMonthPage{Month: monthName}
+		monthRanks[month] = make(map[string]int)
+		monthDataset, err := source.Slice(monthName, monthName)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range monthDataset.Leaderboard() {
+			if entry.Total == 0 {
+				break
+			}
+			page.Entries = append(page.Entries, entry)
+			page.Total += entry.Total
+			monthRanks[month][entry.User] = entry.Rank
+		}
+		if month > 0 {
+			page.Previous = source.Months[month-1]
+		}
+		if month < len(source.Months)-1 {
+			page.Next = source.Months[month+1]
+		}
+		dataset.MonthPages = append(dataset.MonthPages, page)
+	}
+
+	for i, userName := range source.Users {
+		user := &siteUserPage{Name: userName, Rank: leaderboardRanks[userName], Notes: annotations.notesOf(userName)}
+		for month, count := range source.Counts[i] {
+			user.Months = append(user.Months, monthActivity{Month: source.Months[month], Count: count, Rank: monthRanks[month][userName]})
+			user.Total += count
+		}
+		user.Chart = renderActivitySVG(user.Months)
+		dataset.Users = append(dataset.Users, user)
+	}
+	return dataset, nil
+}
+
+// Returns the number of the footnote of a user of the leaderboard (0 if none)
+func (dataset *siteDataset) FootnoteOf(user string) int {
+	return dataset.footnoteNumbers[user]
+}
+
+// Renders the monthly activity as an inline SVG bar chart
+func renderActivitySVG(months []monthActivity) template.HTML {
+	const width, height, labelHeight = 720, 120, 14
+	maxCount := 1
+	for _, month := range months {
+		if month.Count > maxCount {
+			maxCount = month.Count
+		}
+	}
+	barWidth := float64(width) / float64(len(months))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="Monthly activity">`, width, height+labelHeight)
+	for i, month := range months {
+		barHeight := float64(month.Count) * height / float64(maxCount)
+		x := float64(i) * barWidth
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f"><title>%s: %d</title></rect>`,
+			x+1, height-barHeight, barWidth-2, barHeight, html.EscapeString(month.Month), month.Count)
+		// Only the years are labeled
+		if strings.HasSuffix(month.Month, "-01") || i == 0 {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d">%s</text>`, x, height+labelHeight-2, html.EscapeString(month.Month[:4]))
+		}
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// Writes all the pages of the site
+func writeSite(outputDir string, site *siteData) error {
+	templates, err := template.ParseFS(siteTemplates, "site_templates/*.html")
+	if err != nil {
+		return err
+	}
+
+	for _, staticFile := range []string{"style.css", "search.js"} {
+		content, err := siteTemplates.ReadFile("site_templates/" + staticFile)
+		if err != nil {
+			return err
+		}
+		if err := writeSiteFile(filepath.Join(outputDir, staticFile), content); err != nil {
+			return err
+		}
+	}
+
+	if site.Style.CustomCSS != "" {
+		content, err := os.ReadFile(site.Style.CustomCSS)
+		if err != nil {
+			return err
+		}
+		if err := writeSiteFile(filepath.Join(outputDir, "custom.css"), content); err != nil {
+			return err
+		}
+	}
+
+	var searchIndex []siteSearchEntry
+	for _, dataset := range site.Datasets {
+		datasetDir := filepath.Join(outputDir, dataset.Name)
+		page := sitePage{Site: site, Root: "../", PageTitle: dataset.Title, Dataset: dataset}
+		if err := renderSitePage(templates, "dataset.html", filepath.Join(datasetDir, "index.html"), page); err != nil {
+			return err
+		}
+
+		for _, month := range dataset.MonthPages {
+			page := sitePage{Site: site, Root: "../../", PageTitle: dataset.Title + " - " + month.Month, Dataset: dataset, Month: month}
+			if err := renderSitePage(templates, "month.html", filepath.Join(datasetDir, "months", month.Month+".html"), page); err != nil {
+				return err
+			}
+		}
+
+		for _, user := range dataset.Users {
+			page := sitePage{Site: site, Root: "../../", PageTitle: user.Name + " (" + dataset.Title + ")", Dataset: dataset, User: user}
+			if err := renderSitePage(templates, "user.html", filepath.Join(datasetDir, "users", user.Name+".html"), page); err != nil {
+				return err
+			}
+			searchIndex = append(searchIndex, siteSearchEntry{User: user.Name, Dataset: dataset.Title, Total: user.Total, URL: dataset.Name + "/users/" + user.Name + ".html"})
+		}
+	}
+
+	page := sitePage{Site: site, Root: "", PageTitle: site.Title}
+	if err := renderSitePage(templates, "index.html", filepath.Join(outputDir, "index.html"), page); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(searchIndex)
+	if err != nil {
+		return err
+	}
+	return writeSiteFile(filepath.Join(outputDir, "search-index.json"), content)
+}
+
+// Returns the classes of the page body enabling the requested styling options
+func (style siteStyleOptions) BodyClass() string {
+	var classes []string
+	if style.IsStickyHeader {
+		classes = append(classes, "sticky-header")
+	}
+	if style.IsZebra {
+		classes = append(classes, "zebra")
+	}
+	if style.IsDarkMode {
+		classes = append(classes, "dark-mode")
+	}
+	return strings.Join(classes, " ")
+}
+
+// Returns the medal of a rank, if requested
+func (page sitePage) Medal(rank int) string {
+	if !page.Site.IsWithMedals {
+		return ""
+	}
+	return medalOf(rank)
+}
+
+// A part of a long ranking
+type siteChunk struct {
+	From    int
+	To      int
+	Entries []leaderboardEntry
+}
+
+// Splits a ranking in chunks of the requested maximum size (a single chunk if not requested)
+func (page sitePage) Chunks(entries []leaderboardEntry) []siteChunk {
+	maxRows := page.Site.MaxRows
+	if maxRows <= 0 || len(entries) <= maxRows {
+		return []siteChunk{{From: 1, To: len(entries), Entries: entries}}
+	}
+	var chunks []siteChunk
+	for from := 0; from < len(entries); from += maxRows {
+		to := from + maxRows
+		if to > len(entries) {
+			to = len(entries)
+		}
+		chunks = append(chunks, siteChunk{From: from + 1, To: to, Entries: entries[from:to]})
+	}
+	return chunks
+}
+
+// Renders a page of the site
+func renderSitePage(templates *template.Template, templateName string, fileName string, page sitePage) error {
+	var b strings.Builder
+	if err := templates.ExecuteTemplate(&b, templateName, page); err != nil {
+		return fmt.Errorf("Unable to render %s: %v", fileName, err)
+	}
+	return writeSiteFile(fileName, []byte(b.String()))
+}
+
+// Writes a file of the site, creating its directory if needed
+func writeSiteFile(fileName string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0644)
+}
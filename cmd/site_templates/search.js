@@ -0,0 +1,22 @@
+// Filters the contributors of the search index while typing
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("search-results");
+  var index = [];
+  fetch("search-index.json").then(function (response) { return response.json(); }).then(function (data) { index = data; });
+  input.addEventListener("input", function () {
+    var pattern = input.value.toLowerCase();
+    results.innerHTML = "";
+    if (pattern.length < 2) {
+      return;
+    }
+    index.filter(function (entry) { return entry.user.toLowerCase().indexOf(pattern) !== -1; }).slice(0, 20).forEach(function (entry) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = entry.url;
+      link.textContent = entry.user + " (" + entry.dataset + ", " + entry.total + ")";
+      item.appendChild(link);
+      results.appendChild(item);
+    });
+  });
+})();
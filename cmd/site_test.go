@@ -0,0 +1,157 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildSiteDataset(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "1", "0", "8"},
+		{"bravo", "2", "2", "0"},
+		{"charly", "0", "0", "0"},
+	}
+
+	source, err := newDataset("test", records)
+	assert.NoError(t, err)
+
+	dataset, err := buildSiteDataset(source, "latest", 2, 10, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2023-02", dataset.From)
+	assert.Equal(t, "2023-03", dataset.To)
+	// Contributors without activity in the period are not in the leaderboard
+	assert.Equal(t, []leaderboardEntry{{User: "alpha", Total: 8, Rank: 1}, {User: "bravo", Total: 2, Rank: 2}}, dataset.Leaderboard)
+	assert.Equal(t, "test/users/alpha.html", dataset.TopFive[0].URL)
+
+	assert.Len(t, dataset.MonthPages, 3)
+	january := dataset.MonthPages[0]
+	assert.Equal(t, 3, january.Total)
+	assert.Equal(t, []leaderboardEntry{{User: "bravo", Total: 2, Rank: 1}, {User: "alpha", Total: 1, Rank: 2}}, january.Entries)
+	assert.Equal(t, "", january.Previous)
+	assert.Equal(t, "2023-02", january.Next)
+
+	assert.Len(t, dataset.Users, 3)
+	alpha := dataset.Users[0]
+	assert.Equal(t, 9, alpha.Total)
+	assert.Equal(t, 1, alpha.Rank)
+	assert.Equal(t, monthActivity{Month: "2023-01", Count: 1, Rank: 2}, alpha.Months[0])
+	assert.Equal(t, 0, dataset.Users[2].Rank)
+}
+
+func Test_buildSiteDataset_annotations(t *testing.T) {
+	records := [][]string{
+		{"", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "1", "0", "8"},
+		{"bravo", "2", "2", "0"},
+		{"charly", "0", "0", "0"},
+	}
+	annotations := userAnnotations{"bravo": {"on sabbatical"}, "charly": {"not in the leaderboard"}}
+
+	source, err := newDataset("test", records)
+	assert.NoError(t, err)
+
+	dataset, err := buildSiteDataset(source, "latest", 2, 10, annotations)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []siteFootnote{{Number: 1, User: "bravo", Notes: []string{"on sabbatical"}}}, dataset.Footnotes)
+	assert.Equal(t, 1, dataset.FootnoteOf("bravo"))
+	assert.Equal(t, 0, dataset.FootnoteOf("alpha"))
+	// The notes are displayed on the user pages, even outside of the leaderboard
+	assert.Equal(t, []string{"not in the leaderboard"}, dataset.Users[2].Notes)
+}
+
+func Test_sitePage_Medal(t *testing.T) {
+	page := sitePage{Site: &siteData{}}
+	assert.Equal(t, "", page.Medal(1))
+
+	page.Site.IsWithMedals = true
+	assert.Equal(t, "🥇", page.Medal(1))
+	assert.Equal(t, "", page.Medal(4))
+}
+
+func Test_siteStyleOptions_BodyClass(t *testing.T) {
+	assert.Equal(t, "", siteStyleOptions{}.BodyClass())
+	assert.Equal(t, "sticky-header zebra dark-mode", siteStyleOptions{IsStickyHeader: true, IsZebra: true, IsDarkMode: true}.BodyClass())
+	assert.Equal(t, "zebra", siteStyleOptions{IsZebra: true, CustomCSS: "site.css"}.BodyClass())
+}
+
+func Test_renderActivitySVG(t *testing.T) {
+	chart := string(renderActivitySVG([]monthActivity{{Month: "2022-12", Count: 2}, {Month: "2023-01", Count: 4}}))
+
+	assert.True(t, strings.HasPrefix(chart, "<svg"))
+	assert.Equal(t, 2, strings.Count(chart, "<rect"))
+	assert.Contains(t, chart, "<title>2023-01: 4</title>")
+	assert.Contains(t, chart, ">2023</text>")
+}
+
+func Test_ExecuteSite(t *testing.T) {
+	tempDir := t.TempDir()
+
+	rootCmd.SetArgs([]string{"site", "../test_data/deleted_user_case.csv", "-m", "latest", "-p", "40", "-o", tempDir})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	for _, fileName := range []string{"index.html", "style.css", "search.js", "search-index.json",
+		"deleted_user_case/index.html", "deleted_user_case/months/2021-06.html", "deleted_user_case/users/ADI10HERO.html"} {
+		assert.FileExists(t, filepath.Join(tempDir, fileName))
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "search-index.json"))
+	assert.NoError(t, err)
+	var searchIndex []siteSearchEntry
+	assert.NoError(t, json.Unmarshal(content, &searchIndex))
+	assert.Len(t, searchIndex, 6)
+	assert.Equal(t, siteSearchEntry{User: "0x41head", Dataset: "deleted_user_case", Total: 1, URL: "deleted_user_case/users/0x41head.html"}, searchIndex[0])
+
+	content, err = os.ReadFile(filepath.Join(tempDir, "deleted_user_case", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<td>1</td><td><a href="users/ADI10HERO.html">ADI10HERO</a></td><td>24</td>`)
+	assert.Contains(t, string(content), `href="../style.css"`)
+}
+
+func Test_ExecuteSite_style(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(t.TempDir(), "site.css")
+	assert.NoError(t, os.WriteFile(cssFile, []byte("h1 { color: red; }\n"), 0644))
+	defer func() { siteStyle = siteStyleOptions{} }()
+
+	rootCmd.SetArgs([]string{"site", "../test_data/deleted_user_case.csv", "-m", "latest", "-p", "40", "-o", tempDir, "--zebra", "--custom-css", cssFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(tempDir, "custom.css"))
+	assert.NoError(t, err)
+	assert.Equal(t, "h1 { color: red; }\n", string(content))
+	content, err = os.ReadFile(filepath.Join(tempDir, "deleted_user_case", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<link rel="stylesheet" href="../custom.css">`)
+	assert.Contains(t, string(content), `<body class="zebra">`)
+}
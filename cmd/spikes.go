@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var spikeFactor float64
+var spikeWindow int
+var spikesFileName string
+
+// Smaller counts are never reported as spikes (going from 1 to 6 is not suspicious)
+const spikeMinimumCount = 10
+
+// A count that is implausibly high compared with the rolling average of the user
+type spike struct {
+	User    string
+	Month   string
+	Line    int // line in the file (starting at 1)
+	Count   int
+	Average float64 // average of the previous months (with data) of the window
+}
+
+// Adds the flags of the spike detection
+func addSpikeFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Float64VarP(&spikeFactor, "spike-factor", "", 0, "Warns when a count exceeds this factor times the user's rolling average (ex: 10, 0 disables the detection)")
+	cmd.PersistentFlags().IntVarP(&spikeWindow, "spike-window", "", 6, "Number of months of the rolling average used to detect the spikes")
+	cmd.PersistentFlags().StringVarP(&spikesFileName, "spikes-file", "", "", "Also writes the detected spikes in that CSV file (with \"--spike-factor\")")
+}
+
+// Finds the counts exceeding factor times the average of the previous months (the window).
+// A full window of history, with some activity, is needed to detect a spike. The months
+// without data (empty cells) are not part of the window.
+func findSpikes(dataset *Dataset, factor float64, window int) []spike {
+	var spikes []spike
+	if factor <= 0 || window < 1 {
+		return spikes
+	}
+	for user, counts := range dataset.Counts {
+		// The counts of the last months with data (the months without data are skipped)
+		var recent []int
+		sum := 0
+		for month, count := range counts {
+			if dataset.isMissing(user, month) {
+				continue
+			}
+			if len(recent) == window {
+				average := float64(sum) / float64(window)
+				if count >= spikeMinimumCount && average > 0 && float64(count) > factor*average {
+					// The header is line 1 of the file
+					spikes = append(spikes, spike{User: dataset.Users[user], Month: dataset.Months[month], Line: user + 2, Count: count, Average: average})
+				}
+				sum -= recent[0]
+				recent = recent[1:]
+			}
+			recent = append(recent, count)
+			sum += count
+		}
+	}
+	return spikes
+}
+
+// Warns about the spikes of the pivot table and writes them in the spikes file (if requested)
+func reportSpikes(ctx context.Context, inputFilename string) error {
+	if spikeFactor <= 0 {
+		return nil
+	}
+	dataset, err := loadDataset(ctx, inputFilename)
+	if err != nil {
+		return err
+	}
+
+	spikes := findSpikes(dataset, spikeFactor, spikeWindow)
+	for _, s := range spikes {
+		warn("Implausible count for \"%s\" in %s at line %d: %d (%.1f times the %d months average of %.1f)",
+			s.User, s.Month, s.Line, s.Count, float64(s.Count)/s.Average, spikeWindow, s.Average)
+	}
+
+	if spikesFileName != "" {
+		if err := CheckDir(spikesFileName); err != nil {
+			return err
+		}
+		report := [][]string{{"user", "month", "line", "count", "average", "factor"}}
+		for _, s := range spikes {
+			report = append(report, []string{s.User, s.Month, strconv.Itoa(s.Line), strconv.Itoa(s.Count),
+				fmt.Sprintf("%.1f", s.Average), fmt.Sprintf("%.1f", float64(s.Count)/s.Average)})
+		}
+		if err := writeCSVtoFile(spikesFileName, report); err != nil {
+			return err
+		}
+		logVerbose("%d spikes written to \"%s\"\n", len(spikes), spikesFileName)
+	}
+	return nil
+}
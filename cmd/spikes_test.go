@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findSpikes(t *testing.T) {
+	dataset, err := loadDataset(context.Background(), "../test_data/spikes.csv")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		factor float64
+		window int
+		want   []spike
+	}{
+		{"disabled", 0, 3, nil},
+		{"only the steady user", 5, 3, []spike{{User: "steady", Month: "2023-05", Line: 2, Count: 40, Average: 5.333333333333333}}},
+		{"busy user over the lower factor", 1.8, 4, []spike{
+			{User: "steady", Month: "2023-05", Line: 2, Count: 40, Average: 5},
+			{User: "busy", Month: "2023-05", Line: 4, Count: 20, Average: 10.5},
+		}},
+		// No full window of history
+		{"window too long", 2, 5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findSpikes(dataset, tt.factor, tt.window))
+		})
+	}
+
+	// The months without data are skipped, rather than lowering the average
+	withMissing, err := newDataset("test", [][]string{{"", "2023-01", "2023-02", "2023-03", "2023-04"}, {"alpha", "10", "", "", "25"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []spike{{User: "alpha", Month: "2023-04", Line: 2, Count: 25, Average: 10}}, findSpikes(withMissing, 2, 1))
+}
+
+func Test_ExecuteCheck_spikes(t *testing.T) {
+	tempDir := t.TempDir()
+	spikesFile := filepath.Join(tempDir, "spikes.csv")
+	warningsFile := filepath.Join(tempDir, "warnings.txt")
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("warnings-file", "")
+		_ = checkCmd.PersistentFlags().Set("spike-factor", "0")
+		_ = checkCmd.PersistentFlags().Set("spike-window", "6")
+		_ = checkCmd.PersistentFlags().Set("spikes-file", "")
+	}()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/spikes.csv", "--spike-factor", "5", "--spike-window", "3", "--spikes-file", spikesFile, "--warnings-file", warningsFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	warnings, err := os.ReadFile(warningsFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(warnings), "Warning: Implausible count for \"steady\" in 2023-05 at line 2: 40 (7.5 times the 3 months average of 5.3)\n")
+	content, err := os.ReadFile(spikesFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "user,month,line,count,average,factor\nsteady,2023-05,2,40,5.3,7.5\n", string(content))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var splitPeriod string
+var splitOutputDir string
+var isSplitKeepEmpty bool
+
+// A part of the split pivot table
+type pivotPart struct {
+	Name      string // "2023" or "2023-Q1"
+	FromMonth string
+	ToMonth   string
+}
+
+// splitCmd represents the split command
+var splitCmd = &cobra.Command{
+	Use:   "split [input file]",
+	Short: "Splits a pivot table in one file per year or per quarter",
+	Long: `The SPLIT command writes one pivot table per calendar year (or per quarter with
+"--by quarter") of the input file. It can be used to archive a big historical pivot
+table or for the tools that can't handle very wide files.
+
+The files are named after the input file with the year ("_2023") or the quarter
+("_2023-Q1") as suffix. As in the files generated by datamash, the submitters without
+activity in the period are not listed (see "--keep-empty").`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		if splitPeriod != "year" && splitPeriod != "quarter" {
+			return fmt.Errorf("\"%s\" is an invalid split period (should be \"year\" or \"quarter\")", splitPeriod)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		outputDir := splitOutputDir
+		if outputDir == "" {
+			outputDir = filepath.Dir(args[0])
+		}
+		baseName := strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+
+		for _, part := range computePivotParts(dataset.Months, splitPeriod == "quarter") {
+			partDataset, err := trimDataset(dataset, part.FromMonth, part.ToMonth, !isSplitKeepEmpty)
+			if err != nil {
+				return err
+			}
+			outputFileName := filepath.Join(outputDir, baseName+"_"+part.Name+".csv")
+			if dirErr := CheckDir(outputFileName); dirErr != nil {
+				return dirErr
+			}
+			if err := writeCSVtoFile(outputFileName, partDataset.Records()); err != nil {
+				return err
+			}
+			logInfo("Wrote %d months and %d submitters to \"%s\"\n", len(partDataset.Months), len(partDataset.Users), outputFileName)
+		}
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(splitCmd)
+
+	splitCmd.Flags().StringVarP(&splitPeriod, "by", "", "year", "Period of each file (year or quarter)")
+	splitCmd.Flags().StringVarP(&splitOutputDir, "out-dir", "", "", "Directory where the files are written (default is the input file directory)")
+	splitCmd.Flags().BoolVarP(&isSplitKeepEmpty, "keep-empty", "", false, "Keeps the submitters without activity in the period")
+
+	splitCmd.ValidArgsFunction = completeInputFile
+	_ = splitCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions([]string{"year", "quarter"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Groups the months by year (or by quarter), in their order
+func computePivotParts(months []string, isByQuarter bool) []pivotPart {
+	var parts []pivotPart
+	for _, month := range months {
+		name := month[:4]
+		if isByQuarter {
+			monthNumber, _ := strconv.Atoi(month[5:7])
+			name = fmt.Sprintf("%s-Q%d", month[:4], (monthNumber-1)/3+1)
+		}
+		if len(parts) > 0 && parts[len(parts)-1].Name == name {
+			parts[len(parts)-1].ToMonth = month
+			continue
+		}
+		parts = append(parts, pivotPart{Name: name, FromMonth: month, ToMonth: month})
+	}
+	return parts
+}
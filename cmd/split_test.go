@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computePivotParts(t *testing.T) {
+	months := []string{"2022-11", "2022-12", "2023-01", "2023-02", "2023-03", "2023-04"}
+
+	byYear := computePivotParts(months, false)
+	assert.Equal(t, []pivotPart{
+		{Name: "2022", FromMonth: "2022-11", ToMonth: "2022-12"},
+		{Name: "2023", FromMonth: "2023-01", ToMonth: "2023-04"},
+	}, byYear)
+
+	byQuarter := computePivotParts(months, true)
+	assert.Equal(t, []pivotPart{
+		{Name: "2022-Q4", FromMonth: "2022-11", ToMonth: "2022-12"},
+		{Name: "2023-Q1", FromMonth: "2023-01", ToMonth: "2023-03"},
+		{Name: "2023-Q2", FromMonth: "2023-04", ToMonth: "2023-04"},
+	}, byQuarter)
+}
+
+func Test_ExecuteSplit(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() { _ = splitCmd.Flags().Set("out-dir", "") }()
+
+	rootCmd.SetArgs([]string{"split", "../test_data/deleted_user_case.csv", "--out-dir", tempDir})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	for _, year := range []string{"2020", "2021", "2022", "2023"} {
+		assert.FileExists(t, filepath.Join(tempDir, "deleted_user_case_"+year+".csv"))
+	}
+
+	records, err := loadInputPivotTable(filepath.Join(tempDir, "deleted_user_case_2021.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, 13, len(records[0]))
+	// 0x41head, deleted_user, ADI10HERO and APEdevelopment were active in 2021
+	assert.Equal(t, 5, len(records))
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var isRunStats bool
+var statsPushgatewayURL string
+var statsJobName string
+
+// Where the statistics of the run are written (the standard output is kept for the results)
+var statsOutput io.Writer = os.Stderr
+
+// Number of input rows loaded during the run (the loaders can run concurrently)
+var processedRows atomic.Int64
+
+// When the current run started (zero once its statistics are emitted)
+var runStartedAt time.Time
+var runCommandName string
+
+// Statistics about the run itself, to monitor the health of the scheduled jobs
+type runStats struct {
+	Command       string
+	Duration      time.Duration
+	RowsProcessed int64
+	MemoryBytes   uint64 // memory obtained from the system, the upper bound of the peak usage
+	IsSuccess     bool
+	FinishedAt    time.Time
+}
+
+// Counts the input rows loaded
+func countProcessedRows(count int) {
+	processedRows.Add(int64(count))
+}
+
+// Starts collecting the statistics of the run
+func startRunStats(commandName string, now time.Time) {
+	processedRows.Store(0)
+	runStartedAt = now
+	runCommandName = commandName
+}
+
+// Returns the statistics of the run so far
+func collectRunStats(isSuccess bool, now time.Time) runStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	return runStats{
+		Command:       runCommandName,
+		Duration:      now.Sub(runStartedAt),
+		RowsProcessed: processedRows.Load(),
+		MemoryBytes:   memStats.Sys,
+		IsSuccess:     isSuccess,
+		FinishedAt:    now,
+	}
+}
+
+// Writes (and pushes if requested) the statistics of the run, if "--stats" is set. Only done once per run.
+func finishRunStats(ctx context.Context, isSuccess bool) error {
+	if !isRunStats || runStartedAt.IsZero() {
+		return nil
+	}
+	stats := collectRunStats(isSuccess, time.Now())
+	runStartedAt = time.Time{}
+
+	fmt.Fprintf(statsOutput, "Run statistics of \"%s\": %s, %d rows processed, %.1f MiB of memory, %s\n",
+		stats.Command, stats.Duration.Round(time.Millisecond), stats.RowsProcessed, float64(stats.MemoryBytes)/(1024*1024), successLabel(isSuccess))
+	if statsPushgatewayURL == "" {
+		return nil
+	}
+	return pushRunStats(ctx, statsPushgatewayURL, statsJobName, stats)
+}
+
+// Describes the outcome of the run
+func successLabel(isSuccess bool) string {
+	if isSuccess {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// Formats the statistics in the Prometheus text format
+func formatRunStatsMetrics(stats runStats) string {
+	success := 0
+	if stats.IsSuccess {
+		success = 1
+	}
+	metrics := []struct {
+		name  string
+		help  string
+		value string
+	}{
+		{"duration_seconds", "Duration of the run", fmt.Sprintf("%g", stats.Duration.Seconds())},
+		{"rows_processed", "Number of input rows loaded", fmt.Sprintf("%d", stats.RowsProcessed)},
+		{"memory_bytes", "Memory obtained from the system", fmt.Sprintf("%d", stats.MemoryBytes)},
+		{"success", "1 if the run succeeded", fmt.Sprintf("%d", success)},
+		{"last_run_timestamp_seconds", "End of the run", fmt.Sprintf("%d", stats.FinishedAt.Unix())},
+	}
+
+	var sb strings.Builder
+	for _, metric := range metrics {
+		name := "jenkins_contribution_aggregator_" + metric.name
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", name, metric.help, name, name, metric.value)
+	}
+	return sb.String()
+}
+
+// Pushes the statistics to a Prometheus Pushgateway, grouped by job and command
+func pushRunStats(ctx context.Context, gatewayURL string, jobName string, stats runStats) error {
+	pushURL := strings.TrimSuffix(gatewayURL, "/") + "/metrics/job/" + url.PathEscape(jobName) + "/command/" + url.PathEscape(stats.Command)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, pushURL, bytes.NewReader([]byte(formatRunStatsMetrics(stats))))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to push the run statistics: %v", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("Pushgateway answered with status %s", response.Status)
+	}
+	logVerbose("Run statistics pushed to \"%s\"\n", pushURL)
+	return nil
+}
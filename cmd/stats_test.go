@@ -0,0 +1,124 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_formatRunStatsMetrics(t *testing.T) {
+	stats := runStats{
+		Command:       "extract",
+		Duration:      1500 * time.Millisecond,
+		RowsProcessed: 42,
+		MemoryBytes:   1024,
+		IsSuccess:     true,
+		FinishedAt:    time.Date(2023, 3, 4, 5, 6, 7, 0, time.UTC),
+	}
+
+	metrics := formatRunStatsMetrics(stats)
+
+	assert.Contains(t, metrics, "# TYPE jenkins_contribution_aggregator_duration_seconds gauge\njenkins_contribution_aggregator_duration_seconds 1.5\n")
+	assert.Contains(t, metrics, "jenkins_contribution_aggregator_rows_processed 42\n")
+	assert.Contains(t, metrics, "jenkins_contribution_aggregator_memory_bytes 1024\n")
+	assert.Contains(t, metrics, "jenkins_contribution_aggregator_success 1\n")
+	assert.Contains(t, metrics, "jenkins_contribution_aggregator_last_run_timestamp_seconds 1677906367\n")
+}
+
+func Test_pushRunStats(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+	}))
+	defer server.Close()
+
+	err := pushRunStats(context.Background(), server.URL+"/", "monthly", runStats{Command: "report", IsSuccess: false})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/monthly/command/report", path)
+	assert.Contains(t, body, "jenkins_contribution_aggregator_success 0\n")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	assert.Error(t, pushRunStats(context.Background(), failing.URL, "monthly", runStats{Command: "report"}))
+}
+
+func Test_ExecuteExtract_stats(t *testing.T) {
+	out := new(bytes.Buffer)
+	statsOutput = out
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("stats", "false")
+		statsOutput = os.Stderr
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/deleted_user_case.csv", "-m", "latest", "-o", filepath.Join(t.TempDir(), "top.csv"), "--stats"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Run statistics of \"extract\": ")
+	assert.Regexp(t, `, [1-9][0-9]* rows processed, `, out.String())
+	assert.Contains(t, out.String(), "succeeded")
+
+	// The statistics are only emitted once
+	assert.NoError(t, finishRunStats(context.Background(), false))
+	assert.NotContains(t, out.String(), "failed")
+}
+
+func Test_executeRoot_checkStats(t *testing.T) {
+	out := new(bytes.Buffer)
+	statsOutput = out
+	defer func() {
+		_ = rootCmd.PersistentFlags().Set("stats", "false")
+		statsOutput = os.Stderr
+		checkCmd.SilenceErrors = false
+		checkCmd.SilenceUsage = false
+	}()
+
+	// The rows read by the check are counted
+	rootCmd.SetArgs([]string{"check", "../test_data/deleted_user_case.csv", "--stats"})
+	assert.Equal(t, 0, executeRoot(context.Background()))
+	assert.Contains(t, out.String(), "Run statistics of \"check\": ")
+	assert.Contains(t, out.String(), ", 7 rows processed, ")
+	assert.Contains(t, out.String(), "succeeded")
+
+	// The statistics of a failed check are also emitted
+	out.Reset()
+	rootCmd.SetArgs([]string{"check", "../test_data/multiple_errors.csv", "--stats"})
+	assert.Equal(t, 1, executeRoot(context.Background()))
+	assert.Contains(t, out.String(), ", 5 rows processed, ")
+	assert.Contains(t, out.String(), "failed")
+}
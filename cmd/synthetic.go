@@ -0,0 +1,174 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Parameters of a synthetic pivot table
+type syntheticPivotSpec struct {
+	Submitters int
+	Months     int
+	EndMonth   time.Time // most recent month of the table
+	Skew       float64   // how much the activity is concentrated on the top submitters (0 for uniform)
+	MaxMean    float64   // average monthly count of the most active submitter
+	Seed       int64
+}
+
+// Building blocks of the generated usernames
+var syntheticNamePrefixes = []string{"amber", "brave", "calm", "dusty", "eager", "fuzzy", "gentle", "happy", "icy", "jolly",
+	"keen", "lucky", "mellow", "nimble", "odd", "proud", "quick", "rusty", "shy", "tidy", "urban", "vivid", "witty", "young", "zesty"}
+var syntheticNameSuffixes = []string{"badger", "beaver", "cobra", "dingo", "eagle", "falcon", "gecko", "heron", "ibis", "jaguar",
+	"koala", "lemur", "marmot", "newt", "otter", "panda", "quail", "raven", "seal", "tapir", "urchin", "viper", "walrus", "yak", "zebra"}
+
+// Generates a pivot table with the same layout as the ones generated by datamash.
+func generateSyntheticPivot(spec syntheticPivotSpec) ([][]string, error) {
+	var pivot [][]string
+	err := streamSyntheticPivot(spec, func(row []string) error {
+		pivot = append(pivot, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pivot, nil
+}
+
+// Writes a synthetic pivot table as CSV. The lines are written as they are generated
+// so that very large tables can be created.
+func writeSyntheticPivot(out io.Writer, spec syntheticPivotSpec) error {
+	csvOut := csv.NewWriter(out)
+	err := streamSyntheticPivot(spec, csvOut.Write)
+	if err != nil {
+		return err
+	}
+	csvOut.Flush()
+	return csvOut.Error()
+}
+
+// Generates the lines of a synthetic pivot table (header first) and passes them to emit.
+// The activity follows a Zipf-like distribution: the n-th submitter has an average
+// monthly count of MaxMean / n^Skew. Every submitter has at least one contribution.
+func streamSyntheticPivot(spec syntheticPivotSpec, emit func(row []string) error) error {
+	if spec.Submitters < 1 {
+		return fmt.Errorf("At least one submitter is required")
+	}
+	if spec.Months < 2 {
+		return fmt.Errorf("At least two months are required")
+	}
+	if spec.Skew < 0 {
+		return fmt.Errorf("The skew can't be negative")
+	}
+
+	random := rand.New(rand.NewSource(spec.Seed))
+
+	header := make([]string, spec.Months+1)
+	firstMonth := time.Date(spec.EndMonth.Year(), spec.EndMonth.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(spec.Months - 1), 0)
+	for i := 0; i < spec.Months; i++ {
+		header[i+1] = firstMonth.AddDate(0, i, 0).Format("2006-01")
+	}
+	if err := emit(header); err != nil {
+		return err
+	}
+
+	// The position in the generated list gives the activity rank of the submitter
+	names := generateSyntheticNames(random, spec.Submitters)
+
+	// datamash sorts the submitters by name
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+
+	for _, rank := range order {
+		mean := spec.MaxMean / math.Pow(float64(rank+1), spec.Skew)
+		row := make([]string, spec.Months+1)
+		row[0] = names[rank]
+		hasActivity := false
+		for month := 1; month <= spec.Months; month++ {
+			count := poissonSample(random, mean)
+			if count > 0 {
+				hasActivity = true
+			}
+			row[month] = strconv.Itoa(count)
+		}
+		// datamash only lists submitters with some activity
+		if !hasActivity {
+			row[1+random.Intn(spec.Months)] = "1"
+		}
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generates unique, GitHub compliant, usernames
+func generateSyntheticNames(random *rand.Rand, count int) []string {
+	names := make([]string, 0, count)
+	isUsed := make(map[string]bool, count)
+	for len(names) < count {
+		name := syntheticNamePrefixes[random.Intn(len(syntheticNamePrefixes))] + "-" + syntheticNameSuffixes[random.Intn(len(syntheticNameSuffixes))]
+		if isUsed[name] {
+			name = name + strconv.Itoa(len(names))
+		}
+		if isUsed[name] {
+			continue
+		}
+		isUsed[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// Draws a number following a Poisson distribution of the given mean
+func poissonSample(random *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	// For large means, the normal approximation is good enough (and much faster)
+	if mean > 30 {
+		value := int(math.Round(random.NormFloat64()*math.Sqrt(mean) + mean))
+		if value < 0 {
+			return 0
+		}
+		return value
+	}
+	// Knuth's algorithm
+	limit := math.Exp(-mean)
+	count := 0
+	product := random.Float64()
+	for product > limit {
+		count++
+		product *= random.Float64()
+	}
+	return count
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_generateSyntheticPivot(t *testing.T) {
+	spec := syntheticPivotSpec{
+		Submitters: 200,
+		Months:     24,
+		EndMonth:   time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC),
+		Skew:       1.0,
+		MaxMean:    20,
+		Seed:       42,
+	}
+
+	pivot, err := generateSyntheticPivot(spec)
+
+	assert.NoError(t, err)
+	assert.Len(t, pivot, 201)
+	assert.Equal(t, []string{"", "2021-05", "2021-06"}, pivot[0][:3])
+	assert.Equal(t, "2023-04", pivot[0][24])
+
+	// The generated file must be a valid input
+	tempDir := t.TempDir()
+	fileName := filepath.Join(tempDir, "sample.csv")
+	writeCSVtoFile(fileName, pivot)
+	assert.Empty(t, validatePivotTable(fileName))
+
+	// The same seed generates the same table
+	again, _ := generateSyntheticPivot(spec)
+	assert.Equal(t, pivot, again)
+}
+
+func Test_generateSyntheticPivot_invalidSpec(t *testing.T) {
+	_, err := generateSyntheticPivot(syntheticPivotSpec{Submitters: 0, Months: 12})
+	assert.Error(t, err)
+	_, err = generateSyntheticPivot(syntheticPivotSpec{Submitters: 10, Months: 1})
+	assert.Error(t, err)
+	_, err = generateSyntheticPivot(syntheticPivotSpec{Submitters: 10, Months: 12, Skew: -1})
+	assert.Error(t, err)
+}
+
+func Test_poissonSample(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, mean := range []float64{0.5, 5, 100} {
+		total := 0
+		for i := 0; i < 2000; i++ {
+			total += poissonSample(random, mean)
+		}
+		assert.InDelta(t, mean, float64(total)/2000, mean*0.1+0.05)
+	}
+	assert.Equal(t, 0, poissonSample(random, 0))
+}
+
+func Test_generateSyntheticPivot_skew(t *testing.T) {
+	// Share of the activity of the top 10% submitters
+	topShare := func(skew float64) float64 {
+		pivot, err := generateSyntheticPivot(syntheticPivotSpec{Submitters: 100, Months: 12, EndMonth: time.Now(), Skew: skew, MaxMean: 20, Seed: 3})
+		assert.NoError(t, err)
+		leaderboard := computeLeaderboard(pivot, 1, 12)
+		total, top := 0, 0
+		for i, entry := range leaderboard {
+			total += entry.Total
+			if i < 10 {
+				top += entry.Total
+			}
+		}
+		return float64(top) / float64(total)
+	}
+
+	assert.Less(t, topShare(0), 0.2)
+	assert.Greater(t, topShare(1.5), 0.6)
+}
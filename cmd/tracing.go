@@ -0,0 +1,271 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The tracer of the current run (nil if the tracing is not configured)
+var activeTracer *tracer
+
+var traceparentRegexp = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// Collects the spans of the run and exports them with OTLP (HTTP/JSON) at the end.
+// It is configured with the standard OpenTelemetry environment variables.
+type tracer struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	traceID     string
+	root        *traceSpan
+
+	mu    sync.Mutex
+	spans []*traceSpan
+}
+
+// A phase of the run (load, validate, compute, render, publish)
+type traceSpan struct {
+	tracer     *tracer
+	spanID     string
+	parentID   string
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes map[string]any
+	errMessage string
+}
+
+// Returns the configuration of the OTLP traces exporter, from the OpenTelemetry environment variables
+func getTracingConfig() (endpoint string, headers map[string]string, serviceName string) {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return "", nil, ""
+	}
+	endpoint = os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" && os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		endpoint = strings.TrimSuffix(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "/") + "/v1/traces"
+	}
+
+	headers = make(map[string]string)
+	for _, variable := range []string{"OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_TRACES_HEADERS"} {
+		for _, header := range strings.Split(os.Getenv(variable), ",") {
+			name, value, isValid := strings.Cut(header, "=")
+			if !isValid {
+				continue
+			}
+			if unescaped, err := url.QueryUnescape(value); err == nil {
+				value = unescaped
+			}
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+
+	serviceName = os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "jenkins-contribution-aggregator"
+	}
+	return endpoint, headers, serviceName
+}
+
+// Starts tracing the run, if an OTLP endpoint is configured. The trace continues the one
+// given in the TRACEPARENT environment variable (ex: set by the CI pipeline), if any.
+func startTracing(commandPath string, now time.Time) {
+	activeTracer = nil
+	endpoint, headers, serviceName := getTracingConfig()
+	if endpoint == "" {
+		return
+	}
+
+	t := &tracer{endpoint: endpoint, headers: headers, serviceName: serviceName, traceID: randomHexID(16)}
+	parentID := ""
+	if matches := traceparentRegexp.FindStringSubmatch(os.Getenv("TRACEPARENT")); matches != nil {
+		t.traceID, parentID = matches[1], matches[2]
+	}
+	t.root = &traceSpan{tracer: t, spanID: randomHexID(8), parentID: parentID, name: commandPath, start: now, attributes: make(map[string]any)}
+	t.spans = append(t.spans, t.root)
+	activeTracer = t
+}
+
+// Starts a span, child of the span of the run. Returns nil if the tracing is not configured.
+func startSpan(name string) *traceSpan {
+	t := activeTracer
+	if t == nil {
+		return nil
+	}
+	span := &traceSpan{tracer: t, spanID: randomHexID(8), parentID: t.root.spanID, name: name, start: time.Now(), attributes: make(map[string]any)}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return span
+}
+
+// Adds an attribute (string or int) to the span
+func (s *traceSpan) setAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	s.attributes[key] = value
+	s.tracer.mu.Unlock()
+}
+
+// Ends the span, in error if err is not nil
+func (s *traceSpan) finish(err error) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	s.end = time.Now()
+	if err != nil {
+		s.errMessage = err.Error()
+	}
+	s.tracer.mu.Unlock()
+}
+
+// Ends the span of the run and exports the trace. Only done once per run.
+func finishTracing(ctx context.Context, err error) error {
+	t := activeTracer
+	if t == nil {
+		return nil
+	}
+	activeTracer = nil
+	t.root.finish(err)
+	return t.export(ctx)
+}
+
+// Sends the spans to the OTLP endpoint
+func (t *tracer) export(ctx context.Context) error {
+	payload, err := json.Marshal(t.otlpPayload())
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range t.headers {
+		request.Header.Set(name, value)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to export the trace: %v", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("OTLP endpoint answered with status %s", response.Status)
+	}
+	logVerbose("Trace %s exported to \"%s\"\n", t.traceID, t.endpoint)
+	return nil
+}
+
+// Builds the OTLP (JSON encoding) export request
+func (t *tracer) otlpPayload() map[string]any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	spans := make([]map[string]any, 0, len(t.spans))
+	for _, span := range t.spans {
+		end := span.end
+		if end.IsZero() {
+			end = t.root.end
+		}
+		otlpSpan := map[string]any{
+			"traceId":           t.traceID,
+			"spanId":            span.spanID,
+			"name":              span.name,
+			"kind":              1, // internal
+			"startTimeUnixNano": strconv.FormatInt(span.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(end.UnixNano(), 10),
+			"attributes":        otlpAttributes(span.attributes),
+			"status":            map[string]any{"code": 1}, // ok
+		}
+		if span.parentID != "" {
+			otlpSpan["parentSpanId"] = span.parentID
+		}
+		if span.errMessage != "" {
+			otlpSpan["status"] = map[string]any{"code": 2, "message": span.errMessage}
+		}
+		spans = append(spans, otlpSpan)
+	}
+
+	return map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{"attributes": otlpAttributes(map[string]any{
+				"service.name":    t.serviceName,
+				"service.version": version,
+			})},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "jenkins-contribution-aggregator", "version": version},
+				"spans": spans,
+			}},
+		}},
+	}
+}
+
+// Converts the attributes to their OTLP form (sorted by key)
+func otlpAttributes(attributes map[string]any) []map[string]any {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	otlp := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]any
+		switch typed := attributes[key].(type) {
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(typed)}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(typed)}
+		}
+		otlp = append(otlp, map[string]any{"key": key, "value": value})
+	}
+	return otlp
+}
+
+// Returns a random identifier of the given number of bytes, hex encoded
+func randomHexID(size int) string {
+	id := make([]byte, size)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Minimal view of an OTLP export request
+type otlpRequest struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Status       struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+// Starts an OTLP endpoint collecting the export requests
+func startOTLPServer(t *testing.T) (*httptest.Server, *[]otlpRequest, *http.Header) {
+	var requests []otlpRequest
+	headers := http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		content, _ := io.ReadAll(r.Body)
+		var request otlpRequest
+		assert.NoError(t, json.Unmarshal(content, &request))
+		requests = append(requests, request)
+		for name, values := range r.Header {
+			headers[name] = values
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &headers
+}
+
+func Test_getTracingConfig(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20secret,x-team=infra")
+	t.Setenv("OTEL_SERVICE_NAME", "monthly-job")
+
+	endpoint, headers, serviceName := getTracingConfig()
+
+	assert.Equal(t, "http://collector:4318/v1/traces", endpoint)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret", "x-team": "infra"}, headers)
+	assert.Equal(t, "monthly-job", serviceName)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://traces:4318/custom")
+	endpoint, _, _ = getTracingConfig()
+	assert.Equal(t, "http://traces:4318/custom", endpoint)
+
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	endpoint, _, _ = getTracingConfig()
+	assert.Empty(t, endpoint)
+}
+
+func Test_tracing(t *testing.T) {
+	server, requests, headers := startOTLPServer(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-team=infra")
+	t.Setenv("TRACEPARENT", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+	startTracing("jenkins-contribution-aggregator extract", time.Now())
+	span := startSpan("render")
+	span.setAttribute("file", "top.csv")
+	span.finish(errors.New("disk full"))
+	assert.NoError(t, finishTracing(context.Background(), nil))
+
+	// Only exported once
+	assert.NoError(t, finishTracing(context.Background(), nil))
+	assert.Nil(t, startSpan("ignored"))
+
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "infra", headers.Get("x-team"))
+	spans := (*requests)[0].ResourceSpans[0].ScopeSpans[0].Spans
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "jenkins-contribution-aggregator extract", spans[0].Name)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].TraceID)
+	assert.Equal(t, "b7ad6b7169203331", spans[0].ParentSpanID)
+	assert.Equal(t, 1, spans[0].Status.Code)
+	assert.Equal(t, "render", spans[1].Name)
+	assert.Equal(t, spans[0].SpanID, spans[1].ParentSpanID)
+	assert.Equal(t, 2, spans[1].Status.Code)
+	assert.Equal(t, "disk full", spans[1].Status.Message)
+}
+
+func Test_ExecuteExtract_tracing(t *testing.T) {
+	server, requests, _ := startOTLPServer(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/deleted_user_case.csv", "-m", "latest", "-o", filepath.Join(t.TempDir(), "top.csv")})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 1)
+	var names []string
+	for _, span := range (*requests)[0].ResourceSpans[0].ScopeSpans[0].Spans {
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, "jenkins-contribution-aggregator extract", names[0])
+	assert.Contains(t, names, "validate")
+	assert.Contains(t, names, "load")
+	assert.Contains(t, names, "compute")
+	assert.Contains(t, names, "render")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var isAtomicOutput bool
+
+// The files of a run are written in a staging directory and moved into place only if the
+// whole run succeeded, so that a failure doesn't leave a half-updated output directory.
+type outputTransaction struct {
+	targetDir  string
+	stagingDir string
+}
+
+// Adds the "--atomic" flag to a command generating several files
+func addAtomicOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&isAtomicOutput, "atomic", "", false, "Moves the generated files into place only if they were all successfully generated")
+}
+
+// Creates the staging directory, next to the output file (so that the files can be moved with a rename)
+func beginOutputTransaction(outputFileName string) (*outputTransaction, error) {
+	targetDir := filepath.Dir(outputFileName)
+	stagingDir, err := os.MkdirTemp(targetDir, ".staging-")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create the staging directory: %v", err)
+	}
+	logVerbose("Staging the generated files in \"%s\"\n", stagingDir)
+	return &outputTransaction{targetDir: targetDir, stagingDir: stagingDir}, nil
+}
+
+// Returns the name under which a file of the output directory is written until the commit
+func (t *outputTransaction) stagedName(fileName string) string {
+	return filepath.Join(t.stagingDir, filepath.Base(fileName))
+}
+
+// Returns the final names of the staged files
+func (t *outputTransaction) finalNames(fileNames []string) []string {
+	names := make([]string, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		relativeName, err := filepath.Rel(t.stagingDir, fileName)
+		if err != nil || strings.HasPrefix(relativeName, "..") {
+			names = append(names, fileName)
+			continue
+		}
+		names = append(names, filepath.Join(t.targetDir, relativeName))
+	}
+	return names
+}
+
+// Moves all the staged files (including the ones in sub-directories, ex: the charts) into place
+func (t *outputTransaction) commit() error {
+	err := filepath.WalkDir(t.stagingDir, func(stagedFile string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativeName, err := filepath.Rel(t.stagingDir, stagedFile)
+		if err != nil {
+			return err
+		}
+		finalName := filepath.Join(t.targetDir, relativeName)
+		if err := os.MkdirAll(filepath.Dir(finalName), os.ModePerm); err != nil {
+			return err
+		}
+		return os.Rename(stagedFile, finalName)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to move the generated files into place: %v", err)
+	}
+	return os.RemoveAll(t.stagingDir)
+}
+
+// Removes the staging directory and whatever it still contains
+func (t *outputTransaction) rollback() {
+	_ = os.RemoveAll(t.stagingDir)
+}
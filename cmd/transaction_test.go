@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outputTransaction(t *testing.T) {
+	targetDir := t.TempDir()
+	outputFile := filepath.Join(targetDir, "top.csv")
+	assert.NoError(t, os.WriteFile(outputFile, []byte("previous"), 0644))
+
+	transaction, err := beginOutputTransaction(outputFile)
+	assert.NoError(t, err)
+	stagedFile := transaction.stagedName(outputFile)
+	assert.NoError(t, os.WriteFile(stagedFile, []byte("new"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(transaction.stagingDir, "plot"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(transaction.stagingDir, "plot", "alpha.png"), []byte("png"), 0644))
+
+	// Nothing is visible before the commit
+	content, _ := os.ReadFile(outputFile)
+	assert.Equal(t, "previous", string(content))
+	assert.NoFileExists(t, filepath.Join(targetDir, "plot", "alpha.png"))
+
+	assert.NoError(t, transaction.commit())
+	content, _ = os.ReadFile(outputFile)
+	assert.Equal(t, "new", string(content))
+	assert.FileExists(t, filepath.Join(targetDir, "plot", "alpha.png"))
+	assert.NoDirExists(t, transaction.stagingDir)
+	assert.Equal(t, []string{outputFile, "../elsewhere.json"}, transaction.finalNames([]string{stagedFile, "../elsewhere.json"}))
+}
+
+func Test_outputTransaction_rollback(t *testing.T) {
+	targetDir := t.TempDir()
+	outputFile := filepath.Join(targetDir, "top.csv")
+
+	transaction, err := beginOutputTransaction(outputFile)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(transaction.stagedName(outputFile), []byte("new"), 0644))
+	transaction.rollback()
+
+	entries, err := os.ReadDir(targetDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_ExecuteExtract_atomic(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "top.csv")
+	defer func() {
+		_ = extractCmd.PersistentFlags().Set("history", "false")
+		_ = extractCmd.Flags().Set("atomic", "false")
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "--history", "--atomic", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.FileExists(t, outputFile)
+	assert.FileExists(t, filepath.Join(tempDir, "top_submitters_fullHistory.csv"))
+	assert.DirExists(t, filepath.Join(tempDir, "plot"))
+	stagingDirs, err := filepath.Glob(filepath.Join(tempDir, ".staging-*"))
+	assert.NoError(t, err)
+	assert.Empty(t, stagingDirs)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var isWithTrend bool
+
+// Trend of the rank compared with the previous month
+const (
+	trendUp     = "↑"
+	trendDown   = "↓"
+	trendStable = "→"
+)
+
+// Adds a "Trend" column to the extraction, comparing the rank of each user with its rank
+// in the period ending one month before.
+func addTrendColumn(inputFilename string, data [][]string, endMonth string, period int) ([][]string, error) {
+	header, err := loadPivotTableHeader(inputFilename)
+	if err != nil {
+		return nil, err
+	}
+	firstColumn, lastColumn, _, _ := getBoundaries([][]string{header}, endMonth, period, 1)
+	if lastColumn == 0 || firstColumn < 1 {
+		return nil, fmt.Errorf("No previous month available to compute the trend")
+	}
+	previousRecords, err := loadProjectedPivotTable(inputFilename, firstColumn, lastColumn)
+	if err != nil {
+		return nil, err
+	}
+	return computeTrendColumn(data, previousRecords), nil
+}
+
+// Adds the trend column to the extraction, based on the projected pivot table of the previous period.
+// The users not active in the previous period are trending up.
+func computeTrendColumn(data [][]string, previousRecords [][]string) [][]string {
+	previousRanks := make(map[string]int)
+	for _, entry := range computeLeaderboard(previousRecords, 1, len(previousRecords[0])-1) {
+		if entry.Total > 0 {
+			previousRanks[entry.User] = entry.Rank
+		}
+	}
+
+	result := [][]string{append(append([]string{}, data[0]...), "Trend")}
+	rank, previousTotal := 0, -1
+	for i, line := range data[1:] {
+		// The ex-aequo share the same rank
+		if total, _ := strconv.Atoi(line[1]); total != previousTotal {
+			rank = i + 1
+			previousTotal = total
+		}
+
+		trend := trendStable
+		previousRank, isFound := previousRanks[line[0]]
+		switch {
+		case !isFound || rank < previousRank:
+			trend = trendUp
+		case rank > previousRank:
+			trend = trendDown
+		}
+		result = append(result, append(append([]string{}, line...), trend))
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_computeTrendColumn(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"alpha", "12"},
+		{"bravo", "9"},
+		{"charlie", "9"},
+		{"delta", "4"},
+	}
+	previousRecords := [][]string{
+		{"", "2023-01", "2023-02"},
+		{"alpha", "5", "5"},
+		{"bravo", "2", "0"},
+		{"charlie", "4", "8"},
+		{"delta", "0", "0"},
+	}
+
+	result := computeTrendColumn(data, previousRecords)
+
+	expected := [][]string{
+		{"Submitter", "Total_PRs", "Trend"},
+		{"alpha", "12", "↑"},  // 1st, was 2nd
+		{"bravo", "9", "↑"},   // 2nd (ex-aequo), was 3rd
+		{"charlie", "9", "↓"}, // 2nd (ex-aequo), was 1st
+		{"delta", "4", "↑"},   // not active before
+	}
+	assert.Equal(t, expected, result)
+}
+
+func Test_addTrendColumn(t *testing.T) {
+	data := [][]string{
+		{"Submitter", "Total_PRs"},
+		{"basil", "1476"},
+	}
+
+	result, err := addTrendColumn("../test_data/overview.csv", data, "latest", 12)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Submitter", "Total_PRs", "Trend"}, {"basil", "1476", "→"}}, result)
+
+	// There is no month before the first one
+	_, err = addTrendColumn("../test_data/overview.csv", data, "2020-01", 1)
+	assert.Error(t, err)
+}
+
+func Test_ExecuteExtract_trend(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+	defer resetFlags(extractCmd)
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "3", "--history=false", "-o", outputFile, "--trend"})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	result, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Submitter", "Total_PRs", "Trend"}, {"basil", "1476", "→"}, {"lemeurherve", "870", "↑"}, {"NotMyFault", "852", "↓"}}, result)
+}
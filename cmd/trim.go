@@ -0,0 +1,133 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var trimFromMonth string
+var trimToMonth string
+var isTrimDropEmpty bool
+var trimOutputFileName string
+
+// trimCmd represents the trim command
+var trimCmd = &cobra.Command{
+	Use:   "trim [input file]",
+	Short: "Removes months and inactive submitters from a pivot table",
+	Long: `The TRIM command produces a smaller pivot table, for example to be shared.
+
+The month columns outside of the "--from" and "--to" range are removed. With
+"--drop-empty", the submitters without any activity in the remaining months
+are removed too.
+
+By default, the result is written to the input file name with a "_trimmed" suffix.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+		if !isFileValid(args[0]) {
+			return fmt.Errorf("Invalid input file\n")
+		}
+		for _, month := range []string{trimFromMonth, trimToMonth} {
+			if month != "" && (!isValidMonth(month, isVerbose()) || strings.EqualFold(month, "latest")) {
+				return fmt.Errorf("\"%s\" is an invalid month\n", month)
+			}
+		}
+		if trimFromMonth != "" && trimToMonth != "" && trimFromMonth > trimToMonth {
+			return fmt.Errorf("The start month (%s) is after the end month (%s)", trimFromMonth, trimToMonth)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// When called standalone, we want to give the minimal information
+		isSilent := true
+
+		if !checkFile(args[0], isSilent) {
+			return fmt.Errorf("Invalid input file.")
+		}
+
+		dataset, err := loadDataset(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		trimmed, err := trimDataset(dataset, trimFromMonth, trimToMonth, isTrimDropEmpty)
+		if err != nil {
+			return err
+		}
+
+		outputFileName := trimOutputFileName
+		if outputFileName == "" {
+			extension := filepath.Ext(args[0])
+			outputFileName = strings.TrimSuffix(args[0], extension) + "_trimmed" + extension
+		}
+		if dirErr := CheckDir(outputFileName); dirErr != nil {
+			return dirErr
+		}
+		if err := writeCSVtoFile(outputFileName, trimmed.Records()); err != nil {
+			return err
+		}
+
+		logInfo("Kept %d months and %d of the %d submitters in \"%s\"\n", len(trimmed.Months), len(trimmed.Users), len(dataset.Users), outputFileName)
+		return nil
+	},
+}
+
+// Initialize the Cobra processor
+func init() {
+	rootCmd.AddCommand(trimCmd)
+
+	trimCmd.Flags().StringVarP(&trimFromMonth, "from", "", "", "First month to keep (YYYY-MM, default is the oldest month)")
+	trimCmd.Flags().StringVarP(&trimToMonth, "to", "", "", "Last month to keep (YYYY-MM, default is the most recent month)")
+	trimCmd.Flags().BoolVarP(&isTrimDropEmpty, "drop-empty", "", false, "Removes the submitters without activity in the kept months")
+	trimCmd.Flags().StringVarP(&trimOutputFileName, "out", "o", "", "Output file name (default is the input file name with a \"_trimmed\" suffix)")
+
+	trimCmd.ValidArgsFunction = completeInputFile
+	_ = trimCmd.RegisterFlagCompletionFunc("from", completeMonth)
+	_ = trimCmd.RegisterFlagCompletionFunc("to", completeMonth)
+}
+
+// Returns the dataset limited to the months between fromMonth and toMonth (empty for
+// no limit). If requested, the submitters without activity in these months are removed.
+func trimDataset(dataset *Dataset, fromMonth string, toMonth string, isDropEmpty bool) (*Dataset, error) {
+	trimmed, err := dataset.Between(fromMonth, toMonth)
+	if err != nil {
+		return nil, err
+	}
+	if removedMonths := len(dataset.Months) - len(trimmed.Months); removedMonths > 0 {
+		recordTransformation(auditFilter, nil, "%d months outside of %s..%s removed", removedMonths, trimmed.Months[0], trimmed.Months[len(trimmed.Months)-1])
+	}
+
+	if !isDropEmpty {
+		return trimmed, nil
+	}
+	trimmed, droppedUsers := trimmed.WithoutInactiveUsers()
+	if len(droppedUsers) > 0 {
+		recordTransformation(auditFilter, droppedUsers, "%d users without activity in the period removed", len(droppedUsers))
+	}
+	return trimmed, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_trimDataset(t *testing.T) {
+	records := [][]string{
+		{"", "2022-12", "2023-01", "2023-02", "2023-03"},
+		{"alpha", "1", "0", "0", "0"},
+		{"bravo", "0", "2", "0", "5"},
+		{"charly", "0", "0", "0", "0"},
+	}
+
+	tests := []struct {
+		name        string
+		fromMonth   string
+		toMonth     string
+		isDropEmpty bool
+		want        [][]string
+		wantErr     bool
+	}{
+		{
+			"no change",
+			"", "", false,
+			records,
+			false,
+		},
+		{
+			"range",
+			"2023-01", "2023-02", false,
+			[][]string{{"", "2023-01", "2023-02"}, {"alpha", "0", "0"}, {"bravo", "2", "0"}, {"charly", "0", "0"}},
+			false,
+		},
+		{
+			"range and drop empty",
+			"2023-01", "", true,
+			[][]string{{"", "2023-01", "2023-02", "2023-03"}, {"bravo", "2", "0", "5"}},
+			false,
+		},
+		{
+			"only drop empty",
+			"", "", true,
+			[][]string{records[0], records[1], records[2]},
+			false,
+		},
+		{
+			"out of range",
+			"2024-01", "", false,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataset, err := newDataset("test", records)
+			assert.NoError(t, err)
+
+			got, err := trimDataset(dataset, tt.fromMonth, tt.toMonth, tt.isDropEmpty)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Records())
+		})
+	}
+}
+
+func Test_ExecuteTrim(t *testing.T) {
+	tempDir := t.TempDir()
+	outputFile := filepath.Join(tempDir, "trimmed.csv")
+	defer func() {
+		_ = trimCmd.Flags().Set("from", "")
+		_ = trimCmd.Flags().Set("drop-empty", "false")
+		_ = trimCmd.Flags().Set("out", "")
+	}()
+
+	rootCmd.SetArgs([]string{"trim", "../test_data/deleted_user_case.csv", "--from", "2022-01", "--drop-empty", "-o", outputFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	records, err := loadInputPivotTable(outputFile)
+	assert.NoError(t, err)
+	// Only ADITYADAS1999 has some activity since 2022-01
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "2022-01", records[0][1])
+	assert.Equal(t, "ADITYADAS1999", records[1][0])
+}
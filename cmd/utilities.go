@@ -24,14 +24,14 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/csv"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Validates that the input file is a real file (and not a directory)
@@ -67,21 +67,19 @@ func isValidMonth(month string, isVerbose bool) bool {
 }
 
 // Write the string slice to a file formatted as a CSV
-func writeCSVtoFile(outputFileName string, csv_output_slice [][]string) {
+func writeCSVtoFile(outputFileName string, csv_output_slice [][]string) error {
 	//Open output file
 	out, err := os.Create(outputFileName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer out.Close()
 
 	//Write the collected data as a CSV file
-	csv_out := csv.NewWriter(out)
-	write_err := csv_out.WriteAll(csv_output_slice)
-	if write_err != nil {
-		log.Fatal(err)
+	if write_err := writeCSV(out, csv_output_slice); write_err != nil {
+		out.Close()
+		return write_err
 	}
-	csv_out.Flush()
+	return out.Close()
 }
 
 // returns true if the file extension is .md.
@@ -96,35 +94,78 @@ func isWithMDfileExtension(filename string) bool {
 }
 
 // TODO: externalize the header creation
-// TODO: return error
 // Writes the data as Markdown
-func writeDataAsMarkdown(outputFileName string, output_data_slice [][]string, introductionText string, isHistory bool, inputType InputType, ) {
+func writeDataAsMarkdown(outputFileName string, output_data_slice [][]string, introductionText string, isHistory bool, inputType InputType) error {
+	return writeDataAsMarkdownWithNotes(outputFileName, output_data_slice, introductionText, "", isHistory, inputType)
+}
+
+// Writes the data as Markdown, followed by the notes (if any)
+func writeDataAsMarkdownWithNotes(outputFileName string, output_data_slice [][]string, introductionText string, notesText string, isHistory bool, inputType InputType) error {
 	//Open output file
 	f, err := os.Create(outputFileName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer f.Close()
 	out := bufio.NewWriter(f)
 
-	width_slice, err := get_columnsWidth(output_data_slice)
-	if err != nil {
-		log.Fatal(err)
-	}
+	//Write the provenance of the report
+	fmt.Fprintf(out, "%s\n\n", getProvenanceHeader())
 
 	//Write the intro text if present
 	if len(introductionText) > 0 {
 		fmt.Fprintf(out, "%s\n", introductionText)
 	}
 
-	// set the plot directory name based on the data type (submitters or commenters)
-	plot_dir := ""
+	if err := writeMarkdownTable(out, output_data_slice, getPlottedUsers(outputFileName, isHistory, inputType), inputType); err != nil {
+		return err
+	}
+
+	//Write the notes if present
+	if len(notesText) > 0 {
+		fmt.Fprintf(out, "\n%s", notesText)
+	}
+
+	return out.Flush()
+}
+
+// Returns the name of the directory of the plots, next to the history file
+func getPlotDirName(inputType InputType) string {
 	if inputType == InputTypeCommenters {
-		//FIXME: should be a global constant (used in the graph generation and in MD generation)
-		plot_dir = "commentersPlot"
-	} else {
-		plot_dir = "plot"
+		return "commentersPlot"
 	}
+	return "plot"
+}
+
+// Returns the users having a plot next to the Markdown file (nil if the history isn't generated),
+// so that the table only links to the plots that exist.
+func getPlottedUsers(markdownFileName string, isHistory bool, inputType InputType) map[string]bool {
+	if !isHistory {
+		return nil
+	}
+	plotted := make(map[string]bool)
+	entries, _ := os.ReadDir(filepath.Join(filepath.Dir(markdownFileName), getPlotDirName(inputType)))
+	for _, entry := range entries {
+		if name, isPlot := strings.CutSuffix(entry.Name(), ".png"); isPlot {
+			plotted[name] = true
+		}
+	}
+	return plotted
+}
+
+// Writes the data as a Markdown table (the first line is the header). The users
+// found in plotted are linked to their plot.
+func writeMarkdownTable(out io.Writer, output_data_slice [][]string, plotted map[string]bool, inputType InputType) error {
+	if isHumanized {
+		output_data_slice = humanizeMarkdownTable(output_data_slice)
+	}
+	width_slice, err := get_columnsWidth(output_data_slice)
+	if err != nil {
+		return err
+	}
+
+	// set the plot directory name based on the data type (submitters or commenters)
+	plot_dir := getPlotDirName(inputType)
 
 	for lineNumber, dataLine := range output_data_slice {
 		//Are we dealing with the title (and underline) ?
@@ -137,7 +178,7 @@ func writeDataAsMarkdown(outputFileName string, output_data_slice [][]string, in
 		underlineBuffer := "|"
 		for columnNbr, data := range dataLine {
 			//Check whether the value is numerical (we don't treat the case of float data)
-			_, atoi_err := strconv.Atoi(data)
+			_, atoi_err := strconv.Atoi(exactCount(data))
 			exact_width := 0
 			if atoi_err != nil {
 				//not integer -> left align
@@ -158,15 +199,13 @@ func writeDataAsMarkdown(outputFileName string, output_data_slice [][]string, in
 				underlineBuffer = underlineBuffer + " " + headerUnderline + " |"
 			}
 
-			// isHistory means that the history (and plots) is generated along the MD.
-			//This means that we need to create a link to the plots
+			// The history (and plots) is generated along the MD.
+			//This means that we need to create a link to the plots (the ones generated)
 			formattedData := ""
-			if isHistory && (columnNbr == 0) && (lineNumber != 0){
-				//data contains the user name (eventually enriched)
-				name_element := strings.Split(data, " ")
-				cleanedName := name_element[0]
-			
-				formattedData = fmt.Sprintf(" [%s](%s/%s.png)", data, plot_dir,cleanedName)
+			//data contains the user name (eventually enriched)
+			cleanedName := strings.Trim(strings.Split(data, " ")[0], "*")
+			if (columnNbr == 0) && (lineNumber != 0) && plotted[cleanedName] {
+				formattedData = fmt.Sprintf(" [%s](%s/%s.png)", data, plot_dir, cleanedName)
 			} else {
 				formattedData = fmt.Sprintf(" %*s", exact_width, data)
 			}
@@ -177,8 +216,7 @@ func writeDataAsMarkdown(outputFileName string, output_data_slice [][]string, in
 		}
 		fmt.Fprint(out, writeBuffer+"\n")
 	}
-
-	out.Flush()
+	return nil
 }
 
 // Returns a list of the maximum width of data supplied in data slice
@@ -200,8 +238,8 @@ func get_columnsWidth(output_data_slice [][]string) (width_slice []int, err erro
 
 		//get the size of each data cell and update the counter slice if necessary
 		for columnNbr, data_cell := range slice_line {
-			if len(data_cell) > width_slice[columnNbr] {
-				width_slice[columnNbr] = len(data_cell)
+			if utf8.RuneCountInString(data_cell) > width_slice[columnNbr] {
+				width_slice[columnNbr] = utf8.RuneCountInString(data_cell)
 			}
 		}
 	}
@@ -211,15 +249,29 @@ func get_columnsWidth(output_data_slice [][]string) (width_slice []int, err erro
 // CheckDir verifies a given path/file string actually exists. If it does not
 // then exit with an error.
 func CheckDir(file string) error {
-	path := filepath.Dir(file)
-	if _, err := os.Stat(path); err != nil {
+	path := filepath.Dir(filepath.Clean(filepath.FromSlash(file)))
+	info, err := os.Stat(path)
+	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("The directory of specified output file (%s) does not exist.", path)
 		}
+		return nil
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("The directory of specified output file (%s) is not a directory.", path)
 	}
 	return nil
 }
 
+// Reads a text file supplied by the user, with the Windows line endings (CRLF) converted to LF
+func readTextFile(fileName string) (string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(string(content), "\r\n", "\n"), nil
+}
+
 // Based on the requested output filename (pivot table), builds a filename to store the history
 func generateHistoryFilename(outputFilename string, dataType InputType, isCompare bool) (historyFilename string) {
 
@@ -238,7 +290,7 @@ func generateHistoryFilename(outputFilename string, dataType InputType, isCompar
 		extractType = "_evolution"
 	}
 
-	historyFilename = path + "/" + "top_" + historyFilenameType + extractType + "_fullHistory.csv"
+	historyFilename = path + string(filepath.Separator) + "top_" + historyFilenameType + extractType + "_fullHistory.csv"
 
 	return historyFilename
 }
@@ -247,20 +299,23 @@ func generateHistoryFilename(outputFilename string, dataType InputType, isCompar
 func writeHistoryOutput(historyOutputFilename string, inputFilename string, dataType InputType, csv_output_slice [][]string) (err error) {
 
 	// Check is the csv_output_slice is at least 1 record + tile long
-	if len(csv_output_slice) <= 2 {
+	if len(csv_output_slice) < 2 {
 		return fmt.Errorf("The generated top user data seems empty.")
 	}
 
-	// Are we dealing with COMPARE type output (it has three columns)?
+	// Are we dealing with COMPARE type output (it has a status column: the third one, or the
+	// last one of the evolution of the users given with "--user")?
 	// Note: this could have been a parameter for robustness. Can be refactored later (TODO:)
-	isCompare := false
+	statusColumn := -1
 	expectedCompareColumnTitle := "status"
-	if len(csv_output_slice[0]) == 3 {
-		isCompare = true
-		if strings.ToLower(csv_output_slice[0][2]) != expectedCompareColumnTitle {
-			return fmt.Errorf("COMPARE output check failure: found three columns but third one doesn't have the expected title (found \"%s\" instead of \"%s\")", csv_output_slice[0][2], expectedCompareColumnTitle)
+	for column, title := range csv_output_slice[0] {
+		if column > 0 && strings.EqualFold(title, expectedCompareColumnTitle) {
+			statusColumn = column
 		}
 	}
+	if len(csv_output_slice[0]) == 3 && statusColumn != 2 {
+		return fmt.Errorf("COMPARE output check failure: found three columns but third one doesn't have the expected title (found \"%s\" instead of \"%s\")", csv_output_slice[0][2], expectedCompareColumnTitle)
+	}
 
 	// Load the pivot table in memory
 	pivotRecords, loadErr := loadInputPivotTable(inputFilename)
@@ -273,6 +328,14 @@ func writeHistoryOutput(historyOutputFilename string, inputFilename string, data
 		return fmt.Errorf("The pivot table (%s) seems empty.", inputFilename)
 	}
 
+	// If requested, only the users who reached the rank in a month are kept
+	var bestRanks map[string]int
+	if historyRank > 0 {
+		if bestRanks, err = bestMonthlyRanks(pivotRecords); err != nil {
+			return err
+		}
+	}
+
 	//This is a new slice that will contain the data to write
 	var historicDataSlice [][]string
 
@@ -294,13 +357,19 @@ func writeHistoryOutput(historyOutputFilename string, inputFilename string, data
 		if index == -1 {
 			return fmt.Errorf("Supplied name (%s) was not found in input pivot table file", name)
 		}
+		if bestRanks != nil {
+			if rank, isRanked := bestRanks[name]; !isRanked || rank > historyRank {
+				logVerbose("%s is not in the history as never ranked %d or better in a month\n", name, historyRank)
+				continue
+			}
+		}
 
 		// If we are dealing with a Compare output we need to update the user handle with its status
 		fullUsername := name
-		if isCompare {
+		if statusColumn > 0 {
 			//We need to update the name with the status (if there is one)
-			if topUser_line[2] != "" {
-				fullUsername = name + " (" + topUser_line[2] + ")"
+			if topUser_line[statusColumn] != "" {
+				fullUsername = name + " (" + topUser_line[statusColumn] + ")"
 			}
 		}
 
@@ -311,15 +380,19 @@ func writeHistoryOutput(historyOutputFilename string, inputFilename string, data
 		historicDataSlice = append(historicDataSlice, pivotRecords[index])
 	}
 
+	if len(historicDataSlice) == 1 {
+		return fmt.Errorf("No top submitter reached the rank %d in a month of the history", historyRank)
+	}
+
+	// Keep only the requested months
+	historicDataSlice, err = selectHistoryWindow(historicDataSlice, historyFromMonth, historyMonths)
+	if err != nil {
+		return err
+	}
+
 	//figure out what the output directory is
 	historyBasePath := filepath.Dir(historyOutputFilename)
-	plotPath := ""
-	if dataType == InputTypeCommenters {
-		plotPath = filepath.Join(historyBasePath, "commentersPlot")
-	} else {
-		plotPath = filepath.Join(historyBasePath, "plot")
-	}
-	
+	plotPath := filepath.Join(historyBasePath, getPlotDirName(dataType))
 
 	//Create it as it doesn't exist and plot doesn't like that.
 	err = os.MkdirAll(plotPath, os.ModePerm)
@@ -334,9 +407,36 @@ func writeHistoryOutput(historyOutputFilename string, inputFilename string, data
 	}
 
 	//Write the CSV
-	writeCSVtoFile(historyOutputFilename, historicDataSlice)
+	return writeCSVtoFile(historyOutputFilename, historicDataSlice)
+}
 
-	return nil
+// Appends the full history, as a collapsible section, at the end of the Markdown output
+func appendHistoryToMarkdown(markdownFileName string, historyFileName string, dataType InputType) error {
+	historicData, err := loadInputPivotTable(historyFileName)
+	if err != nil {
+		return err
+	}
+
+	// The first column of the pivot table has no title
+	historicData[0][0] = "Submitter"
+	if dataType == InputTypeCommenters {
+		historicData[0][0] = "Commenter"
+	}
+
+	f, err := os.OpenFile(markdownFileName, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	out := bufio.NewWriter(f)
+
+	// The blank lines are needed for the table to be rendered inside the HTML block
+	fmt.Fprint(out, "\n<details>\n<summary>Full history</summary>\n\n")
+	if err := writeMarkdownTable(out, historicData, nil, dataType); err != nil {
+		return err
+	}
+	fmt.Fprint(out, "\n</details>\n")
+	return out.Flush()
 }
 
 // returns the index in the pivot record's slice with the supplied name.
@@ -28,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -221,7 +222,7 @@ func Test_writeMarkdownFile(t *testing.T) {
 
 	// Execute function under test
 	isHistory := false
-	writeDataAsMarkdown(testOutputFilename, data, introductionText, isHistory, InputTypeSubmitters)
+	assert.NoError(t, writeDataAsMarkdown(testOutputFilename, data, introductionText, isHistory, InputTypeSubmitters))
 
 	// result validation
 	assert.NoError(t, isFileEquivalent(testOutputFilename, goldenMarkdownFilename))
@@ -236,7 +237,7 @@ func Test_writeMarkdownFile_withLinks(t *testing.T) {
 	assert.NotEmpty(t, goldenMarkdownFilename, "Failure to duplicate test file")
 
 	// Setup input data
-	testOutputFilename := tempDir + "markdown_output.md"
+	testOutputFilename := filepath.Join(tempDir, "markdown_output.md")
 	introductionText := "# Extract\n"
 	data := [][]string{
 		{"Submitter", "Total_PRs"},
@@ -253,10 +254,16 @@ func Test_writeMarkdownFile_withLinks(t *testing.T) {
 		{"gounthar", "208"},
 		{"mawinter69", "179"},
 		{"daniel-beck", "164"}}
+	// Only the generated plots are linked
+	var users []string
+	for _, dataLine := range data[1:] {
+		users = append(users, dataLine[0])
+	}
+	createPlotFiles(t, tempDir, users...)
 
 	// Execute function under test
 	isHistory := true
-	writeDataAsMarkdown(testOutputFilename, data, introductionText, isHistory, InputTypeSubmitters)
+	assert.NoError(t, writeDataAsMarkdown(testOutputFilename, data, introductionText, isHistory, InputTypeSubmitters))
 
 	// result validation
 	assert.NoError(t, isFileEquivalent(testOutputFilename, goldenMarkdownFilename))
@@ -399,6 +406,30 @@ func Test_writeHistoryOutput_noTopUserData(t *testing.T) {
 	assert.EqualErrorf(t, writeErr, "The generated top user data seems empty.", "Function under test should have failed")
 }
 
+func Test_getPlottedUsers(t *testing.T) {
+	tempDir := t.TempDir()
+	createPlotFiles(t, tempDir, "alpha")
+	markdownFile := filepath.Join(tempDir, "top.md")
+
+	assert.Equal(t, map[string]bool{"alpha": true}, getPlottedUsers(markdownFile, true, InputTypeSubmitters))
+	assert.Empty(t, getPlottedUsers(markdownFile, true, InputTypeCommenters))
+	assert.Nil(t, getPlottedUsers(markdownFile, false, InputTypeSubmitters))
+
+	var sb strings.Builder
+	assert.NoError(t, writeMarkdownTable(&sb, [][]string{{"Submitter", "Total_PRs"}, {"alpha", "3"}, {"bravo", "2"}}, getPlottedUsers(markdownFile, true, InputTypeSubmitters), InputTypeSubmitters))
+	assert.Contains(t, sb.String(), "| [alpha](plot/alpha.png) |")
+	assert.Contains(t, sb.String(), "| bravo     |")
+}
+
+// Creates empty plot files of the given users next to the files of the directory
+func createPlotFiles(t *testing.T, dir string, users ...string) {
+	plotDir := filepath.Join(dir, "plot")
+	assert.NoError(t, os.MkdirAll(plotDir, os.ModePerm))
+	for _, user := range users {
+		assert.NoError(t, os.WriteFile(filepath.Join(plotDir, user+".png"), nil, 0644))
+	}
+}
+
 func Test_writeHistoryOutput_noPivotTableData(t *testing.T) {
 	// Setup environment
 	inputPivotTableName := "../test_data/noData_overview.csv"
@@ -458,6 +489,20 @@ func Test_writeHistoryOutput_compareWithWrongHeader(t *testing.T) {
 	assert.EqualErrorf(t, writeErr, expectedErrorMessage, "Function under test should have failed")
 }
 
+func Test_appendHistoryToMarkdown(t *testing.T) {
+	tempDir := t.TempDir()
+	markdownFilename := tempDir + "/top.md"
+	assert.NoError(t, os.WriteFile(markdownFilename, []byte("| Submitter | Total_PRs |\n"), 0644))
+
+	err := appendHistoryToMarkdown(markdownFilename, "../test_data/historicCompare_reference.csv", InputTypeCommenters)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(markdownFilename)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "| Submitter | Total_PRs |\n\n<details>\n<summary>Full history</summary>\n\n| Commenter "))
+	assert.True(t, strings.HasSuffix(string(content), " |\n\n</details>\n"))
+}
+
 func Test_getIndexInPivotTable(t *testing.T) {
 	testInputSlice := [][]string{
 		{"", "month_1", "month_2", "month_3"},
@@ -532,6 +577,16 @@ func Test_CheckDir(t *testing.T) {
 			args{file: "../junkDir/fle-1.txt"},
 			true,
 		},
+		{
+			"Directory is a file",
+			args{file: "../test_data/deleted_user_case.csv/fle-1.txt"},
+			true,
+		},
+		{
+			"Directory with a trailing separator",
+			args{file: "../test_data//fle-1.txt"},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
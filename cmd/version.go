@@ -24,6 +24,7 @@ THE SOFTWARE.
 
 import (
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -35,6 +36,7 @@ var (
 	commit     = "none"
 	date       = "unknown"
 	builtBy    = ""
+	goVersion  = ""
 	versionCmd = &cobra.Command{
 		Use:   "version",
 		Short: "Displays the version and build information",
@@ -51,7 +53,7 @@ var (
 						prettyPrintedDate = fmt.Sprint(error)
 					}
 				}
-				response = fmt.Sprintf("jenkins-contribution-aggregator :\n- version:  %s\n- commit:   %s\n- date:     %s\n- built by: %s\n", version, commit, prettyPrintedDate, builtBy)
+				response = fmt.Sprintf("jenkins-contribution-aggregator :\n- version:  %s\n- commit:   %s\n- date:     %s\n- built by: %s\n- go:       %s\n", version, commit, prettyPrintedDate, builtBy, getGoVersion())
 			} else {
 				response = fmt.Sprintf("jenkins-contribution-aggregator version: %s\n", version)
 			}
@@ -64,4 +66,23 @@ var (
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.Flags().BoolVarP(&detailed, "detailed", "d", false, "Prints the detailed version information")
+
+	// Enables the "--version" flag on the root command
+	rootCmd.Version = version
+	rootCmd.SetVersionTemplate("jenkins-contribution-aggregator version: {{.Version}}\n")
+}
+
+// Returns the Go version used to build the binary.
+// It can be injected with ldflags, otherwise the runtime value is used.
+func getGoVersion() string {
+	if goVersion != "" {
+		return goVersion
+	}
+	return runtime.Version()
+}
+
+// Builds the provenance line written at the top of the generated reports.
+// It is an HTML comment so that it is not displayed when the Markdown is rendered.
+func getProvenanceHeader() string {
+	return fmt.Sprintf("<!-- Generated by jenkins-contribution-aggregator version %s (commit: %s, build date: %s) -->", version, commit, date)
 }
@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getProvenanceHeader(t *testing.T) {
+	header := getProvenanceHeader()
+
+	assert.True(t, strings.HasPrefix(header, "<!--"), "Provenance header should be an HTML comment")
+	assert.True(t, strings.HasSuffix(header, "-->"), "Provenance header should be an HTML comment")
+	assert.Contains(t, header, "version "+version)
+	assert.Contains(t, header, "commit: "+commit)
+}
+
+func Test_ExecuteVersionFlag(t *testing.T) {
+	// setup the command line
+	actual := new(bytes.Buffer)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"--version"})
+	defer func() { _ = rootCmd.Flags().Set("version", "false") }()
+
+	// Execute the module under test
+	error := rootCmd.Execute()
+
+	assert.NoError(t, error, "Unexpected failure")
+	assert.Equal(t, "jenkins-contribution-aggregator version: private build\n", actual.String())
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var warningsFileName string
+
+// Where the warnings are written. They never go to the standard output so
+// that the results can be consumed by scripts.
+var warningsOutput io.Writer = os.Stderr
+
+// The strict GitHub username rule (see https://stackoverflow.com/questions/58726546/github-username-convention-using-regex)
+var strictGitHubUserRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+(?:-[a-zA-Z0-9]+)*$`)
+
+// Writes a warning on the warnings channel
+func warn(format string, a ...any) {
+	fmt.Fprintf(warningsOutput, "Warning: "+format+"\n", a...)
+}
+
+// Redirects the warnings to the file given with "--warnings-file" (if any)
+func openWarningsFile() error {
+	if warningsFileName == "" {
+		warningsOutput = os.Stderr
+		return nil
+	}
+	f, err := os.Create(warningsFileName)
+	if err != nil {
+		return fmt.Errorf("Unable to create warnings file %s: %v", warningsFileName, err)
+	}
+	warningsOutput = f
+	return nil
+}
+
+// Closes the warnings file (if any) and reverts to the standard error
+func closeWarningsFile() {
+	if f, ok := warningsOutput.(*os.File); ok && f != os.Stderr {
+		f.Close()
+	}
+	warningsOutput = os.Stderr
+}
+
+// Emits the warnings about a (valid) data line: suspicious usernames and
+// users without any activity.
+func warnAboutDataLine(dataLine []string, lineNumber int) {
+	user := dataLine[0]
+	if user != "deleted_user" && !strictGitHubUserRegexp.MatchString(user) {
+		warn("User \"%s\" at line %d is not a valid GitHub username", user, lineNumber)
+	}
+
+	for _, value := range dataLine[1:] {
+		if count, err := strconv.Atoi(value); err != nil || count != 0 {
+			return
+		}
+	}
+	warn("User \"%s\" at line %d has no activity", user, lineNumber)
+}
+
+// Emits a warning if the most recent month of the dataset is not over yet
+func warnAboutPartialMonth(lastMonth string, now time.Time) {
+	if lastMonth == now.Format("2006-01") {
+		warn("The data of the most recent month (%s) is partial", lastMonth)
+	}
+}
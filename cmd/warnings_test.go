@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_warnAboutPartialMonth(t *testing.T) {
+	out := new(bytes.Buffer)
+	warningsOutput = out
+	defer func() { warningsOutput = os.Stderr }()
+
+	now := time.Date(2023, time.April, 12, 0, 0, 0, 0, time.UTC)
+
+	warnAboutPartialMonth("2023-03", now)
+	assert.Empty(t, out.String())
+
+	warnAboutPartialMonth("2023-04", now)
+	assert.Equal(t, "Warning: The data of the most recent month (2023-04) is partial\n", out.String())
+}
+
+func Test_warningsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	warningsFile := filepath.Join(tempDir, "warnings.txt")
+	defer func() { _ = rootCmd.PersistentFlags().Set("warnings-file", "") }()
+
+	rootCmd.SetArgs([]string{"check", "../test_data/suspicious_data.csv", "--warnings-file", warningsFile})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stderr, warningsOutput, "Warnings should revert to stderr")
+	content, err := os.ReadFile(warningsFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Warning: User \"sleeper\" at line 5 has no activity")
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var notifyWebhookURL string
+
+// Number of top users included in the notification
+const webhookTopSize = 5
+
+// Summary of a generation, POSTed to the webhook
+type generationSummary struct {
+	Command   string              `json:"command"`
+	InputFile string              `json:"input_file"`
+	Period    generationPeriod    `json:"period"`
+	Users     int                 `json:"users"`
+	Total     int                 `json:"total"`
+	Top       []generationTopUser `json:"top"`
+	Artifacts []string            `json:"artifacts"`
+}
+
+type generationPeriod struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Months int    `json:"months"`
+}
+
+type generationTopUser struct {
+	User  string `json:"user"`
+	Total int    `json:"total"`
+}
+
+// Adds the flags to notify a webhook or a chat after a successful generation
+func addNotifyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&notifyWebhookURL, "notify-webhook", "", "", "URL to POST a JSON summary to after a successful generation")
+	cmd.PersistentFlags().StringVarP(&notifyDiscordURL, "notify-discord", "", "", "Discord webhook URL to post the summary to after a successful generation")
+	cmd.PersistentFlags().StringVarP(&notifyMatrixRoom, "notify-matrix", "", "", "Matrix room (ex: \"!abc:matrix.org\") to post the summary to (token in MATRIX_ACCESS_TOKEN)")
+	cmd.PersistentFlags().StringVarP(&matrixHomeserver, "matrix-homeserver", "", "https://matrix.org", "URL of the Matrix homeserver used by \"--notify-matrix\"")
+}
+
+// Builds the summary of an extraction (the header is used to compute the actual period).
+// The lines without total (the "churned" users of a compare) are ignored.
+func buildGenerationSummary(command string, inputFilename string, extraction [][]string, endMonth string, period int, artifacts []string) (generationSummary, error) {
+	header, err := loadPivotTableHeader(inputFilename)
+	if err != nil {
+		return generationSummary{}, err
+	}
+	startColumn, endColumn, startMonth, lastMonth := getBoundaries([][]string{header}, endMonth, period, 0)
+	if endColumn == 0 {
+		return generationSummary{}, fmt.Errorf("Failed to compute the period of the summary")
+	}
+
+	summary := generationSummary{
+		Command:   command,
+		InputFile: inputFilename,
+		Period:    generationPeriod{From: startMonth, To: lastMonth, Months: endColumn - startColumn + 1},
+		Top:       []generationTopUser{},
+		Artifacts: artifacts,
+	}
+	for i, line := range extraction {
+		if i == 0 || len(line) < 2 {
+			continue
+		}
+		total, err := strconv.Atoi(line[1])
+		if err != nil {
+			continue
+		}
+		summary.Users++
+		summary.Total += total
+		if len(summary.Top) < webhookTopSize {
+			summary.Top = append(summary.Top, generationTopUser{User: line[0], Total: total})
+		}
+	}
+	return summary, nil
+}
+
+// POSTs the summary to the webhook. Any non 2xx answer is an error.
+func notifyWebhook(ctx context.Context, url string, summary generationSummary) error {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Failed to notify the webhook: %v", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("Webhook answered with status %s", response.Status)
+	}
+	logVerbose("Summary sent to the webhook \"%s\"\n", url)
+	return nil
+}
+
+// Notifies the webhook and chats, if requested, of a successful extraction
+func notifyGeneration(ctx context.Context, command string, inputFilename string, extraction [][]string, artifacts []string) (err error) {
+	if notifyWebhookURL == "" && notifyDiscordURL == "" && notifyMatrixRoom == "" {
+		return nil
+	}
+	span := startSpan("publish")
+	span.setAttribute("destination", "notifications")
+	defer func() { span.finish(err) }()
+
+	summary, err := buildGenerationSummary(command, inputFilename, extraction, endMonth, period, artifacts)
+	if err != nil {
+		return err
+	}
+	if notifyWebhookURL != "" {
+		if err := notifyWebhook(ctx, notifyWebhookURL, summary); err != nil {
+			return err
+		}
+	}
+	if notifyDiscordURL != "" {
+		if err := notifyDiscord(ctx, notifyDiscordURL, summary); err != nil {
+			return err
+		}
+	}
+	if notifyMatrixRoom != "" {
+		return notifyMatrix(ctx, matrixHomeserver, notifyMatrixRoom, summary, time.Now())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildGenerationSummary(t *testing.T) {
+	extraction := [][]string{
+		{"Submitter", "Total_PRs", "Status"},
+		{"alpha", "12", ""},
+		{"bravo", "7", "new"},
+		{"charlie", "", "churned"},
+	}
+
+	summary, err := buildGenerationSummary("compare", "../test_data/overview.csv", extraction, "2023-03", 3, []string{"out.csv"})
+
+	assert.NoError(t, err)
+	expected := generationSummary{
+		Command:   "compare",
+		InputFile: "../test_data/overview.csv",
+		Period:    generationPeriod{From: "2023-01", To: "2023-03", Months: 3},
+		Users:     2,
+		Total:     19,
+		Top:       []generationTopUser{{User: "alpha", Total: 12}, {User: "bravo", Total: 7}},
+		Artifacts: []string{"out.csv"},
+	}
+	assert.Equal(t, expected, summary)
+}
+
+func Test_notifyWebhook(t *testing.T) {
+	var received generationSummary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	summary := generationSummary{Command: "extract", Total: 3, Top: []generationTopUser{{User: "alpha", Total: 3}}}
+	assert.NoError(t, notifyWebhook(context.Background(), server.URL, summary))
+	assert.Equal(t, summary, received)
+
+	assert.Error(t, notifyWebhook(context.Background(), server.URL+"/failing", summary))
+}
+
+func Test_ExecuteExtract_notifyWebhook(t *testing.T) {
+	var received generationSummary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	defer func() { _ = extractCmd.PersistentFlags().Set("notify-webhook", "") }()
+	outputFile := filepath.Join(t.TempDir(), "top.csv")
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "10", "-o", outputFile, "--history=false", "--notify-webhook", server.URL})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "extract", received.Command)
+	assert.Equal(t, 12, received.Period.Months)
+	assert.Len(t, received.Top, webhookTopSize)
+	assert.Equal(t, []string{outputFile}, received.Artifacts)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Validates the answer to a wizard question
+type answerValidator func(answer string) error
+
+// Runs the interactive wizard: asks the parameters, executes the resulting
+// command and prints the equivalent command line.
+func runInteractive(cmd *cobra.Command) error {
+	args, err := askWizardQuestions(cmd.InOrStdin(), cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+
+	runErr := executeWizardCommand(cmd.Context(), cmd.Root(), args)
+	if runErr != nil {
+		// Already reported by the run of the command
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\nEquivalent command:\n  %s\n", buildCommandLine(cmd.Root().Name(), args))
+	return runErr
+}
+
+// Asks the user for the operation and its parameters. It returns the corresponding
+// command line arguments.
+func askWizardQuestions(in io.Reader, out io.Writer) (args []string, err error) {
+	reader := bufio.NewReader(in)
+
+	inputFile, err := askQuestion(reader, out, "Input file (pivot table)", "", func(answer string) error {
+		if !isFileValid(answer) {
+			return fmt.Errorf("\"%s\" is not a valid file", answer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := askQuestion(reader, out, "Operation (check, extract, compare)", "extract", func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "check", "extract", "compare":
+			return nil
+		}
+		return fmt.Errorf("\"%s\" is not a supported operation", answer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	operation = strings.ToLower(operation)
+
+	args = []string{operation, inputFile}
+	if operation == "check" {
+		return args, nil
+	}
+
+	detectedType, _ := detectInputType(inputFile)
+	dataType, err := askQuestion(reader, out, "Data type (submitters, commenters)", inputTypeName(detectedType), func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "submitters", "commenters":
+			return nil
+		}
+		return fmt.Errorf("%s is an invalid input type", answer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	month, err := askQuestion(reader, out, "End month (YYYY-MM or latest)", "latest", func(answer string) error {
+		if !isValidMonth(answer, false) {
+			return fmt.Errorf("\"%s\" is an invalid month", answer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topSizeAnswer, err := askQuestion(reader, out, "Number of top users", "35", isPositiveNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	periodAnswer, err := askQuestion(reader, out, "Number of months to accumulate (0 for all)", "12", isPositiveNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	args = append(args, "--type="+strings.ToLower(dataType), "--month="+month, "--topSize="+topSizeAnswer, "--period="+periodAnswer)
+
+	if operation == "compare" {
+		compareAnswer, err := askQuestion(reader, out, "Number of months back to compare with", "3", isPositiveNumber)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--compare="+compareAnswer)
+	}
+
+	output, err := askQuestion(reader, out, "Output file (\".md\" for markdown)", "top-submitters_YYYY-MM.csv", func(answer string) error {
+		return CheckDir(answer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, "--out="+output)
+
+	return args, nil
+}
+
+// Prints the question and reads the answer. An empty answer selects the default value.
+// The question is asked again as long as the answer is not valid.
+func askQuestion(reader *bufio.Reader, out io.Writer, question string, defaultValue string, validate answerValidator) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(out, "%s: ", question)
+		}
+
+		line, err := reader.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if err != nil && (err != io.EOF || answer == "") {
+			return "", fmt.Errorf("No answer to \"%s\"", question)
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		validationErr := validate(answer)
+		if validationErr == nil {
+			return answer, nil
+		}
+		fmt.Fprintf(out, "  %v\n", validationErr)
+	}
+}
+
+// Validates that the answer is a positive (or zero) integer
+func isPositiveNumber(answer string) error {
+	value, err := strconv.Atoi(answer)
+	if err != nil || value < 0 {
+		return fmt.Errorf("\"%s\" is not a positive number", answer)
+	}
+	return nil
+}
+
+// Runs the command described by the arguments, as if it was typed on the command line
+// (its flags, hooks and context included)
+func executeWizardCommand(ctx context.Context, root *cobra.Command, args []string) error {
+	// The lock of the wizard is taken over by the run of the command
+	unlockRun()
+
+	root.SetArgs(args)
+	return root.ExecuteContext(ctx)
+}
+
+// Builds a command line that can be pasted in a shell
+func buildCommandLine(commandName string, args []string) string {
+	commandLine := commandName
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \t'\"$&|;<>()*?") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		commandLine = commandLine + " " + arg
+	}
+	return commandLine
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_askWizardQuestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			"check",
+			"../test_data/overview.csv\ncheck\n",
+			[]string{"check", "../test_data/overview.csv"},
+			false,
+		},
+		{
+			"extract with defaults",
+			"../test_data/overview.csv\n\n\n\n\n\n\n",
+			[]string{"extract", "../test_data/overview.csv", "--type=submitters", "--month=latest", "--topSize=35", "--period=12", "--out=top-submitters_YYYY-MM.csv"},
+			false,
+		},
+		{
+			"compare with retries on invalid answers",
+			"junk.csv\n../test_data/overview.csv\ncompare\ncommenters\n2023-13\n2023-02\n-1\n10\n6\n2\nout.md\n",
+			[]string{"compare", "../test_data/overview.csv", "--type=commenters", "--month=2023-02", "--topSize=10", "--period=6", "--compare=2", "--out=out.md"},
+			false,
+		},
+		{
+			"no answer",
+			"../test_data/overview.csv\n",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			gotArgs, err := askWizardQuestions(strings.NewReader(tt.input), out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("askWizardQuestions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("askWizardQuestions() = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_buildCommandLine(t *testing.T) {
+	got := buildCommandLine("jenkins-contribution-aggregator", []string{"extract", "my data.csv", "--out=out.md"})
+	assert.Equal(t, "jenkins-contribution-aggregator extract 'my data.csv' --out=out.md", got)
+}
+
+func Test_ExecuteInteractive_integrationTest(t *testing.T) {
+	// setup the command line
+	actual := new(bytes.Buffer)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetIn(strings.NewReader("../test_data/overview.csv\ncheck\n"))
+	rootCmd.SetArgs([]string{"--interactive"})
+	defer rootCmd.SetIn(nil)
+	defer func() { isInteractive = false }()
+
+	// Execute the module under test
+	error := rootCmd.Execute()
+
+	assert.NoError(t, error, "Unexpected failure")
+	assert.Contains(t, actual.String(), "Equivalent command:\n  jenkins-contribution-aggregator check ../test_data/overview.csv\n")
+}
+
+func Test_ExecuteInteractive_runsTheCommand(t *testing.T) {
+	actual := new(bytes.Buffer)
+	out := new(bytes.Buffer)
+	statsOutput = out
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetIn(strings.NewReader("../test_data/deleted_user_case.csv\ncheck\n"))
+	rootCmd.SetArgs([]string{"--interactive", "--stats"})
+	defer func() {
+		rootCmd.SetIn(nil)
+		isInteractive = false
+		_ = rootCmd.PersistentFlags().Set("stats", "false")
+		statsOutput = os.Stderr
+	}()
+
+	assert.NoError(t, rootCmd.Execute())
+
+	// The command went through the hooks of the root command (the statistics are its own)
+	assert.Contains(t, out.String(), "Run statistics of \"check\": ")
+	assert.Contains(t, out.String(), ", 7 rows processed, ")
+}
+
+func Test_ExecuteInteractive_warningsFile(t *testing.T) {
+	warningsFile := filepath.Join(t.TempDir(), "warnings.txt")
+	actual := new(bytes.Buffer)
+	rootCmd.SetOut(actual)
+	rootCmd.SetErr(actual)
+	rootCmd.SetArgs([]string{"--interactive", "--warnings-file", warningsFile})
+	defer func() {
+		rootCmd.SetIn(nil)
+		isInteractive = false
+		warningsFileName = ""
+	}()
+
+	// The wizard doesn't create the warnings file by itself
+	rootCmd.SetIn(strings.NewReader(""))
+	assert.Error(t, rootCmd.Execute())
+	assert.NoFileExists(t, warningsFile)
+
+	// The warnings of the command it runs are written in the file
+	rootCmd.SetIn(strings.NewReader("../test_data/overview.csv\ncheck\n"))
+	assert.NoError(t, rootCmd.Execute())
+	content, err := os.ReadFile(warningsFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "User \"Opa-\" at line 440 is not a valid GitHub username")
+}
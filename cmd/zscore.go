@@ -0,0 +1,108 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var isWithZScore bool
+
+// Adds the flag requesting the z-score version of the history
+func addZScoreFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVarP(&isWithZScore, "zscore", "", false, "Also writes the history as z-scores relative to each user's own history (requires \"--history\")")
+}
+
+// Returns the name of the z-score version of a history file
+func generateZScoreFilename(historyFilename string) string {
+	return strings.TrimSuffix(historyFilename, ".csv") + "_zscore.csv"
+}
+
+// Writes the z-score version of a history file
+func writeZScoreHistory(historyFilename string, zscoreFilename string) error {
+	history, err := loadInputPivotTable(historyFilename)
+	if err != nil {
+		return err
+	}
+	return writeCSVtoFile(zscoreFilename, computeZScores(history))
+}
+
+// Replaces the monthly counts of each user with their z-score: the number of standard deviations
+// from the user's mean. Only the months since the first activity of the user are taken into account,
+// the previous ones are left empty, as are the months without data. A user with a constant activity
+// has z-scores of 0.
+func computeZScores(history [][]string) [][]string {
+	result := [][]string{history[0]}
+	for _, dataLine := range history[1:] {
+		var counts []float64
+		var columns []int
+		for column := 1; column < len(dataLine); column++ {
+			if dataLine[column] == "" {
+				continue
+			}
+			// We don't treat conversion errors as the file has already been checked
+			value, _ := strconv.Atoi(dataLine[column])
+			if len(counts) == 0 && value == 0 {
+				continue
+			}
+			counts = append(counts, float64(value))
+			columns = append(columns, column)
+		}
+
+		mean, stdDev := meanAndStdDev(counts)
+		zscores := make([]string, len(dataLine))
+		zscores[0] = dataLine[0]
+		for i, value := range counts {
+			zscore := 0.0
+			if stdDev > 0 {
+				zscore = (value - mean) / stdDev
+			}
+			// Avoids the "-0.00"
+			if math.Abs(zscore) < 0.005 {
+				zscore = 0
+			}
+			zscores[columns[i]] = fmt.Sprintf("%.2f", zscore)
+		}
+		result = append(result, zscores)
+	}
+	return result
+}
+
+// Returns the mean and the (population) standard deviation of the values
+func meanAndStdDev(values []float64) (mean float64, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+	for _, value := range values {
+		stdDev += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(stdDev / float64(len(values)))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_meanAndStdDev(t *testing.T) {
+	mean, stdDev := meanAndStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.Equal(t, 5.0, mean)
+	assert.Equal(t, 2.0, stdDev)
+
+	mean, stdDev = meanAndStdDev(nil)
+	assert.Equal(t, 0.0, mean)
+	assert.Equal(t, 0.0, stdDev)
+}
+
+func Test_computeZScores(t *testing.T) {
+	history := [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "1", "3", "1", "3"},
+		{"bravo", "0", "0", "2", "8"},
+		{"charlie", "0", "5", "5", "5"},
+		{"delta", "0", "0", "0", "0"},
+		{"echo", "1", "", "3", "1"},
+	}
+
+	assert.Equal(t, [][]string{
+		{"", "2023-01", "2023-02", "2023-03", "2023-04"},
+		{"alpha", "-1.00", "1.00", "-1.00", "1.00"},
+		// The months before the first activity are ignored
+		{"bravo", "", "", "-1.00", "1.00"},
+		{"charlie", "", "0.00", "0.00", "0.00"},
+		{"delta", "", "", "", ""},
+		// The months without data are not part of the mean and stay empty
+		{"echo", "-0.71", "", "1.41", "-0.71"},
+	}, computeZScores(history))
+}
+
+func Test_ExecuteExtract_zscore(t *testing.T) {
+	tempDir := t.TempDir()
+	defer func() {
+		isWithZScore = false
+		isOutputHistory = false
+	}()
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "3", "--history", "--zscore", "-o", filepath.Join(tempDir, "top.csv")})
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(tempDir, "top_submitters_fullHistory_zscore.csv"))
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[1], "basil,-1.06,"))
+
+	rootCmd.SetArgs([]string{"extract", "../test_data/overview.csv", "-m", "latest", "-p", "12", "-t", "3", "--history=false", "--zscore", "-o", filepath.Join(tempDir, "top.csv")})
+	assert.Error(t, rootCmd.Execute())
+}
@@ -0,0 +1,234 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package pivottable reads the pivot tables generated by the Jenkins Submitters Stats scripts
+// (a line per contributor, a column per month). The contributors are streamed one by one,
+// so that services embedding it can process very large tables without loading them in memory.
+package pivottable
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Name of the first column written by the newer datamash versions
+var groupByColumnRegexp = regexp.MustCompile(`(?i)^GroupBy\(.*\)$`)
+
+// The cancellation is checked every checkInterval lines (checking every line would be too costly)
+const checkInterval = 1024
+
+// A contributor (submitter or commenter) and the monthly counts of the pivot table
+type Submitter struct {
+	Name    string
+	Line    int    // line in the pivot table (the header is line 1)
+	Counts  []int  // one count per month of the table (0 for the months without data)
+	Missing []bool // months without data (an empty cell), nil if there is data for all the months
+}
+
+// Tells whether there is no data (rather than no activity) for the month at the given index
+func (s Submitter) IsMissing(month int) bool {
+	return s.Missing != nil && s.Missing[month]
+}
+
+// Returns the total of the monthly counts
+func (s Submitter) Total() int {
+	total := 0
+	for _, count := range s.Counts {
+		total += count
+	}
+	return total
+}
+
+// A pivot table file
+type Table struct {
+	fileName string
+}
+
+// Returns the pivot table stored in the file. The file is only read when iterating.
+func Open(fileName string) *Table {
+	return &Table{fileName: fileName}
+}
+
+// Iterates over the contributors of the table. Each call reads the file again.
+func (t *Table) Rows(ctx context.Context) *Rows {
+	f, err := os.Open(t.fileName)
+	if err != nil {
+		return &Rows{err: err}
+	}
+	rows := NewRows(ctx, f)
+	rows.closer = f
+	return rows
+}
+
+// Calls fn for each contributor of the table, stopping at the first error
+func (t *Table) ForEachSubmitter(ctx context.Context, fn func(Submitter) error) error {
+	return forEach(t.Rows(ctx), fn)
+}
+
+// Calls fn for each contributor of the pivot table read from r, stopping at the first error
+func ForEachSubmitter(ctx context.Context, r io.Reader, fn func(Submitter) error) error {
+	return forEach(NewRows(ctx, r), fn)
+}
+
+func forEach(rows *Rows, fn func(Submitter) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows.Submitter()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Iterator over the contributors of a pivot table:
+//
+//	rows := table.Rows(ctx)
+//	defer rows.Close()
+//	for rows.Next() {
+//		submitter := rows.Submitter()
+//		...
+//	}
+//	if err := rows.Err(); err != nil {
+//		...
+//	}
+type Rows struct {
+	ctx       context.Context
+	reader    *csv.Reader
+	closer    io.Closer
+	months    []string
+	line      int
+	submitter Submitter
+	err       error
+}
+
+// Iterates over the contributors of the pivot table read from r
+func NewRows(ctx context.Context, r io.Reader) *Rows {
+	rows := &Rows{ctx: ctx, reader: csv.NewReader(r)}
+	// The lines are copied into the submitters
+	rows.reader.ReuseRecord = true
+	header, err := rows.reader.Read()
+	if err == io.EOF {
+		rows.err = fmt.Errorf("Empty pivot table")
+		return rows
+	}
+	if err != nil {
+		rows.err = err
+		return rows
+	}
+	header = append([]string(nil), header...)
+	NormalizeHeader(header)
+	rows.months = header[1:]
+	rows.line = 1
+	return rows
+}
+
+// Returns the months of the table (the header without the first column)
+func (r *Rows) Months() []string {
+	return r.months
+}
+
+// Reads the next contributor. Returns false at the end of the table or on error (see Err).
+func (r *Rows) Next() bool {
+	if r.err != nil || r.reader == nil {
+		return false
+	}
+	if r.line%checkInterval == 0 {
+		if err := r.ctx.Err(); err != nil {
+			r.err = err
+			return false
+		}
+	}
+
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return false
+	}
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.line++
+
+	counts := make([]int, len(record)-1)
+	var missing []bool
+	for i, value := range record[1:] {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			if missing == nil {
+				missing = make([]bool, len(counts))
+			}
+			missing[i] = true
+			continue
+		}
+		counts[i], err = strconv.Atoi(value)
+		if err != nil {
+			r.err = fmt.Errorf("Invalid count \"%s\" of \"%s\" at line %d", value, record[0], r.line)
+			return false
+		}
+	}
+	r.submitter = Submitter{Name: record[0], Line: r.line, Counts: counts, Missing: missing}
+	return true
+}
+
+// Returns the contributor read by the last call to Next
+func (r *Rows) Submitter() Submitter {
+	return r.submitter
+}
+
+// Returns the error that stopped the iteration, if any
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Closes the underlying file (if any)
+func (r *Rows) Close() error {
+	r.reader = nil
+	if r.closer == nil {
+		return nil
+	}
+	closer := r.closer
+	r.closer = nil
+	return closer.Close()
+}
+
+// Normalizes the header of a pivot table generated by any datamash version: the name of the
+// first column ("GroupBy(...)") is removed and the spaces around the months are trimmed.
+func NormalizeHeader(header []string) {
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	if len(header) > 0 && IsGroupByColumn(header[0]) {
+		header[0] = ""
+	}
+}
+
+// Tells whether a header column is the first column named by the newer datamash
+// versions (ex: "GroupBy(user.login)"), the spaces around the name being ignored
+func IsGroupByColumn(name string) bool {
+	return groupByColumnRegexp.MatchString(strings.TrimSpace(name))
+}
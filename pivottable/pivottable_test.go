@@ -0,0 +1,132 @@
+/*
+Copyright © 2026 Jean-Marc Meessen jean-marc@meessen-web.org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package pivottable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleTable = `GroupBy(user.login), 2023-01 ,2023-02
+"alpha",1,3
+"bravo",0,2
+`
+
+func Test_Rows(t *testing.T) {
+	rows := NewRows(context.Background(), strings.NewReader(sampleTable))
+	defer rows.Close()
+
+	assert.Equal(t, []string{"2023-01", "2023-02"}, rows.Months())
+	var submitters []Submitter
+	for rows.Next() {
+		submitters = append(submitters, rows.Submitter())
+	}
+
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []Submitter{
+		{Name: "alpha", Line: 2, Counts: []int{1, 3}},
+		{Name: "bravo", Line: 3, Counts: []int{0, 2}},
+	}, submitters)
+	assert.Equal(t, 4, submitters[0].Total())
+}
+
+func Test_Rows_missingMonths(t *testing.T) {
+	rows := NewRows(context.Background(), strings.NewReader(",2023-01,2023-02,2023-03\n\"alice\",1,,3\n"))
+	defer rows.Close()
+
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Err())
+	alice := rows.Submitter()
+	assert.Equal(t, []int{1, 0, 3}, alice.Counts)
+	assert.False(t, alice.IsMissing(0))
+	assert.True(t, alice.IsMissing(1))
+	assert.Equal(t, 4, alice.Total())
+}
+
+func Test_Rows_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty table", "", "Empty pivot table"},
+		{"invalid count", ",\"2023-01\"\n\"alpha\",x\n", "Invalid count \"x\" of \"alpha\" at line 2"},
+		{"wrong number of fields", ",\"2023-01\"\n\"alpha\",1,2\n", "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := NewRows(context.Background(), strings.NewReader(tt.content))
+			for rows.Next() {
+			}
+			assert.ErrorContains(t, rows.Err(), tt.wantErr)
+		})
+	}
+}
+
+func Test_Rows_canceled(t *testing.T) {
+	content := ",\"2023-01\"\n" + strings.Repeat("\"alpha\",1\n", 2*checkInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	count := 0
+	err := ForEachSubmitter(ctx, strings.NewReader(content), func(Submitter) error {
+		count++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, count, 2*checkInterval)
+}
+
+func Test_Table_ForEachSubmitter(t *testing.T) {
+	table := Open("../test_data/deleted_user_case.csv")
+
+	var names []string
+	err := table.ForEachSubmitter(context.Background(), func(submitter Submitter) error {
+		if submitter.Total() > 0 {
+			names = append(names, submitter.Name)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, names, "ADITYADAS1999")
+
+	// The iteration stops at the first error of the callback
+	stop := errors.New("stop")
+	err = table.ForEachSubmitter(context.Background(), func(Submitter) error { return stop })
+	assert.ErrorIs(t, err, stop)
+
+	err = Open("../test_data/missing.csv").ForEachSubmitter(context.Background(), func(Submitter) error { return nil })
+	assert.Error(t, err)
+}
+
+func Test_IsGroupByColumn(t *testing.T) {
+	assert.True(t, IsGroupByColumn("GroupBy(user.login)"))
+	assert.True(t, IsGroupByColumn(" groupby(comment.user.login) "))
+	assert.False(t, IsGroupByColumn(""))
+	assert.False(t, IsGroupByColumn("user"))
+}